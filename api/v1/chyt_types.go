@@ -31,6 +31,8 @@ const (
 	ChytReleaseStatusCreatingUser           ChytReleaseStatus = "CreatingUser"
 	ChytReleaseStatusUploadingIntoCypress   ChytReleaseStatus = "UploadingIntoCypress"
 	ChytReleaseStatusCreatingChPublicClique ChytReleaseStatus = "CreatingChPublicClique"
+	ChytReleaseStatusCleaningUp             ChytReleaseStatus = "CleaningUp"
+	ChytReleaseStatusWaitingForCliques      ChytReleaseStatus = "WaitingForCliques"
 	ChytReleaseStatusFinished               ChytReleaseStatus = "Finished"
 )
 
@@ -42,16 +44,29 @@ type ChytSpec struct {
 	Image    string                       `json:"image,omitempty"`
 	//+kubebuilder:default:=false
 	MakeDefault bool `json:"makeDefault"`
+	// Number of previously installed releases which are kept in Cypress after the image is changed,
+	// older releases are removed from Cypress. Files of a release are read from Cypress after its upload.
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	KeepPreviousReleases int32 `json:"keepPreviousReleases,omitempty"`
 }
 
 // ChytStatus defines the observed state of Chyt
 type ChytStatus struct {
 	Conditions    []metav1.Condition `json:"conditions,omitempty"`
 	ReleaseStatus ChytReleaseStatus  `json:"releaseStatus,omitempty"`
+	// Image of the release which is installed into Cypress.
+	InstalledImage string `json:"installedImage,omitempty"`
+	// Images of previous releases which are still kept in Cypress, the most recent first.
+	PreviousImages []string `json:"previousImages,omitempty"`
+	// Cypress paths of the files uploaded by the installed and previous releases, by image.
+	// The paths are reported by the release job, files shared by all releases are not recorded.
+	ReleasePaths map[string][]string `json:"releasePaths,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:printcolumn:name="ReleaseStatus",type="string",JSONPath=".status.releaseStatus",description="Status of release"
+//+kubebuilder:printcolumn:name="InstalledImage",type="string",JSONPath=".status.installedImage",description="Image of installed release",priority=1
 //+kubebuilder:resource:categories=ytsaurus-all;yt-all
 //+kubebuilder:subresource:status
 
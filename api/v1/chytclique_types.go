@@ -0,0 +1,123 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ChytCliqueState string
+
+const (
+	ChytCliqueStatePending  ChytCliqueState = "Pending"
+	ChytCliqueStateSynced   ChytCliqueState = "Synced"
+	ChytCliqueStateFailed   ChytCliqueState = "Failed"
+	ChytCliqueStateDeleting ChytCliqueState = "Deleting"
+)
+
+// ChytCliqueSpec defines the desired state of ChytClique
+type ChytCliqueSpec struct {
+	// Ytsaurus cluster to run the clique in. Either ytsaurus or chyt should be set.
+	//+optional
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus,omitempty"`
+	// Chyt release to run the clique with, the clique is created once the release is finished
+	// and runs in the cluster of the release.
+	//+optional
+	Chyt *corev1.LocalObjectReference `json:"chyt,omitempty"`
+	// Alias of the clique in YTsaurus, name of the resource is used by default.
+	//+optional
+	Alias string `json:"alias,omitempty"`
+	// Pool to run the clique operation in. The pool is created in the default pool tree if it does not exist.
+	//+kubebuilder:validation:MinLength:=1
+	Pool string `json:"pool"`
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum:=1
+	InstanceCount int32 `json:"instanceCount,omitempty"`
+	// Number of CPU cores of each instance.
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum:=1
+	InstanceCPU int32 `json:"instanceCpu,omitempty"`
+	// Total memory of each instance, it is split between memory categories of CHYT.
+	//+optional
+	InstanceMemory *resource.Quantity `json:"instanceMemory,omitempty"`
+	//+optional
+	EnableGeodata bool `json:"enableGeodata,omitempty"`
+	// Config of ClickHouse in YSON format which is merged into the config of instances,
+	// for example {settings={max_threads=8}}.
+	//+optional
+	ClickHouseConfig string `json:"clickHouseConfig,omitempty"`
+	// ACL of the clique, subjects need the use permission to run queries.
+	//+optional
+	ACL []AccessControlEntry `json:"acl,omitempty"`
+}
+
+// ChytCliqueStatus defines the observed state of ChytClique
+type ChytCliqueStatus struct {
+	State   ChytCliqueState `json:"state,omitempty"`
+	Message string          `json:"message,omitempty"`
+	// Health of the clique reported by the strawberry controller.
+	Health       string `json:"health,omitempty"`
+	HealthReason string `json:"healthReason,omitempty"`
+	// Id and state of the operation running the clique instances.
+	OperationID    string             `json:"operationId,omitempty"`
+	OperationState string             `json:"operationState,omitempty"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=chytcliques,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=chytcliques/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=chytcliques/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of clique reconciliation"
+//+kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.health",description="Health of the clique"
+//+kubebuilder:printcolumn:name="Operation",type="string",JSONPath=".status.operationId",description="Operation running the clique"
+//+kubebuilder:resource:path=chytcliques,shortName=clique,categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// ChytClique is the Schema for the chytcliques API.
+// The clique is managed via the strawberry controller and removed from YTsaurus with the resource.
+type ChytClique struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChytCliqueSpec   `json:"spec,omitempty"`
+	Status ChytCliqueStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ChytCliqueList contains a list of ChytClique
+type ChytCliqueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChytClique `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChytClique{}, &ChytCliqueList{})
+}
+
+// GetAlias returns the alias of the clique in YTsaurus.
+func (c *ChytClique) GetAlias() string {
+	if c.Spec.Alias != "" {
+		return c.Spec.Alias
+	}
+	return c.Name
+}
@@ -1,5 +1,14 @@
 package v1
 
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
 func FindFirstLocation(locations []LocationSpec, locationType LocationType) *LocationSpec {
 	for _, location := range locations {
 		if location.LocationType == locationType {
@@ -18,3 +27,98 @@ func FindAllLocations(locations []LocationSpec, locationType LocationType) []Loc
 	}
 	return result
 }
+
+// GetStateDeadline returns the deadline of the update state, nil means that there is no deadline.
+func (s *UpdateDeadlinesSpec) GetStateDeadline(state UpdateState) *time.Duration {
+	if s == nil {
+		return nil
+	}
+	if deadline, ok := s.States[state]; ok {
+		return &deadline.Duration
+	}
+	if s.Default != nil {
+		return &s.Default.Duration
+	}
+	return nil
+}
+
+// GetRollbackDeadline returns the deadline of the rollback, nil means that there is no deadline.
+func (s *UpdateDeadlinesSpec) GetRollbackDeadline() *time.Duration {
+	if s == nil {
+		return nil
+	}
+	if s.Rollback != nil {
+		return &s.Rollback.Duration
+	}
+	if s.Default != nil {
+		return &s.Default.Duration
+	}
+	return nil
+}
+
+func (w *MaintenanceWindowSpec) parse() (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule %q: %w", w.Schedule, err)
+	}
+	location := time.UTC
+	if w.TimeZone != "" {
+		location, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+		}
+	}
+	return schedule, location, nil
+}
+
+// IsMaintenanceWindowOpen checks whether one of the windows is open at the moment.
+// If all windows are closed, the time of the nearest opening is returned.
+// Absence of windows means that maintenance is always allowed.
+func IsMaintenanceWindowOpen(windows []MaintenanceWindowSpec, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+	var nextOpening time.Time
+	for i := range windows {
+		schedule, location, err := windows[i].parse()
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		localNow := now.In(location)
+		// The window is open if it has been opened during the last duration.
+		opening := schedule.Next(localNow.Add(-windows[i].Duration.Duration))
+		if !opening.IsZero() && !opening.After(localNow) {
+			return true, time.Time{}, nil
+		}
+		opening = schedule.Next(localNow)
+		if !opening.IsZero() && (nextOpening.IsZero() || opening.Before(nextOpening)) {
+			nextOpening = opening
+		}
+	}
+	return false, nextOpening, nil
+}
+
+// isMasterUpdateAllowed checks that the master update flow could be chosen for the spec.
+func isMasterUpdateAllowed(spec *YtsaurusSpec) bool {
+	switch spec.UpdateSelector {
+	case UpdateSelectorUnspecified:
+		return spec.EnableFullUpdate
+	case UpdateSelectorEverything, UpdateSelectorMasterOnly:
+		return true
+	}
+	return false
+}
+
+// GetRemoteClusters returns references to all remote clusters registered in //sys/clusters,
+// i.e. remote clusters of the cluster and of query engines.
+func (s *YtsaurusSpec) GetRemoteClusters() []corev1.LocalObjectReference {
+	refs := slices.Clone(s.RemoteClusters)
+	if s.QueryTrackers != nil {
+		for _, ref := range s.QueryTrackers.RemoteClusters {
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MasterRestoreState string
+
+const (
+	MasterRestoreStatePending            MasterRestoreState = "Pending"
+	MasterRestoreStateStoppingMasters    MasterRestoreState = "StoppingMasters"
+	MasterRestoreStateRestoringSnapshots MasterRestoreState = "RestoringSnapshots"
+	MasterRestoreStateStartingMasters    MasterRestoreState = "StartingMasters"
+	MasterRestoreStateWaitingForLeader   MasterRestoreState = "WaitingForLeader"
+	MasterRestoreStateExitingReadOnly    MasterRestoreState = "ExitingReadOnly"
+	MasterRestoreStateCompleted          MasterRestoreState = "Completed"
+	MasterRestoreStateFailed             MasterRestoreState = "Failed"
+)
+
+// MasterRestoreSourceSpec describes the location of master snapshots,
+// it has the same layout as the target of master snapshots export.
+type MasterRestoreSourceSpec struct {
+	// Persistent volume claim to take snapshots from.
+	//+optional
+	PVC *MasterSnapshotsExportPVCSpec `json:"pvc,omitempty"`
+	// S3-compatible bucket to take snapshots from.
+	//+optional
+	S3 *MasterSnapshotsExportS3Spec `json:"s3,omitempty"`
+	// Path of the backup within the source, e.g. <export path>/<backup id>.
+	// The backup contains a directory per master pod with snapshots subdirectory inside.
+	//+kubebuilder:validation:MinLength:=1
+	Path string `json:"path"`
+}
+
+// MasterRestoreSpec defines the desired state of MasterRestore
+type MasterRestoreSpec struct {
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus"`
+	Source   MasterRestoreSourceSpec      `json:"source"`
+	// Image of restore jobs. Core image is used by default, restore from S3 requires an image with MinIO client (mc).
+	//+optional
+	Image *string `json:"image,omitempty"`
+}
+
+// MasterRestoreStatus defines the observed state of MasterRestore
+type MasterRestoreStatus struct {
+	State      MasterRestoreState `json:"state,omitempty"`
+	Message    string             `json:"message,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=masterrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=masterrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=masterrestores/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of restore"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Details of the current state"
+//+kubebuilder:resource:categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// MasterRestore is the Schema for the masterrestores API.
+// Primary and secondary master cells are restored together from the same backup.
+type MasterRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MasterRestoreSpec   `json:"spec,omitempty"`
+	Status MasterRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MasterRestoreList contains a list of MasterRestore
+type MasterRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MasterRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MasterRestore{}, &MasterRestoreList{})
+}
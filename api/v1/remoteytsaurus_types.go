@@ -30,6 +30,15 @@ type RemoteYtsaurusSpec struct {
 
 	MasterConnectionSpec `json:",inline"`
 	MasterCachesSpec     `json:",inline"`
+	// Secondary master cells of the remote cluster.
+	//+optional
+	SecondaryMasters []MasterConnectionSpec `json:"secondaryMasters,omitempty"`
+	// Address of HTTP proxies of the remote cluster, required by clients without native connection, e.g. YQL agents.
+	//+optional
+	HTTPProxyAddress string `json:"httpProxyAddress,omitempty"`
+	// HTTPProxySecure is true if HTTP proxies of the remote cluster are accessed by HTTPS, e.g. by UI.
+	//+optional
+	HTTPProxySecure bool `json:"httpProxySecure,omitempty"`
 }
 
 // RemoteYtsaurusStatus defines the observed state of RemoteYtsaurus
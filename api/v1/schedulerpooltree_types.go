@@ -0,0 +1,122 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SchedulerPoolTreeState string
+
+const (
+	SchedulerPoolTreeStatePending SchedulerPoolTreeState = "Pending"
+	SchedulerPoolTreeStateSynced  SchedulerPoolTreeState = "Synced"
+	SchedulerPoolTreeStateFailed  SchedulerPoolTreeState = "Failed"
+)
+
+// SchedulerPoolSpec is a pool of the pool tree, attributes which are not specified are left intact.
+type SchedulerPoolSpec struct {
+	//+kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+	// Name of the parent pool, the pool is created at the top level of the tree if not specified.
+	// An existing pool is moved when its parent is changed.
+	//+optional
+	Parent string `json:"parent,omitempty"`
+	//+optional
+	Weight *resource.Quantity `json:"weight,omitempty"`
+	// Resources guaranteed to the pool, only cpu and memory are supported.
+	//+optional
+	StrongGuaranteeResources corev1.ResourceList `json:"strongGuaranteeResources,omitempty"`
+	//+optional
+	MaxOperationCount *int64 `json:"maxOperationCount,omitempty"`
+	//+optional
+	MaxRunningOperationCount *int64 `json:"maxRunningOperationCount,omitempty"`
+	//+optional
+	ACL []AccessControlEntry `json:"acl,omitempty"`
+}
+
+// SchedulerPoolTreeSpec defines the desired state of SchedulerPoolTree
+type SchedulerPoolTreeSpec struct {
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus"`
+	// Name of the pool tree in YTsaurus, name of the resource is used by default.
+	//+optional
+	Name string `json:"name,omitempty"`
+	// The tree consists of exec nodes having all the tags, tags must be declared in tags of exec nodes.
+	// The tree consists of all exec nodes if no tags are specified.
+	//+optional
+	NodeTags []string `json:"nodeTags,omitempty"`
+	// Make the tree the default pool tree of the cluster.
+	//+optional
+	Default bool `json:"default,omitempty"`
+	// Pools of the tree, parents are referenced by name, so the hierarchy is kept flat.
+	//+optional
+	Pools []SchedulerPoolSpec `json:"pools,omitempty"`
+}
+
+// SchedulerPoolTreeStatus defines the observed state of SchedulerPoolTree
+type SchedulerPoolTreeStatus struct {
+	State   SchedulerPoolTreeState `json:"state,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	// Differences between the cluster and the spec found by the last reconciliation.
+	// Declared attributes are brought back to the spec, pools which are not declared are kept.
+	//+optional
+	Drift      []string           `json:"drift,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=schedulerpooltrees,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=schedulerpooltrees/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=schedulerpooltrees/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of pool tree reconciliation"
+//+kubebuilder:printcolumn:name="Default",type="boolean",JSONPath=".spec.default",description="Pool tree is the default one"
+//+kubebuilder:resource:path=schedulerpooltrees,shortName=ytpooltree,categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// SchedulerPoolTree is the Schema for the schedulerpooltrees API.
+// Removal of the resource keeps the pool tree in YTsaurus, since operations may still run in its pools.
+type SchedulerPoolTree struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchedulerPoolTreeSpec   `json:"spec,omitempty"`
+	Status SchedulerPoolTreeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SchedulerPoolTreeList contains a list of SchedulerPoolTree
+type SchedulerPoolTreeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchedulerPoolTree `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SchedulerPoolTree{}, &SchedulerPoolTreeList{})
+}
+
+// GetPoolTreeName returns the name of the pool tree in YTsaurus.
+func (t *SchedulerPoolTree) GetPoolTreeName() string {
+	if t.Spec.Name != "" {
+		return t.Spec.Name
+	}
+	return t.Name
+}
@@ -30,6 +30,7 @@ const (
 	SpytReleaseStatusCreatingUserSecret   SpytReleaseStatus = "CreatingUserSecret"
 	SpytReleaseStatusCreatingUser         SpytReleaseStatus = "CreatingUser"
 	SpytReleaseStatusUploadingIntoCypress SpytReleaseStatus = "UploadingIntoCypress"
+	SpytReleaseStatusCleaningUp           SpytReleaseStatus = "CleaningUp"
 	SpytReleaseStatusFinished             SpytReleaseStatus = "Finished"
 )
 
@@ -39,12 +40,24 @@ type SpytSpec struct {
 
 	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus,omitempty"`
 	Image    string                       `json:"image,omitempty"`
+	// Number of previously installed releases which are kept in Cypress after the image is changed,
+	// older releases are removed by a cleanup job. The release version is the tag of the image.
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	KeepPreviousReleases int32 `json:"keepPreviousReleases,omitempty"`
 }
 
 // SpytStatus defines the observed state of Spyt
 type SpytStatus struct {
 	Conditions    []metav1.Condition `json:"conditions,omitempty"`
 	ReleaseStatus SpytReleaseStatus  `json:"releaseStatus,omitempty"`
+	// Image of the release which is installed into Cypress.
+	InstalledImage string `json:"installedImage,omitempty"`
+	// Images of previous releases which are still kept in Cypress, the most recent first.
+	PreviousImages []string `json:"previousImages,omitempty"`
+	// Cypress paths of the releases published by the installed and previous images, by image.
+	// The paths are reported by the release job.
+	ReleasePaths map[string][]string `json:"releasePaths,omitempty"`
 }
 
 //+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=spyts,verbs=get;list;watch;create;update;patch;delete
@@ -53,6 +66,7 @@ type SpytStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:printcolumn:name="ReleaseStatus",type="string",JSONPath=".status.releaseStatus",description="Status of release"
+//+kubebuilder:printcolumn:name="InstalledImage",type="string",JSONPath=".status.installedImage",description="Image of installed release",priority=1
 //+kubebuilder:resource:categories=ytsaurus-all;yt-all
 //+kubebuilder:subresource:status
 
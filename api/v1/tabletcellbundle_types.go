@@ -0,0 +1,103 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TabletCellBundleState string
+
+const (
+	TabletCellBundleStatePending TabletCellBundleState = "Pending"
+	TabletCellBundleStateSynced  TabletCellBundleState = "Synced"
+	TabletCellBundleStateFailed  TabletCellBundleState = "Failed"
+)
+
+// TabletCellBundleSpec defines the desired state of TabletCellBundle
+type TabletCellBundleSpec struct {
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus"`
+	// Name of the bundle in YTsaurus, name of the resource is used by default.
+	//+optional
+	Name string `json:"name,omitempty"`
+	// Tablet cells of the bundle are placed on tablet nodes matching the filter.
+	//+optional
+	NodeTagFilter string `json:"nodeTagFilter,omitempty"`
+	//+optional
+	SnapshotPrimaryMedium *string `json:"snapshotMedium,omitempty"`
+	//+optional
+	ChangelogPrimaryMedium *string `json:"changelogMedium,omitempty"`
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum:=0
+	TabletCellCount int `json:"tabletCellCount"`
+	// ACL of the bundle, it is left intact if not specified.
+	//+optional
+	ACL []AccessControlEntry `json:"acl,omitempty"`
+}
+
+// TabletCellBundleStatus defines the observed state of TabletCellBundle
+type TabletCellBundleStatus struct {
+	State   TabletCellBundleState `json:"state,omitempty"`
+	Message string                `json:"message,omitempty"`
+	// Health of the bundle as reported by YTsaurus.
+	Health          string             `json:"health,omitempty"`
+	TabletCellCount int                `json:"tabletCellCount,omitempty"`
+	Conditions      []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=tabletcellbundles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=tabletcellbundles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=tabletcellbundles/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of bundle reconciliation"
+//+kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.health",description="Health of the bundle"
+//+kubebuilder:printcolumn:name="Cells",type="integer",JSONPath=".status.tabletCellCount",description="Number of tablet cells"
+//+kubebuilder:resource:path=tabletcellbundles,shortName=tcb,categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// TabletCellBundle is the Schema for the tabletcellbundles API.
+// Removal of the resource keeps the bundle in YTsaurus, since it may still hold tables.
+type TabletCellBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TabletCellBundleSpec   `json:"spec,omitempty"`
+	Status TabletCellBundleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TabletCellBundleList contains a list of TabletCellBundle
+type TabletCellBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TabletCellBundle `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TabletCellBundle{}, &TabletCellBundleList{})
+}
+
+// GetBundleName returns the name of the bundle in YTsaurus.
+func (b *TabletCellBundle) GetBundleName() string {
+	if b.Spec.Name != "" {
+		return b.Spec.Name
+	}
+	return b.Name
+}
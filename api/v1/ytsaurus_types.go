@@ -196,6 +196,17 @@ type BundleBootstrapSpec struct {
 	TabletCellCount int `json:"tabletCellCount,omitempty"`
 }
 
+// AccessControlEntry is an entry of ACL of a YTsaurus object.
+type AccessControlEntry struct {
+	//+kubebuilder:validation:Enum={"allow","deny"}
+	//+kubebuilder:default:=allow
+	Action string `json:"action,omitempty"`
+	//+kubebuilder:validation:MinItems:=1
+	Subjects []string `json:"subjects"`
+	//+kubebuilder:validation:MinItems:=1
+	Permissions []string `json:"permissions"`
+}
+
 type BundlesBootstrapSpec struct {
 	Sys     *BundleBootstrapSpec `json:"sys,omitempty"`
 	Default *BundleBootstrapSpec `json:"default,omitempty"`
@@ -236,6 +247,45 @@ type HealthcheckProbeParams struct {
 	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
+// ComponentUpdateStrategyType string describes how pods of a component are replaced during an update.
+// +enum
+type ComponentUpdateStrategyType string
+
+const (
+	// ComponentUpdateStrategyTypeBulk removes all pods of a component and then creates new ones.
+	ComponentUpdateStrategyTypeBulk ComponentUpdateStrategyType = "Bulk"
+	// ComponentUpdateStrategyTypeRolling replaces pods of a component batch by batch,
+	// waiting for readiness of replaced pods before proceeding with the next batch.
+	// It is applied within the Stateless update flow, tablet nodes are additionally
+	// drained of tablet cells node by node within the TabletNodes update flow.
+	ComponentUpdateStrategyTypeRolling ComponentUpdateStrategyType = "Rolling"
+)
+
+type RollingUpdateSpec struct {
+	// Number of pods replaced at once, default is 1.
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// Maximum number of not ready pods during the update, default is batchSize.
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+}
+
+type CanaryUpdateSpec struct {
+	// Number of pods with the highest ordinals which get the new version.
+	//+kubebuilder:validation:Minimum:=1
+	InstanceCount int32 `json:"instanceCount"`
+}
+
+type ComponentUpdateStrategy struct {
+	//+kubebuilder:default:=Bulk
+	//+kubebuilder:validation:Enum=Bulk;Rolling
+	Type ComponentUpdateStrategyType `json:"type,omitempty"`
+	//+optional
+	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty"`
+}
+
 type InstanceSpec struct {
 	// Overrides coreImage for component.
 	//+optional
@@ -277,6 +327,17 @@ type InstanceSpec struct {
 	// Component config for native RPC bus transport.
 	//+optional
 	NativeTransport *RPCTransportSpec `json:"nativeTransport,omitempty"`
+	// Strategy of pods replacement during updates, default is to remove all pods at once.
+	//+optional
+	UpdateStrategy *ComponentUpdateStrategy `json:"updateStrategy,omitempty"`
+	// Canary update: while set, changes of the image, configs and certificates of the instance group
+	// are rolled out only to canary pods without cluster update, the rest of pods keep running the previous version.
+	// Canary pods read configs from a separate config map, the main one is changed only on promotion.
+	// Requires image of the instance group, since coreImage is shared by all components.
+	// Remove it to promote the changes to all pods with the usual update,
+	// revert the changes to abort. Supported for proxies and exec nodes.
+	//+optional
+	Canary *CanaryUpdateSpec `json:"canary,omitempty"`
 }
 
 type MasterConnectionSpec struct {
@@ -284,10 +345,29 @@ type MasterConnectionSpec struct {
 	HostAddresses []string `json:"hostAddresses,omitempty"`
 }
 
+// MasterCellRole is a role of the master cell in a multicell cluster.
+// +kubebuilder:validation:Enum=cypress_node_host;chunk_host;transaction_coordinator;dedicated_chunk_host;sequoia_node_host
+type MasterCellRole string
+
+const (
+	MasterCellRoleCypressNodeHost        MasterCellRole = "cypress_node_host"
+	MasterCellRoleChunkHost              MasterCellRole = "chunk_host"
+	MasterCellRoleTransactionCoordinator MasterCellRole = "transaction_coordinator"
+	MasterCellRoleDedicatedChunkHost     MasterCellRole = "dedicated_chunk_host"
+	MasterCellRoleSequoiaNodeHost        MasterCellRole = "sequoia_node_host"
+)
+
 type MastersSpec struct {
 	InstanceSpec         `json:",inline"`
 	MasterConnectionSpec `json:",inline"`
 
+	// Roles of the secondary master cell, e.g. chunk_host to keep chunks metadata
+	// and cypress_node_host to host portals for Cypress sharding.
+	// Roles are set in //sys/@config/multicell_manager/cell_descriptors, default roles are used if empty.
+	// Not applicable to primary masters.
+	//+optional
+	CellRoles []MasterCellRole `json:"cellRoles,omitempty"`
+
 	HostAddressLabel string `json:"hostAddressLabel,omitempty"`
 
 	MaxSnapshotCountToKeep  *int `json:"maxSnapshotCountToKeep,omitempty"`
@@ -295,6 +375,47 @@ type MastersSpec struct {
 
 	// List of sidecar containers as yaml of core/v1 Container.
 	Sidecars []string `json:"sidecars,omitempty"`
+
+	// Export of master snapshots and changelogs before master pods are removed during update.
+	//+optional
+	SnapshotsExport *MasterSnapshotsExportSpec `json:"snapshotsExport,omitempty"`
+}
+
+type MasterSnapshotsExportPVCSpec struct {
+	// Name of the persistent volume claim to store backups.
+	//+kubebuilder:validation:MinLength:=1
+	ClaimName string `json:"claimName"`
+}
+
+type MasterSnapshotsExportS3Spec struct {
+	// Endpoint of S3-compatible storage, e.g. http://minio:9000.
+	//+kubebuilder:validation:MinLength:=1
+	Endpoint string `json:"endpoint"`
+	//+kubebuilder:validation:MinLength:=1
+	Bucket string `json:"bucket"`
+	// Reference to the secret with accessKeyId and secretAccessKey keys.
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret"`
+}
+
+type MasterSnapshotsExportSpec struct {
+	// Persistent volume claim to export snapshots to.
+	//+optional
+	PVC *MasterSnapshotsExportPVCSpec `json:"pvc,omitempty"`
+	// S3-compatible bucket to export snapshots to.
+	//+optional
+	S3 *MasterSnapshotsExportS3Spec `json:"s3,omitempty"`
+	// Path within the target to store backups, each backup is a subdirectory named after its creation time.
+	// Ytsaurus name is used by default.
+	//+optional
+	Path string `json:"path,omitempty"`
+	// Number of backups to keep, older ones are removed after a successful export.
+	//+kubebuilder:default:=3
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	MaxBackupCount int32 `json:"maxBackupCount,omitempty"`
+	// Image of export jobs. Core image is used by default, export to S3 requires an image with MinIO client (mc).
+	//+optional
+	Image *string `json:"image,omitempty"`
 }
 
 type HTTPTransportSpec struct {
@@ -333,6 +454,65 @@ type RPCTransportSpec struct {
 	TLSPeerAlternativeHostName string `json:"tlsPeerAlternativeHostName,omitempty"`
 }
 
+type CertificateIssuerReference struct {
+	// Name of cert-manager issuer.
+	Name string `json:"name"`
+	//+kubebuilder:default:=Issuer
+	//+kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	//+optional
+	Kind string `json:"kind,omitempty"`
+	//+kubebuilder:default:=cert-manager.io
+	//+optional
+	Group string `json:"group,omitempty"`
+}
+
+type ManagedCertificatesSpec struct {
+	// Issuer of cert-manager Certificate objects, certificates are signed by
+	// the operator with a self-signed CA if not set, which is suitable only for development.
+	// Issuer must provide "ca.crt" in certificate secrets or caBundle must be set.
+	//+optional
+	IssuerRef *CertificateIssuerReference `json:"issuerRef,omitempty"`
+	// Issue certificate for native RPC bus transport of all components
+	// unless the TLS secret is set explicitly.
+	//+optional
+	NativeTransport bool `json:"nativeTransport,omitempty"`
+	// Issue certificate for HTTPS of HTTP proxies unless the HTTPS secret is set explicitly.
+	//+optional
+	HTTPS bool `json:"https,omitempty"`
+	// Additional DNS names for HTTPS certificate, for example, ingress hosts.
+	//+optional
+	ExtraDNSNames []string `json:"extraDnsNames,omitempty"`
+	// Lifetime of issued certificates.
+	//+kubebuilder:default:="2160h"
+	//+optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Certificates are renewed this long before expiration,
+	// pods which mount them are restarted one by one without cluster update.
+	//+kubebuilder:default:="720h"
+	//+optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+type MonitoringSpec struct {
+	// Interval between scrapes, Prometheus default is used if not set.
+	//+kubebuilder:validation:Pattern:="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	//+optional
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// Timeout of scrape, Prometheus default is used if not set.
+	//+kubebuilder:validation:Pattern:="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	//+optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// Labels of ServiceMonitor objects, for example, to match serviceMonitorSelector of Prometheus.
+	//+optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Keep only metrics with names matching any of these regular expressions.
+	//+optional
+	KeepMetrics []string `json:"keepMetrics,omitempty"`
+	// Drop metrics with names matching any of these regular expressions.
+	//+optional
+	DropMetrics []string `json:"dropMetrics,omitempty"`
+}
+
 type RPCProxiesSpec struct {
 	InstanceSpec `json:",inline"`
 	ServiceType  *corev1.ServiceType `json:"serviceType,omitempty"`
@@ -446,6 +626,10 @@ type ExecNodesSpec struct {
 	JobResources *corev1.ResourceRequirements `json:"jobResources,omitempty"`
 	//+optional
 	JobEnvironment *JobEnvironmentSpec `json:"jobEnvironment,omitempty"`
+	// If set, scheduling of new jobs is disabled on nodes before removal of pods during update,
+	// and operator waits for running jobs to finish within this timeout.
+	//+optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
 }
 
 type TabletNodesSpec struct {
@@ -510,13 +694,53 @@ type UISpec struct {
 	DirectDownload *bool `json:"directDownload,omitempty"`
 }
 
+type QueryTrackerStageSpec struct {
+	// Name of the stage, queries choose the stage by name; production is the stage of the main instances.
+	//+kubebuilder:validation:MinLength:=1
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name         string `json:"name"`
+	InstanceSpec `json:",inline"`
+}
+
 type QueryTrackerSpec struct {
 	InstanceSpec `json:",inline"`
+	// Additional stages of query tracker, e.g. testing stage running another image.
+	// Each stage keeps its state in a separate Cypress root.
+	//+optional
+	Stages []QueryTrackerStageSpec `json:"stages,omitempty"`
+	// Remote clusters available to query engines, references to RemoteYtsaurus objects.
+	// They are registered in //sys/clusters along with remote clusters of the cluster.
+	//+optional
+	RemoteClusters []corev1.LocalObjectReference `json:"remoteClusters,omitempty"`
 }
 
 type StrawberryControllerSpec struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	Image     *string                     `json:"image,omitempty"`
+	// Cypress path where the controller keeps its state.
+	//+kubebuilder:default:="//sys/strawberry"
+	//+optional
+	Root string `json:"root,omitempty"`
+	// Stage of the controller, operations started by controllers of other stages are ignored.
+	//+kubebuilder:default:=production
+	//+optional
+	Stage string `json:"stage,omitempty"`
+	// Extra names of the cluster in the controller HTTP API, the name of the cluster is always used.
+	//+optional
+	LocationAliases []string `json:"locationAliases,omitempty"`
+	// Controller families to run, only chyt is run if it is not set.
+	//+optional
+	Families []StrawberryControllerFamilySpec `json:"families,omitempty"`
+}
+
+type StrawberryControllerFamilySpec struct {
+	// Name of the family, e.g. chyt, jupyt or livy.
+	//+kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+	// Config of the family controller in YSON format, its top-level keys
+	// replace keys of the config generated by the operator.
+	//+optional
+	Config string `json:"config,omitempty"`
 }
 
 type YQLAgentSpec struct {
@@ -560,6 +784,15 @@ type CommonSpec struct {
 	//+optional
 	NativeTransport *RPCTransportSpec `json:"nativeTransport,omitempty"`
 
+	// Certificates for native RPC bus transport and HTTPS issued by the operator.
+	//+optional
+	ManagedCertificates *ManagedCertificatesSpec `json:"managedCertificates,omitempty"`
+
+	// Prometheus ServiceMonitor objects for monitoring services of components.
+	// Ignored if monitoring.coreos.com CRDs are not installed.
+	//+optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
 	// Allow prioritizing performance over data safety. Useful for tests and experiments.
 	//+kubebuilder:default:=false
 	//+optional
@@ -614,6 +847,18 @@ type YtsaurusSpec struct {
 	// UpdateSelector is an experimental field. Behaviour may change.
 	// If UpdateSelector is not empty EnableFullUpdate is ignored.
 	UpdateSelector UpdateSelector `json:"updateSelector"`
+	// UpdateDeadlines limits time of update states. If pods are not removed or recreated in time,
+	// the cluster is rolled back to the spec it was running with before the update. The object is not
+	// modified, the cluster keeps running the previous spec until the spec is changed again.
+	//+optional
+	UpdateDeadlines *UpdateDeadlinesSpec `json:"updateDeadlines,omitempty"`
+	// MaintenanceWindows restrict updates which enable safe mode or switch masters to read-only,
+	// i.e. Full, Master and TabletNodes update flows, to the listed windows. Such updates wait in
+	// WaitingForMaintenanceWindow state until a window opens. Updates are not restricted if the list is empty.
+	// Annotation cluster.ytsaurus.tech/ignore-maintenance-windows="true" starts the update immediately,
+	// the annotation is removed once the update starts.
+	//+optional
+	MaintenanceWindows []MaintenanceWindowSpec `json:"maintenanceWindows,omitempty"`
 
 	Bootstrap *BootstrapSpec `json:"bootstrap,omitempty"`
 
@@ -641,6 +886,11 @@ type YtsaurusSpec struct {
 	QueueAgents              *QueueAgentSpec           `json:"queueAgents,omitempty"`
 
 	UI *UISpec `json:"ui,omitempty"`
+
+	// Remote clusters registered in //sys/clusters and in UI, references to RemoteYtsaurus objects.
+	// Registered clusters are available for cross-cluster operations, e.g. remote copy and replicated tables.
+	//+optional
+	RemoteClusters []corev1.LocalObjectReference `json:"remoteClusters,omitempty"`
 }
 
 type ClusterState string
@@ -670,17 +920,49 @@ const (
 	UpdateStateWaitingForTabletCellsRemovingStart UpdateState = "WaitingForTabletCellsRemovingStart"
 	UpdateStateWaitingForTabletCellsRemoved       UpdateState = "WaitingForTabletCellsRemoved"
 	UpdateStateWaitingForSnapshots                UpdateState = "WaitingForSnapshots"
+	UpdateStateWaitingForMasterSnapshotsExport    UpdateState = "WaitingForMasterSnapshotsExport"
 	UpdateStateWaitingForPodsRemoval              UpdateState = "WaitingForPodsRemoval"
 	UpdateStateWaitingForPodsCreation             UpdateState = "WaitingForPodsCreation"
 	UpdateStateWaitingForMasterExitReadOnly       UpdateState = "WaitingForMasterExitReadOnly"
+	UpdateStateWaitingForMasterQuorum             UpdateState = "WaitingForMasterQuorum"
 	UpdateStateWaitingForTabletCellsRecovery      UpdateState = "WaitingForTabletCellsRecovery"
 	UpdateStateWaitingForOpArchiveUpdatingPrepare UpdateState = "WaitingForOpArchiveUpdatingPrepare"
 	UpdateStateWaitingForOpArchiveUpdate          UpdateState = "WaitingForOpArchiveUpdate"
 	UpdateStateWaitingForQTStateUpdatingPrepare   UpdateState = "WaitingForQTStateUpdatingPrepare"
 	UpdateStateWaitingForQTStateUpdate            UpdateState = "WaitingForQTStateUpdate"
 	UpdateStateWaitingForSafeModeDisabled         UpdateState = "WaitingForSafeModeDisabled"
+	UpdateStateWaitingForTabletNodesRollingUpdate UpdateState = "WaitingForTabletNodesRollingUpdate"
+	UpdateStateWaitingForExecNodesDrain           UpdateState = "WaitingForExecNodesDrain"
+	UpdateStateWaitingForExecNodesUndrain         UpdateState = "WaitingForExecNodesUndrain"
+	UpdateStateWaitingForMaintenanceWindow        UpdateState = "WaitingForMaintenanceWindow"
 )
 
+type UpdateDeadlinesSpec struct {
+	// Deadline for update states which are not listed in States.
+	//+optional
+	Default *metav1.Duration `json:"default,omitempty"`
+	// Deadlines for particular update states.
+	//+optional
+	States map[UpdateState]metav1.Duration `json:"states,omitempty"`
+	// Deadline for the whole rollback, Default is used if not set. The rollback is marked as failed
+	// when it is exceeded, but the operator keeps bringing the cluster to the previous spec.
+	//+optional
+	Rollback *metav1.Duration `json:"rollback,omitempty"`
+}
+
+type MaintenanceWindowSpec struct {
+	// Schedule of window openings in the standard cron format with five fields,
+	// for example "0 22 * * 1-5" opens the window at 22:00 on working days.
+	//+kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration of the window, the update is started only if the window is open.
+	// The update is not interrupted when the window closes.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone of the schedule in the IANA format, for example "Europe/Amsterdam", UTC by default.
+	//+optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 type TabletCellBundleInfo struct {
 	Name            string `yson:",value" json:"name"`
 	TabletCellCount int    `yson:"tablet_cell_count,attr" json:"tabletCellCount"`
@@ -717,6 +999,28 @@ const (
 	UpdateFlowFull        UpdateFlow = "Full"
 )
 
+type UpdateResult string
+
+const (
+	UpdateResultFinished   UpdateResult = "Finished"
+	UpdateResultCanceled   UpdateResult = "Canceled"
+	UpdateResultRolledBack UpdateResult = "RolledBack"
+)
+
+// UpdateHistoryEntry describes a completed or canceled update.
+type UpdateHistoryEntry struct {
+	StartTime  *metav1.Time `json:"startTime,omitempty"`
+	EndTime    metav1.Time  `json:"endTime"`
+	Flow       UpdateFlow   `json:"flow,omitempty"`
+	Components []string     `json:"components,omitempty"`
+	// Core image the cluster was running with before the update.
+	ImageFrom string `json:"imageFrom,omitempty"`
+	// Core image from the spec of the update.
+	ImageTo string       `json:"imageTo,omitempty"`
+	Result  UpdateResult `json:"result"`
+	Message string       `json:"message,omitempty"`
+}
+
 type UpdateStatus struct {
 	//+kubebuilder:default:=None
 	State      UpdateState `json:"state,omitempty"`
@@ -728,6 +1032,21 @@ type UpdateStatus struct {
 	Conditions            []metav1.Condition     `json:"conditions,omitempty"`
 	TabletCellBundles     []TabletCellBundleInfo `json:"tabletCellBundles,omitempty"`
 	MasterMonitoringPaths []string               `json:"masterMonitoringPaths,omitempty"`
+	// StateTransitionTime is the time of the last update state change.
+	StateTransitionTime *metav1.Time `json:"stateTransitionTime,omitempty"`
+	// StartTime is the time when the current update has started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// MasterInstanceCount is the number of primary masters at the current step of masters resize.
+	// Peers are added or removed one at a time, every step rebuilds snapshots, restarts masters
+	// with the new peer list and waits for the master quorum.
+	//+optional
+	MasterInstanceCount int32 `json:"masterInstanceCount,omitempty"`
+	// ImageFrom is the core image the cluster was running with when the current update has started.
+	//+optional
+	ImageFrom string `json:"imageFrom,omitempty"`
+	// ImageTo is the core image from the spec of the current update, it is kept after rollback.
+	//+optional
+	ImageTo string `json:"imageTo,omitempty"`
 }
 
 // YtsaurusStatus defines the observed state of Ytsaurus
@@ -737,6 +1056,45 @@ type YtsaurusStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
+	// UpdateHistory keeps a few most recent updates, the latest is the last.
+	//+optional
+	UpdateHistory []UpdateHistoryEntry `json:"updateHistory,omitempty"`
+	// RemoteClusters reports registration and connectivity of remote clusters.
+	//+optional
+	RemoteClusters []RemoteClusterStatus `json:"remoteClusters,omitempty"`
+	// QueryTrackerStages are additional query tracker stages deployed by the operator,
+	// resources of stages removed from the spec are deleted.
+	//+optional
+	QueryTrackerStages []QueryTrackerStageStatus `json:"queryTrackerStages,omitempty"`
+}
+
+type QueryTrackerStageStatus struct {
+	// Name of the stage.
+	Name string `json:"name"`
+	// StateImage is the image which has initialized the state of the stage,
+	// the state is initialized again when the image of the stage changes.
+	//+optional
+	StateImage string `json:"stateImage,omitempty"`
+}
+
+type RemoteClusterStatus struct {
+	// Name of the RemoteYtsaurus object, the cluster is registered under this name.
+	Name string `json:"name"`
+	// Generation of the RemoteYtsaurus object which connection is registered in //sys/clusters.
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Registered is true if the cluster is registered in //sys/clusters by the operator,
+	// registrations made by hand are neither overwritten nor removed.
+	//+optional
+	Registered bool `json:"registered,omitempty"`
+	// Connected is true if the remote cluster was reachable from the cluster by its registered connection
+	// at the last check.
+	Connected bool `json:"connected"`
+	//+optional
+	Message string `json:"message,omitempty"`
+	// Time of the last connectivity check.
+	//+optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
 }
 
 //+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurus,verbs=get;list;watch;create;update;patch;delete
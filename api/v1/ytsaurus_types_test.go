@@ -3,11 +3,13 @@ package v1_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	k8syaml "sigs.k8s.io/yaml"
 
@@ -86,3 +88,62 @@ func testMarshallUnmarshall(t *testing.T, marshall marshalFunc, unmarshall unmar
 	require.NoError(t, err)
 	require.Empty(t, cmp.Diff(serialized, reSerialized))
 }
+
+func TestUpdateStateDeadline(t *testing.T) {
+	var noDeadlines *ytv1.UpdateDeadlinesSpec
+	require.Nil(t, noDeadlines.GetStateDeadline(ytv1.UpdateStateWaitingForPodsCreation))
+
+	deadlines := &ytv1.UpdateDeadlinesSpec{
+		States: map[ytv1.UpdateState]metav1.Duration{
+			ytv1.UpdateStateWaitingForPodsCreation: {Duration: time.Hour},
+		},
+	}
+	require.Equal(t, time.Hour, *deadlines.GetStateDeadline(ytv1.UpdateStateWaitingForPodsCreation))
+	require.Nil(t, deadlines.GetStateDeadline(ytv1.UpdateStateWaitingForPodsRemoval))
+
+	require.Nil(t, deadlines.GetRollbackDeadline())
+
+	deadlines.Default = &metav1.Duration{Duration: time.Minute}
+	require.Equal(t, time.Minute, *deadlines.GetStateDeadline(ytv1.UpdateStateWaitingForPodsRemoval))
+	require.Equal(t, time.Minute, *deadlines.GetRollbackDeadline())
+
+	deadlines.Rollback = &metav1.Duration{Duration: 2 * time.Hour}
+	require.Equal(t, 2*time.Hour, *deadlines.GetRollbackDeadline())
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	isOpen, _, err := ytv1.IsMaintenanceWindowOpen(nil, now)
+	require.NoError(t, err)
+	require.True(t, isOpen)
+
+	windows := []ytv1.MaintenanceWindowSpec{
+		{Schedule: "0 22 * * 1-5", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+		{Schedule: "0 10 * * 6", Duration: metav1.Duration{Duration: 8 * time.Hour}},
+	}
+	isOpen, nextOpening, err := ytv1.IsMaintenanceWindowOpen(windows, now)
+	require.NoError(t, err)
+	require.False(t, isOpen)
+	require.True(t, nextOpening.Equal(time.Date(2024, 5, 15, 22, 0, 0, 0, time.UTC)))
+
+	// The window opened on the previous day is still open after midnight.
+	isOpen, _, err = ytv1.IsMaintenanceWindowOpen(windows, time.Date(2024, 5, 16, 1, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, isOpen)
+
+	isOpen, nextOpening, err = ytv1.IsMaintenanceWindowOpen(windows, time.Date(2024, 5, 18, 2, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.False(t, isOpen)
+	require.True(t, nextOpening.Equal(time.Date(2024, 5, 18, 10, 0, 0, 0, time.UTC)))
+
+	windows[0].TimeZone = "Europe/Amsterdam"
+	isOpen, _, err = ytv1.IsMaintenanceWindowOpen(windows, time.Date(2024, 5, 15, 20, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, isOpen)
+
+	windows[0].Schedule = "0 25 * * *"
+	_, _, err = ytv1.IsMaintenanceWindowOpen(windows, now)
+	require.Error(t, err)
+}
@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,8 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"go.ytsaurus.tech/yt/go/yson"
+
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 )
 
@@ -74,7 +77,7 @@ func (r *ytsaurusValidator) validatePrimaryMasters(newYtsaurus, oldYtsaurus *Yts
 
 	path := field.NewPath("spec").Child("primaryMasters")
 	allErrors = append(allErrors, r.validateInstanceSpec(newYtsaurus.Spec.PrimaryMasters.InstanceSpec, path)...)
-	allErrors = append(allErrors, r.validateHostAddresses(newYtsaurus, path)...)
+	allErrors = append(allErrors, r.validateHostAddresses(newYtsaurus, &newYtsaurus.Spec.PrimaryMasters, path)...)
 
 	if FindFirstLocation(newYtsaurus.Spec.PrimaryMasters.Locations, LocationTypeMasterChangelogs) == nil {
 		allErrors = append(allErrors, field.NotFound(path.Child("locations"), LocationTypeMasterChangelogs))
@@ -84,10 +87,32 @@ func (r *ytsaurusValidator) validatePrimaryMasters(newYtsaurus, oldYtsaurus *Yts
 		allErrors = append(allErrors, field.NotFound(path.Child("locations"), LocationTypeMasterSnapshots))
 	}
 
+	if export := newYtsaurus.Spec.PrimaryMasters.SnapshotsExport; export != nil {
+		exportPath := path.Child("snapshotsExport")
+		if (export.PVC == nil) == (export.S3 == nil) {
+			allErrors = append(allErrors, field.Invalid(exportPath, export, "Exactly one of pvc and s3 should be specified"))
+		}
+		if export.S3 != nil && export.Image == nil {
+			allErrors = append(allErrors, field.Required(exportPath.Child("image"), "Image with MinIO client is required for export to S3"))
+		}
+	}
+
+	if len(newYtsaurus.Spec.PrimaryMasters.CellRoles) != 0 {
+		allErrors = append(allErrors, field.Forbidden(path.Child("cellRoles"), "Cell roles could be set only for secondary masters"))
+	}
+
 	if oldYtsaurus != nil && oldYtsaurus.Spec.PrimaryMasters.CellTag != newYtsaurus.Spec.PrimaryMasters.CellTag {
 		allErrors = append(allErrors, field.Invalid(path.Child("cellTag"), newYtsaurus.Spec.PrimaryMasters.CellTag, "Could not be changed"))
 	}
 
+	if oldYtsaurus != nil && oldYtsaurus.Spec.PrimaryMasters.InstanceCount != newYtsaurus.Spec.PrimaryMasters.InstanceCount {
+		// Peers are reconfigured by the master update flow, so the change would be stuck otherwise.
+		if !isMasterUpdateAllowed(&newYtsaurus.Spec) {
+			allErrors = append(allErrors, field.Forbidden(path.Child("instanceCount"),
+				"Masters could be resized only if master update is allowed by enableFullUpdate or updateSelector"))
+		}
+	}
+
 	if newYtsaurus.Spec.PrimaryMasters.InstanceCount > 1 && !newYtsaurus.Spec.EphemeralCluster {
 		affinity := newYtsaurus.Spec.PrimaryMasters.Affinity
 		if affinity == nil || affinity.PodAntiAffinity == nil {
@@ -98,23 +123,55 @@ func (r *ytsaurusValidator) validatePrimaryMasters(newYtsaurus, oldYtsaurus *Yts
 	return allErrors
 }
 
-func (r *ytsaurusValidator) validateSecondaryMasters(newYtsaurus *Ytsaurus) field.ErrorList {
+func (r *ytsaurusValidator) validateSecondaryMasters(newYtsaurus *Ytsaurus, oldYtsaurus *Ytsaurus) field.ErrorList {
 	var allErrors field.ErrorList
-	for i, sm := range newYtsaurus.Spec.SecondaryMasters {
+
+	cellTags := map[int16]bool{
+		newYtsaurus.Spec.PrimaryMasters.CellTag: true,
+	}
+	for i := range newYtsaurus.Spec.SecondaryMasters {
+		sm := &newYtsaurus.Spec.SecondaryMasters[i]
 		path := field.NewPath("spec").Child("secondaryMasters").Index(i)
 		allErrors = append(allErrors, r.validateInstanceSpec(sm.InstanceSpec, path)...)
-		// allErrors = append(allErrors, r.validateHostAddresses(newYtsaurus.Spec.PrimaryMasters, path)...)
-		allErrors = append(allErrors, r.validateHostAddresses(newYtsaurus, path)...)
+		allErrors = append(allErrors, r.validateHostAddresses(newYtsaurus, sm, path)...)
+
+		if _, exists := cellTags[sm.CellTag]; exists {
+			allErrors = append(allErrors, field.Duplicate(path.Child("cellTag"), sm.CellTag))
+		}
+		cellTags[sm.CellTag] = true
+	}
+
+	if oldYtsaurus != nil {
+		// Master cells could not be added or removed on a running cluster,
+		// since the primary master keeps the registered cells in its snapshots.
+		path := field.NewPath("spec").Child("secondaryMasters")
+		var oldCellTags, newCellTags []int16
+		for _, sm := range oldYtsaurus.Spec.SecondaryMasters {
+			oldCellTags = append(oldCellTags, sm.CellTag)
+		}
+		for _, sm := range newYtsaurus.Spec.SecondaryMasters {
+			newCellTags = append(newCellTags, sm.CellTag)
+		}
+		if !slices.Equal(oldCellTags, newCellTags) {
+			allErrors = append(allErrors, field.Forbidden(path, "Secondary master cells could not be changed"))
+		}
+		for i, sm := range newYtsaurus.Spec.SecondaryMasters {
+			if i < len(oldYtsaurus.Spec.SecondaryMasters) && oldYtsaurus.Spec.SecondaryMasters[i].InstanceCount != sm.InstanceCount &&
+				!isMasterUpdateAllowed(&newYtsaurus.Spec) {
+				allErrors = append(allErrors, field.Forbidden(path.Index(i).Child("instanceCount"),
+					"Masters could be resized only if master update is allowed by enableFullUpdate or updateSelector"))
+			}
+		}
 	}
 
 	return allErrors
 }
 
-func (r *ytsaurusValidator) validateHostAddresses(newYtsaurus *Ytsaurus, fieldPath *field.Path) field.ErrorList {
+func (r *ytsaurusValidator) validateHostAddresses(newYtsaurus *Ytsaurus, spec *MastersSpec, fieldPath *field.Path) field.ErrorList {
 	var allErrors field.ErrorList
 
 	hostAddressesFieldPath := fieldPath.Child("hostAddresses")
-	if !ptr.Deref(newYtsaurus.Spec.PrimaryMasters.HostNetwork, newYtsaurus.Spec.HostNetwork) && len(newYtsaurus.Spec.PrimaryMasters.HostAddresses) != 0 {
+	if !ptr.Deref(spec.HostNetwork, newYtsaurus.Spec.HostNetwork) && len(spec.HostAddresses) != 0 {
 		allErrors = append(
 			allErrors,
 			field.Required(
@@ -124,13 +181,13 @@ func (r *ytsaurusValidator) validateHostAddresses(newYtsaurus *Ytsaurus, fieldPa
 		)
 	}
 
-	if len(newYtsaurus.Spec.PrimaryMasters.HostAddresses) != 0 && len(newYtsaurus.Spec.PrimaryMasters.HostAddresses) != int(newYtsaurus.Spec.PrimaryMasters.InstanceCount) {
+	if len(spec.HostAddresses) != 0 && len(spec.HostAddresses) != int(spec.InstanceCount) {
 		instanceCountFieldPath := fieldPath.Child("instanceCount")
 		allErrors = append(
 			allErrors,
 			field.Invalid(
 				hostAddressesFieldPath,
-				newYtsaurus.Spec.PrimaryMasters.HostAddresses,
+				spec.HostAddresses,
 				fmt.Sprintf("%s list length shoud be equal to %s", hostAddressesFieldPath.String(), instanceCountFieldPath.String()),
 			),
 		)
@@ -200,7 +257,7 @@ func (r *ytsaurusValidator) validateTCPProxies(newYtsaurus *Ytsaurus) field.Erro
 	return allErrors
 }
 
-func (r *ytsaurusValidator) validateDataNodes(newYtsaurus *Ytsaurus) field.ErrorList {
+func (r *ytsaurusValidator) validateDataNodes(newYtsaurus, oldYtsaurus *Ytsaurus) field.ErrorList {
 	var allErrors field.ErrorList
 
 	names := make(map[string]bool)
@@ -219,6 +276,22 @@ func (r *ytsaurusValidator) validateDataNodes(newYtsaurus *Ytsaurus) field.Error
 		}
 	}
 
+	if oldYtsaurus != nil && oldYtsaurus.Status.State == ClusterStateUpdating {
+		// Masters could be read-only during the update, so nodes could not be decommissioned.
+		for _, oldDn := range oldYtsaurus.Spec.DataNodes {
+			i := slices.IndexFunc(newYtsaurus.Spec.DataNodes, func(dn DataNodesSpec) bool {
+				return dn.Name == oldDn.Name
+			})
+			if i < 0 {
+				allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("dataNodes"),
+					fmt.Sprintf("Data nodes group %s could not be removed during the update", oldDn.Name)))
+			} else if newYtsaurus.Spec.DataNodes[i].InstanceCount < oldDn.InstanceCount {
+				allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("dataNodes").Index(i).Child("instanceCount"),
+					"Data nodes could not be scaled down during the update"))
+			}
+		}
+	}
+
 	return allErrors
 }
 
@@ -340,6 +413,21 @@ func (r *ytsaurusValidator) validateStrawberry(newYtsaurus *Ytsaurus) field.Erro
 		if newYtsaurus.Spec.Schedulers == nil {
 			allErrors = append(allErrors, field.Required(field.NewPath("spec").Child("schedulers"), "schedulers are required for strawberry"))
 		}
+
+		names := make(map[string]bool)
+		for i, family := range newYtsaurus.Spec.StrawberryController.Families {
+			path := field.NewPath("spec").Child("strawberry").Child("families").Index(i)
+			if names[family.Name] {
+				allErrors = append(allErrors, field.Duplicate(path.Child("name"), family.Name))
+			}
+			names[family.Name] = true
+			if family.Config != "" {
+				var config map[string]any
+				if err := yson.Unmarshal([]byte(family.Config), &config); err != nil {
+					allErrors = append(allErrors, field.Invalid(path.Child("config"), family.Config, err.Error()))
+				}
+			}
+		}
 	}
 
 	return allErrors
@@ -359,6 +447,16 @@ func (r *ytsaurusValidator) validateQueryTrackers(newYtsaurus *Ytsaurus) field.E
 		if newYtsaurus.Spec.Schedulers == nil {
 			allErrors = append(allErrors, field.Required(field.NewPath("spec").Child("schedulers"), "schedulers are required for queryTrackers"))
 		}
+
+		names := map[string]bool{consts.DefaultQueryTrackerStage: true}
+		for i, stage := range newYtsaurus.Spec.QueryTrackers.Stages {
+			stagePath := path.Child("stages").Index(i)
+			if names[stage.Name] {
+				allErrors = append(allErrors, field.Duplicate(stagePath.Child("name"), stage.Name))
+			}
+			names[stage.Name] = true
+			allErrors = append(allErrors, r.validateInstanceSpec(stage.InstanceSpec, stagePath)...)
+		}
 	}
 
 	return allErrors
@@ -413,7 +511,8 @@ func (r *ytsaurusValidator) validateUi(newYtsaurus *Ytsaurus) field.ErrorList {
 			if hp.Role != consts.DefaultHTTPProxyRole {
 				continue
 			}
-			if hp.Transport.HTTPSSecret == nil {
+			managedHTTPS := newYtsaurus.Spec.ManagedCertificates != nil && newYtsaurus.Spec.ManagedCertificates.HTTPS
+			if hp.Transport.HTTPSSecret == nil && !managedHTTPS {
 				allErrors = append(allErrors, field.Required(
 					field.NewPath("spec", "httpProxies").Index(i).Child("transport", "httpsSecret"),
 					fmt.Sprintf("configured HTTPS for proxy with `%s` role is required for ui.secure", consts.DefaultHTTPProxyRole)))
@@ -427,6 +526,37 @@ func (r *ytsaurusValidator) validateUi(newYtsaurus *Ytsaurus) field.ErrorList {
 
 //////////////////////////////////////////////////
 
+func (r *ytsaurusValidator) validateManagedCertificates(newYtsaurus *Ytsaurus) field.ErrorList {
+	var allErrors field.ErrorList
+
+	spec := newYtsaurus.Spec.ManagedCertificates
+	if spec != nil && !spec.NativeTransport && !spec.HTTPS {
+		allErrors = append(allErrors, field.Invalid(
+			field.NewPath("spec", "managedCertificates"),
+			spec,
+			"at least one of nativeTransport and https should be enabled"))
+	}
+
+	return allErrors
+}
+
+func (r *ytsaurusValidator) validateMaintenanceWindows(newYtsaurus *Ytsaurus) field.ErrorList {
+	var allErrors field.ErrorList
+
+	for i := range newYtsaurus.Spec.MaintenanceWindows {
+		window := &newYtsaurus.Spec.MaintenanceWindows[i]
+		path := field.NewPath("spec", "maintenanceWindows").Index(i)
+		if _, _, err := window.parse(); err != nil {
+			allErrors = append(allErrors, field.Invalid(path, window, err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrors = append(allErrors, field.Invalid(path.Child("duration"), window.Duration, "duration should be positive"))
+		}
+	}
+
+	return allErrors
+}
+
 func (r *ytsaurusValidator) validateInstanceSpec(instanceSpec InstanceSpec, path *field.Path) field.ErrorList {
 	var allErrors field.ErrorList
 
@@ -434,6 +564,16 @@ func (r *ytsaurusValidator) validateInstanceSpec(instanceSpec InstanceSpec, path
 		allErrors = append(allErrors, field.Invalid(path.Child("EnableAntiAffinity"), instanceSpec.EnableAntiAffinity, "EnableAntiAffinity is deprecated, use Affinity instead"))
 	}
 
+	if instanceSpec.Canary != nil && instanceSpec.Canary.InstanceCount >= instanceSpec.InstanceCount {
+		allErrors = append(allErrors, field.Invalid(path.Child("canary", "instanceCount"), instanceSpec.Canary.InstanceCount,
+			"canary instanceCount must be less than instanceCount"))
+	}
+
+	if instanceSpec.Canary != nil && instanceSpec.Image == nil {
+		allErrors = append(allErrors, field.Required(path.Child("image"),
+			"canary update requires image of the instance group, coreImage is shared by all components"))
+	}
+
 	if instanceSpec.Locations != nil {
 		for locationIdx, location := range instanceSpec.Locations {
 			inVolumeMount := false
@@ -453,6 +593,77 @@ func (r *ytsaurusValidator) validateInstanceSpec(instanceSpec InstanceSpec, path
 	return allErrors
 }
 
+// validateRemoteClusters forbids registering a remote cluster twice or under the name of the cluster itself.
+func (r *ytsaurusValidator) validateRemoteClusters(newYtsaurus *Ytsaurus) field.ErrorList {
+	var allErrors field.ErrorList
+
+	check := func(refs []corev1.LocalObjectReference, path *field.Path) {
+		names := map[string]bool{newYtsaurus.Name: true}
+		for i, ref := range refs {
+			if names[ref.Name] {
+				allErrors = append(allErrors, field.Duplicate(path.Index(i).Child("name"), ref.Name))
+			}
+			names[ref.Name] = true
+		}
+	}
+
+	check(newYtsaurus.Spec.RemoteClusters, field.NewPath("spec").Child("remoteClusters"))
+	if newYtsaurus.Spec.QueryTrackers != nil {
+		check(newYtsaurus.Spec.QueryTrackers.RemoteClusters, field.NewPath("spec").Child("queryTrackers").Child("remoteClusters"))
+	}
+
+	return allErrors
+}
+
+// validateCanaryUpdates forbids canary updates of components which are updated only together with the whole cluster.
+func (r *ytsaurusValidator) validateCanaryUpdates(newYtsaurus *Ytsaurus) field.ErrorList {
+	var allErrors field.ErrorList
+
+	spec := &newYtsaurus.Spec
+	path := field.NewPath("spec")
+	check := func(instanceSpec *InstanceSpec, path *field.Path) {
+		if instanceSpec != nil && instanceSpec.Canary != nil {
+			allErrors = append(allErrors, field.Forbidden(path.Child("canary"),
+				"canary update is supported only for proxies and exec nodes"))
+		}
+	}
+
+	check(&spec.Discovery.InstanceSpec, path.Child("discovery"))
+	check(&spec.PrimaryMasters.InstanceSpec, path.Child("primaryMasters"))
+	for i := range spec.SecondaryMasters {
+		check(&spec.SecondaryMasters[i].InstanceSpec, path.Child("secondaryMasters").Index(i))
+	}
+	if spec.MasterCaches != nil {
+		check(&spec.MasterCaches.InstanceSpec, path.Child("masterCaches"))
+	}
+	for i := range spec.DataNodes {
+		check(&spec.DataNodes[i].InstanceSpec, path.Child("dataNodes").Index(i))
+	}
+	for i := range spec.TabletNodes {
+		check(&spec.TabletNodes[i].InstanceSpec, path.Child("tabletNodes").Index(i))
+	}
+	if spec.Schedulers != nil {
+		check(&spec.Schedulers.InstanceSpec, path.Child("schedulers"))
+	}
+	if spec.ControllerAgents != nil {
+		check(&spec.ControllerAgents.InstanceSpec, path.Child("controllerAgents"))
+	}
+	if spec.QueryTrackers != nil {
+		check(&spec.QueryTrackers.InstanceSpec, path.Child("queryTrackers"))
+		for i := range spec.QueryTrackers.Stages {
+			check(&spec.QueryTrackers.Stages[i].InstanceSpec, path.Child("queryTrackers").Child("stages").Index(i))
+		}
+	}
+	if spec.QueueAgents != nil {
+		check(&spec.QueueAgents.InstanceSpec, path.Child("queueAgents"))
+	}
+	if spec.YQLAgents != nil {
+		check(&spec.YQLAgents.InstanceSpec, path.Child("yqlAgents"))
+	}
+
+	return allErrors
+}
+
 func (r *ytsaurusValidator) validateExistsYtsaurus(ctx context.Context, newYtsaurus *Ytsaurus) field.ErrorList {
 	var allErrors field.ErrorList
 
@@ -483,11 +694,11 @@ func (r *ytsaurusValidator) validateYtsaurus(ctx context.Context, newYtsaurus, o
 
 	allErrors = append(allErrors, r.validateDiscovery(newYtsaurus)...)
 	allErrors = append(allErrors, r.validatePrimaryMasters(newYtsaurus, oldYtsaurus)...)
-	allErrors = append(allErrors, r.validateSecondaryMasters(newYtsaurus)...)
+	allErrors = append(allErrors, r.validateSecondaryMasters(newYtsaurus, oldYtsaurus)...)
 	allErrors = append(allErrors, r.validateHTTPProxies(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateRPCProxies(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateTCPProxies(newYtsaurus)...)
-	allErrors = append(allErrors, r.validateDataNodes(newYtsaurus)...)
+	allErrors = append(allErrors, r.validateDataNodes(newYtsaurus, oldYtsaurus)...)
 	allErrors = append(allErrors, r.validateExecNodes(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateSchedulers(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateControllerAgents(newYtsaurus)...)
@@ -499,6 +710,10 @@ func (r *ytsaurusValidator) validateYtsaurus(ctx context.Context, newYtsaurus, o
 	allErrors = append(allErrors, r.validateSpyt(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateYQLAgents(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateUi(newYtsaurus)...)
+	allErrors = append(allErrors, r.validateManagedCertificates(newYtsaurus)...)
+	allErrors = append(allErrors, r.validateRemoteClusters(newYtsaurus)...)
+	allErrors = append(allErrors, r.validateCanaryUpdates(newYtsaurus)...)
+	allErrors = append(allErrors, r.validateMaintenanceWindows(newYtsaurus)...)
 	allErrors = append(allErrors, r.validateExistsYtsaurus(ctx, newYtsaurus)...)
 
 	return allErrors
@@ -0,0 +1,117 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type YtsaurusAccountState string
+
+const (
+	YtsaurusAccountStatePending YtsaurusAccountState = "Pending"
+	YtsaurusAccountStateSynced  YtsaurusAccountState = "Synced"
+	YtsaurusAccountStateFailed  YtsaurusAccountState = "Failed"
+)
+
+// AccountResourceLimits are quotas of an account, limits which are not specified are left intact.
+type AccountResourceLimits struct {
+	//+optional
+	NodeCount *int64 `json:"nodeCount,omitempty"`
+	//+optional
+	ChunkCount *int64 `json:"chunkCount,omitempty"`
+	// Disk space limits by medium name, media are derived from locations of data nodes.
+	//+optional
+	DiskSpacePerMedium map[string]resource.Quantity `json:"diskSpacePerMedium,omitempty"`
+}
+
+// AccountResourceUsage is the usage of account resources reported by YTsaurus.
+type AccountResourceUsage struct {
+	NodeCount          int64                        `json:"nodeCount"`
+	ChunkCount         int64                        `json:"chunkCount"`
+	DiskSpacePerMedium map[string]resource.Quantity `json:"diskSpacePerMedium,omitempty"`
+}
+
+// YtsaurusAccountSpec defines the desired state of YtsaurusAccount
+type YtsaurusAccountSpec struct {
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus"`
+	// Name of the account in YTsaurus, name of the resource is used by default.
+	//+optional
+	Name string `json:"name,omitempty"`
+	// Name of the parent account in YTsaurus, the account is created at the top level if not specified.
+	//+optional
+	ParentName string `json:"parentName,omitempty"`
+	//+optional
+	ResourceLimits AccountResourceLimits `json:"resourceLimits,omitempty"`
+	// ACL of the account, it is left intact if not specified.
+	//+optional
+	ACL []AccessControlEntry `json:"acl,omitempty"`
+}
+
+// YtsaurusAccountStatus defines the observed state of YtsaurusAccount
+type YtsaurusAccountStatus struct {
+	State   YtsaurusAccountState `json:"state,omitempty"`
+	Message string               `json:"message,omitempty"`
+	//+optional
+	ResourceUsage *AccountResourceUsage `json:"resourceUsage,omitempty"`
+	Conditions    []metav1.Condition    `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusaccounts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusaccounts/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of account reconciliation"
+//+kubebuilder:printcolumn:name="Parent",type="string",JSONPath=".spec.parentName",description="Parent account"
+//+kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.resourceUsage.nodeCount",description="Number of nodes used"
+//+kubebuilder:resource:path=ytsaurusaccounts,shortName=ytaccount,categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// YtsaurusAccount is the Schema for the ytsaurusaccounts API.
+// Removal of the resource keeps the account in YTsaurus, since it may still hold data.
+// Every change of resource limits is recorded as an event of the resource.
+type YtsaurusAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YtsaurusAccountSpec   `json:"spec,omitempty"`
+	Status YtsaurusAccountStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// YtsaurusAccountList contains a list of YtsaurusAccount
+type YtsaurusAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YtsaurusAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YtsaurusAccount{}, &YtsaurusAccountList{})
+}
+
+// GetAccountName returns the name of the account in YTsaurus.
+func (a *YtsaurusAccount) GetAccountName() string {
+	if a.Spec.Name != "" {
+		return a.Spec.Name
+	}
+	return a.Name
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// YtsaurusGroupSpec defines the desired state of YtsaurusGroup
+type YtsaurusGroupSpec struct {
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus"`
+	// Name of the group in YTsaurus, name of the resource is used by default.
+	//+optional
+	Name string `json:"name,omitempty"`
+	// Users and groups which are members of the group.
+	// Members which were added by the operator are removed once they are dropped from the list.
+	//+optional
+	Members []string `json:"members,omitempty"`
+}
+
+// YtsaurusGroupStatus defines the observed state of YtsaurusGroup
+type YtsaurusGroupStatus struct {
+	State   YtsaurusSubjectState `json:"state,omitempty"`
+	Message string               `json:"message,omitempty"`
+	// Whether the group was created by the operator. Groups which existed before are not removed with the resource.
+	Created bool `json:"created,omitempty"`
+	// Members which were added to the group by the operator.
+	Members    []string           `json:"members,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusgroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusgroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusgroups/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of group reconciliation"
+//+kubebuilder:resource:path=ytsaurusgroups,shortName=ytgroup,categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// YtsaurusGroup is the Schema for the ytsaurusgroups API.
+// Removal of the resource removes the group from YTsaurus if the group was created by the operator.
+type YtsaurusGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YtsaurusGroupSpec   `json:"spec,omitempty"`
+	Status YtsaurusGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// YtsaurusGroupList contains a list of YtsaurusGroup
+type YtsaurusGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YtsaurusGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YtsaurusGroup{}, &YtsaurusGroupList{})
+}
+
+// GetGroupName returns the name of the group in YTsaurus.
+func (g *YtsaurusGroup) GetGroupName() string {
+	if g.Spec.Name != "" {
+		return g.Spec.Name
+	}
+	return g.Name
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var ytsaurusgrouplog = logf.Log.WithName("ytsaurusgroup-resource")
+
+// builtinGroupNames are groups which are created by YTsaurus or by the operator itself,
+// they must not be managed by YtsaurusGroup since its removal could remove them.
+var builtinGroupNames = []string{
+	"everyone",
+	"users",
+	"superusers",
+	"admins",
+}
+
+func (r *YtsaurusGroup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-cluster-ytsaurus-tech-v1-ytsaurusgroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=cluster.ytsaurus.tech,resources=ytsaurusgroups,verbs=create;update,versions=v1,name=vytsaurusgroup.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &YtsaurusGroup{}
+
+func (r *YtsaurusGroup) validate(old *YtsaurusGroup) error {
+	var allErrors field.ErrorList
+
+	path := field.NewPath("spec").Child("name")
+	// Only new resources are checked, so the existing ones could still be removed.
+	if old == nil && slices.Contains(builtinGroupNames, r.GetGroupName()) {
+		allErrors = append(allErrors, field.Forbidden(path,
+			fmt.Sprintf("Group %s is built-in and could not be managed by YtsaurusGroup", r.GetGroupName())))
+	}
+	if old != nil && old.GetGroupName() != r.GetGroupName() {
+		allErrors = append(allErrors, field.Invalid(path, r.Spec.Name, "Could not be changed"))
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "cluster.ytsaurus.tech", Kind: "YtsaurusGroup"},
+		r.Name,
+		allErrors)
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *YtsaurusGroup) ValidateCreate() (admission.Warnings, error) {
+	ytsaurusgrouplog.Info("validate create", "name", r.Name)
+	return nil, r.validate(nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *YtsaurusGroup) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	ytsaurusgrouplog.Info("validate update", "name", r.Name)
+	oldGroup, ok := old.(*YtsaurusGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a YtsaurusGroup but got a %T", old)
+	}
+	return nil, r.validate(oldGroup)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *YtsaurusGroup) ValidateDelete() (admission.Warnings, error) {
+	ytsaurusgrouplog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// YtsaurusSubjectState is the state of reconciliation of a YTsaurus user or group.
+type YtsaurusSubjectState string
+
+const (
+	YtsaurusSubjectStatePending  YtsaurusSubjectState = "Pending"
+	YtsaurusSubjectStateSynced   YtsaurusSubjectState = "Synced"
+	YtsaurusSubjectStateFailed   YtsaurusSubjectState = "Failed"
+	YtsaurusSubjectStateDeleting YtsaurusSubjectState = "Deleting"
+)
+
+// YtsaurusUserSpec defines the desired state of YtsaurusUser
+type YtsaurusUserSpec struct {
+	Ytsaurus *corev1.LocalObjectReference `json:"ytsaurus"`
+	// Name of the user in YTsaurus, name of the resource is used by default.
+	//+optional
+	Name string `json:"name,omitempty"`
+	// Groups the user is added to. The user is removed from groups which are dropped from the list.
+	//+optional
+	MemberOf []string `json:"memberOf,omitempty"`
+	// Key of a secret holding the password of the user. The password is set again on each change of the secret.
+	//+optional
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+	// Name of a secret to hold the token of the user under the YT_TOKEN key.
+	// The secret is created with a generated token if it does not exist or has no token.
+	//+optional
+	TokenSecretName string `json:"tokenSecretName,omitempty"`
+}
+
+// YtsaurusUserStatus defines the observed state of YtsaurusUser
+type YtsaurusUserStatus struct {
+	State   YtsaurusSubjectState `json:"state,omitempty"`
+	Message string               `json:"message,omitempty"`
+	// Whether the user was created by the operator. Users which existed before are not removed with the resource.
+	Created bool `json:"created,omitempty"`
+	// Groups the user was added to by the operator.
+	MemberOf []string `json:"memberOf,omitempty"`
+	// Hash of the token which was issued to the user by the operator, it is revoked once the token is rotated.
+	TokenHash string `json:"tokenHash,omitempty"`
+	// Resource version of the password secret which was set as the password of the user.
+	PasswordSecretVersion string             `json:"passwordSecretVersion,omitempty"`
+	Conditions            []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurususers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurususers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurususers/finalizers,verbs=update
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of user reconciliation"
+//+kubebuilder:resource:path=ytsaurususers,shortName=ytuser,categories=ytsaurus-all;yt-all
+//+kubebuilder:subresource:status
+
+// YtsaurusUser is the Schema for the ytsaurususers API.
+// Removal of the resource removes the user from YTsaurus if the user was created by the operator.
+type YtsaurusUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YtsaurusUserSpec   `json:"spec,omitempty"`
+	Status YtsaurusUserStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// YtsaurusUserList contains a list of YtsaurusUser
+type YtsaurusUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YtsaurusUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YtsaurusUser{}, &YtsaurusUserList{})
+}
+
+// GetUserName returns the name of the user in YTsaurus.
+func (u *YtsaurusUser) GetUserName() string {
+	if u.Spec.Name != "" {
+		return u.Spec.Name
+	}
+	return u.Name
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+// log is for logging in this package.
+var ytsaurususerlog = logf.Log.WithName("ytsaurususer-resource")
+
+// builtinUserNames are users which are created by YTsaurus or by the operator itself,
+// they must not be managed by YtsaurusUser since its removal could remove them.
+var builtinUserNames = []string{
+	"root",
+	"guest",
+	"job",
+	"scheduler",
+	"replicator",
+	"file_cache",
+	"operations_cleaner",
+	"operations_client",
+	"tablet_cell_changelogger",
+	"tablet_cell_snapshotter",
+	"table_mount_informer",
+	"alien_cell_synchronizer",
+	"queue_agent",
+	"query_tracker",
+	"yql_agent",
+	"tablet_balancer",
+	consts.DefaultAdminLogin,
+	consts.UIUserName,
+	consts.StrawberryControllerUserName,
+	consts.YtsaurusOperatorUserName,
+}
+
+func (r *YtsaurusUser) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-cluster-ytsaurus-tech-v1-ytsaurususer,mutating=false,failurePolicy=fail,sideEffects=None,groups=cluster.ytsaurus.tech,resources=ytsaurususers,verbs=create;update,versions=v1,name=vytsaurususer.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &YtsaurusUser{}
+
+func (r *YtsaurusUser) validate(old *YtsaurusUser) error {
+	var allErrors field.ErrorList
+
+	path := field.NewPath("spec").Child("name")
+	// Only new resources are checked, so the existing ones could still be removed.
+	if old == nil && slices.Contains(builtinUserNames, r.GetUserName()) {
+		allErrors = append(allErrors, field.Forbidden(path,
+			fmt.Sprintf("User %s is built-in and could not be managed by YtsaurusUser", r.GetUserName())))
+	}
+	if old != nil && old.GetUserName() != r.GetUserName() {
+		allErrors = append(allErrors, field.Invalid(path, r.Spec.Name, "Could not be changed"))
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "cluster.ytsaurus.tech", Kind: "YtsaurusUser"},
+		r.Name,
+		allErrors)
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *YtsaurusUser) ValidateCreate() (admission.Warnings, error) {
+	ytsaurususerlog.Info("validate create", "name", r.Name)
+	return nil, r.validate(nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *YtsaurusUser) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	ytsaurususerlog.Info("validate update", "name", r.Name)
+	oldUser, ok := old.(*YtsaurusUser)
+	if !ok {
+		return nil, fmt.Errorf("expected a YtsaurusUser but got a %T", old)
+	}
+	return nil, r.validate(oldUser)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *YtsaurusUser) ValidateDelete() (admission.Warnings, error) {
+	ytsaurususerlog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
@@ -22,10 +22,90 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlEntry) DeepCopyInto(out *AccessControlEntry) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlEntry.
+func (in *AccessControlEntry) DeepCopy() *AccessControlEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountResourceLimits) DeepCopyInto(out *AccountResourceLimits) {
+	*out = *in
+	if in.NodeCount != nil {
+		in, out := &in.NodeCount, &out.NodeCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ChunkCount != nil {
+		in, out := &in.ChunkCount, &out.ChunkCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskSpacePerMedium != nil {
+		in, out := &in.DiskSpacePerMedium, &out.DiskSpacePerMedium
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountResourceLimits.
+func (in *AccountResourceLimits) DeepCopy() *AccountResourceLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountResourceLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountResourceUsage) DeepCopyInto(out *AccountResourceUsage) {
+	*out = *in
+	if in.DiskSpacePerMedium != nil {
+		in, out := &in.DiskSpacePerMedium, &out.DiskSpacePerMedium
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountResourceUsage.
+func (in *AccountResourceUsage) DeepCopy() *AccountResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseLoggerSpec) DeepCopyInto(out *BaseLoggerSpec) {
 	*out = *in
@@ -181,6 +261,21 @@ func (in *CRIJobEnvironmentSpec) DeepCopy() *CRIJobEnvironmentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpdateSpec) DeepCopyInto(out *CanaryUpdateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpdateSpec.
+func (in *CanaryUpdateSpec) DeepCopy() *CanaryUpdateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpdateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CategoriesFilter) DeepCopyInto(out *CategoriesFilter) {
 	*out = *in
@@ -201,6 +296,21 @@ func (in *CategoriesFilter) DeepCopy() *CategoriesFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerReference) DeepCopyInto(out *CertificateIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerReference.
+func (in *CertificateIssuerReference) DeepCopy() *CertificateIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Chyt) DeepCopyInto(out *Chyt) {
 	*out = *in
@@ -228,6 +338,124 @@ func (in *Chyt) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChytClique) DeepCopyInto(out *ChytClique) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChytClique.
+func (in *ChytClique) DeepCopy() *ChytClique {
+	if in == nil {
+		return nil
+	}
+	out := new(ChytClique)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChytClique) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChytCliqueList) DeepCopyInto(out *ChytCliqueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChytClique, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChytCliqueList.
+func (in *ChytCliqueList) DeepCopy() *ChytCliqueList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChytCliqueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChytCliqueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChytCliqueSpec) DeepCopyInto(out *ChytCliqueSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Chyt != nil {
+		in, out := &in.Chyt, &out.Chyt
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.InstanceMemory != nil {
+		in, out := &in.InstanceMemory, &out.InstanceMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]AccessControlEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChytCliqueSpec.
+func (in *ChytCliqueSpec) DeepCopy() *ChytCliqueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChytCliqueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChytCliqueStatus) DeepCopyInto(out *ChytCliqueStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChytCliqueStatus.
+func (in *ChytCliqueStatus) DeepCopy() *ChytCliqueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChytCliqueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChytList) DeepCopyInto(out *ChytList) {
 	*out = *in
@@ -295,6 +523,27 @@ func (in *ChytStatus) DeepCopyInto(out *ChytStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreviousImages != nil {
+		in, out := &in.PreviousImages, &out.PreviousImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReleasePaths != nil {
+		in, out := &in.ReleasePaths, &out.ReleasePaths
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChytStatus.
@@ -345,6 +594,16 @@ func (in *CommonSpec) DeepCopyInto(out *CommonSpec) {
 		*out = new(RPCTransportSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagedCertificates != nil {
+		in, out := &in.ManagedCertificates, &out.ManagedCertificates
+		*out = new(ManagedCertificatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.KeepSocket != nil {
 		in, out := &in.KeepSocket, &out.KeepSocket
 		*out = new(bool)
@@ -384,6 +643,26 @@ func (in *CommonSpec) DeepCopy() *CommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentUpdateStrategy) DeepCopyInto(out *ComponentUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentUpdateStrategy.
+func (in *ComponentUpdateStrategy) DeepCopy() *ComponentUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerAgentsSpec) DeepCopyInto(out *ControllerAgentsSpec) {
 	*out = *in
@@ -527,6 +806,11 @@ func (in *ExecNodesSpec) DeepCopyInto(out *ExecNodesSpec) {
 		*out = new(JobEnvironmentSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecNodesSpec.
@@ -735,6 +1019,16 @@ func (in *InstanceSpec) DeepCopyInto(out *InstanceSpec) {
 		*out = new(RPCTransportSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(ComponentUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryUpdateSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSpec.
@@ -852,6 +1146,57 @@ func (in *LogRotationPolicy) DeepCopy() *LogRotationPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedCertificatesSpec) DeepCopyInto(out *ManagedCertificatesSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertificateIssuerReference)
+		**out = **in
+	}
+	if in.ExtraDNSNames != nil {
+		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedCertificatesSpec.
+func (in *ManagedCertificatesSpec) DeepCopy() *ManagedCertificatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedCertificatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MasterCachesConnectionSpec) DeepCopyInto(out *MasterCachesConnectionSpec) {
 	*out = *in
@@ -910,43 +1255,278 @@ func (in *MasterConnectionSpec) DeepCopy() *MasterConnectionSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MastersSpec) DeepCopyInto(out *MastersSpec) {
+func (in *MasterRestore) DeepCopyInto(out *MasterRestore) {
 	*out = *in
-	in.InstanceSpec.DeepCopyInto(&out.InstanceSpec)
-	in.MasterConnectionSpec.DeepCopyInto(&out.MasterConnectionSpec)
-	if in.MaxSnapshotCountToKeep != nil {
-		in, out := &in.MaxSnapshotCountToKeep, &out.MaxSnapshotCountToKeep
-		*out = new(int)
-		**out = **in
-	}
-	if in.MaxChangelogCountToKeep != nil {
-		in, out := &in.MaxChangelogCountToKeep, &out.MaxChangelogCountToKeep
-		*out = new(int)
-		**out = **in
-	}
-	if in.Sidecars != nil {
-		in, out := &in.Sidecars, &out.Sidecars
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MastersSpec.
-func (in *MastersSpec) DeepCopy() *MastersSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterRestore.
+func (in *MasterRestore) DeepCopy() *MasterRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(MastersSpec)
+	out := new(MasterRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OauthServiceSpec) DeepCopyInto(out *OauthServiceSpec) {
-	*out = *in
-	in.UserInfo.DeepCopyInto(&out.UserInfo)
-}
-
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MasterRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterRestoreList) DeepCopyInto(out *MasterRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MasterRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterRestoreList.
+func (in *MasterRestoreList) DeepCopy() *MasterRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MasterRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterRestoreSourceSpec) DeepCopyInto(out *MasterRestoreSourceSpec) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(MasterSnapshotsExportPVCSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(MasterSnapshotsExportS3Spec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterRestoreSourceSpec.
+func (in *MasterRestoreSourceSpec) DeepCopy() *MasterRestoreSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterRestoreSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterRestoreSpec) DeepCopyInto(out *MasterRestoreSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterRestoreSpec.
+func (in *MasterRestoreSpec) DeepCopy() *MasterRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterRestoreStatus) DeepCopyInto(out *MasterRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterRestoreStatus.
+func (in *MasterRestoreStatus) DeepCopy() *MasterRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterSnapshotsExportPVCSpec) DeepCopyInto(out *MasterSnapshotsExportPVCSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterSnapshotsExportPVCSpec.
+func (in *MasterSnapshotsExportPVCSpec) DeepCopy() *MasterSnapshotsExportPVCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterSnapshotsExportPVCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterSnapshotsExportS3Spec) DeepCopyInto(out *MasterSnapshotsExportS3Spec) {
+	*out = *in
+	out.CredentialsSecret = in.CredentialsSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterSnapshotsExportS3Spec.
+func (in *MasterSnapshotsExportS3Spec) DeepCopy() *MasterSnapshotsExportS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterSnapshotsExportS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterSnapshotsExportSpec) DeepCopyInto(out *MasterSnapshotsExportSpec) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(MasterSnapshotsExportPVCSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(MasterSnapshotsExportS3Spec)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterSnapshotsExportSpec.
+func (in *MasterSnapshotsExportSpec) DeepCopy() *MasterSnapshotsExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterSnapshotsExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MastersSpec) DeepCopyInto(out *MastersSpec) {
+	*out = *in
+	in.InstanceSpec.DeepCopyInto(&out.InstanceSpec)
+	in.MasterConnectionSpec.DeepCopyInto(&out.MasterConnectionSpec)
+	if in.CellRoles != nil {
+		in, out := &in.CellRoles, &out.CellRoles
+		*out = make([]MasterCellRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxSnapshotCountToKeep != nil {
+		in, out := &in.MaxSnapshotCountToKeep, &out.MaxSnapshotCountToKeep
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxChangelogCountToKeep != nil {
+		in, out := &in.MaxChangelogCountToKeep, &out.MaxChangelogCountToKeep
+		*out = new(int)
+		**out = **in
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SnapshotsExport != nil {
+		in, out := &in.SnapshotsExport, &out.SnapshotsExport
+		*out = new(MasterSnapshotsExportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MastersSpec.
+func (in *MastersSpec) DeepCopy() *MastersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MastersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KeepMetrics != nil {
+		in, out := &in.KeepMetrics, &out.KeepMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DropMetrics != nil {
+		in, out := &in.DropMetrics, &out.DropMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OauthServiceSpec) DeepCopyInto(out *OauthServiceSpec) {
+	*out = *in
+	in.UserInfo.DeepCopyInto(&out.UserInfo)
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OauthServiceSpec.
 func (in *OauthServiceSpec) DeepCopy() *OauthServiceSpec {
 	if in == nil {
@@ -981,6 +1561,18 @@ func (in *OauthUserInfoHandlerSpec) DeepCopy() *OauthUserInfoHandlerSpec {
 func (in *QueryTrackerSpec) DeepCopyInto(out *QueryTrackerSpec) {
 	*out = *in
 	in.InstanceSpec.DeepCopyInto(&out.InstanceSpec)
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]QueryTrackerStageSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTrackerSpec.
@@ -993,6 +1585,37 @@ func (in *QueryTrackerSpec) DeepCopy() *QueryTrackerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryTrackerStageSpec) DeepCopyInto(out *QueryTrackerStageSpec) {
+	*out = *in
+	in.InstanceSpec.DeepCopyInto(&out.InstanceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTrackerStageSpec.
+func (in *QueryTrackerStageSpec) DeepCopy() *QueryTrackerStageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryTrackerStageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryTrackerStageStatus) DeepCopyInto(out *QueryTrackerStageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTrackerStageStatus.
+func (in *QueryTrackerStageStatus) DeepCopy() *QueryTrackerStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryTrackerStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueueAgentSpec) DeepCopyInto(out *QueueAgentSpec) {
 	*out = *in
@@ -1056,6 +1679,25 @@ func (in *RPCTransportSpec) DeepCopy() *RPCTransportSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterStatus.
+func (in *RemoteClusterStatus) DeepCopy() *RemoteClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteExecNodes) DeepCopyInto(out *RemoteExecNodes) {
 	*out = *in
@@ -1216,6 +1858,13 @@ func (in *RemoteYtsaurusSpec) DeepCopyInto(out *RemoteYtsaurusSpec) {
 	*out = *in
 	in.MasterConnectionSpec.DeepCopyInto(&out.MasterConnectionSpec)
 	in.MasterCachesSpec.DeepCopyInto(&out.MasterCachesSpec)
+	if in.SecondaryMasters != nil {
+		in, out := &in.SecondaryMasters, &out.SecondaryMasters
+		*out = make([]MasterConnectionSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteYtsaurusSpec.
@@ -1244,25 +1893,212 @@ func (in *RemoteYtsaurusStatus) DeepCopy() *RemoteYtsaurusStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchedulersSpec) DeepCopyInto(out *SchedulersSpec) {
+func (in *RollingUpdateSpec) DeepCopyInto(out *RollingUpdateSpec) {
 	*out = *in
-	in.InstanceSpec.DeepCopyInto(&out.InstanceSpec)
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulersSpec.
-func (in *SchedulersSpec) DeepCopy() *SchedulersSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateSpec.
+func (in *RollingUpdateSpec) DeepCopy() *RollingUpdateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SchedulersSpec)
+	out := new(RollingUpdateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Spyt) DeepCopyInto(out *Spyt) {
+func (in *SchedulerPoolSpec) DeepCopyInto(out *SchedulerPoolSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StrongGuaranteeResources != nil {
+		in, out := &in.StrongGuaranteeResources, &out.StrongGuaranteeResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxOperationCount != nil {
+		in, out := &in.MaxOperationCount, &out.MaxOperationCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRunningOperationCount != nil {
+		in, out := &in.MaxRunningOperationCount, &out.MaxRunningOperationCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]AccessControlEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPoolSpec.
+func (in *SchedulerPoolSpec) DeepCopy() *SchedulerPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerPoolTree) DeepCopyInto(out *SchedulerPoolTree) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPoolTree.
+func (in *SchedulerPoolTree) DeepCopy() *SchedulerPoolTree {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPoolTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchedulerPoolTree) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerPoolTreeList) DeepCopyInto(out *SchedulerPoolTreeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchedulerPoolTree, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPoolTreeList.
+func (in *SchedulerPoolTreeList) DeepCopy() *SchedulerPoolTreeList {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPoolTreeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchedulerPoolTreeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerPoolTreeSpec) DeepCopyInto(out *SchedulerPoolTreeSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.NodeTags != nil {
+		in, out := &in.NodeTags, &out.NodeTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]SchedulerPoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPoolTreeSpec.
+func (in *SchedulerPoolTreeSpec) DeepCopy() *SchedulerPoolTreeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPoolTreeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerPoolTreeStatus) DeepCopyInto(out *SchedulerPoolTreeStatus) {
+	*out = *in
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPoolTreeStatus.
+func (in *SchedulerPoolTreeStatus) DeepCopy() *SchedulerPoolTreeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPoolTreeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulersSpec) DeepCopyInto(out *SchedulersSpec) {
+	*out = *in
+	in.InstanceSpec.DeepCopyInto(&out.InstanceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulersSpec.
+func (in *SchedulersSpec) DeepCopy() *SchedulersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Spyt) DeepCopyInto(out *Spyt) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
@@ -1353,6 +2189,27 @@ func (in *SpytStatus) DeepCopyInto(out *SpytStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreviousImages != nil {
+		in, out := &in.PreviousImages, &out.PreviousImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReleasePaths != nil {
+		in, out := &in.ReleasePaths, &out.ReleasePaths
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpytStatus.
@@ -1365,6 +2222,21 @@ func (in *SpytStatus) DeepCopy() *SpytStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StrawberryControllerFamilySpec) DeepCopyInto(out *StrawberryControllerFamilySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StrawberryControllerFamilySpec.
+func (in *StrawberryControllerFamilySpec) DeepCopy() *StrawberryControllerFamilySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StrawberryControllerFamilySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StrawberryControllerSpec) DeepCopyInto(out *StrawberryControllerSpec) {
 	*out = *in
@@ -1374,6 +2246,16 @@ func (in *StrawberryControllerSpec) DeepCopyInto(out *StrawberryControllerSpec)
 		*out = new(string)
 		**out = **in
 	}
+	if in.LocationAliases != nil {
+		in, out := &in.LocationAliases, &out.LocationAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Families != nil {
+		in, out := &in.Families, &out.Families
+		*out = make([]StrawberryControllerFamilySpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StrawberryControllerSpec.
@@ -1423,6 +2305,33 @@ func (in *TCPProxiesSpec) DeepCopy() *TCPProxiesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletCellBundle) DeepCopyInto(out *TabletCellBundle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletCellBundle.
+func (in *TabletCellBundle) DeepCopy() *TabletCellBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletCellBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TabletCellBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TabletCellBundleInfo) DeepCopyInto(out *TabletCellBundleInfo) {
 	*out = *in
@@ -1438,6 +2347,97 @@ func (in *TabletCellBundleInfo) DeepCopy() *TabletCellBundleInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletCellBundleList) DeepCopyInto(out *TabletCellBundleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TabletCellBundle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletCellBundleList.
+func (in *TabletCellBundleList) DeepCopy() *TabletCellBundleList {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletCellBundleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TabletCellBundleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletCellBundleSpec) DeepCopyInto(out *TabletCellBundleSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SnapshotPrimaryMedium != nil {
+		in, out := &in.SnapshotPrimaryMedium, &out.SnapshotPrimaryMedium
+		*out = new(string)
+		**out = **in
+	}
+	if in.ChangelogPrimaryMedium != nil {
+		in, out := &in.ChangelogPrimaryMedium, &out.ChangelogPrimaryMedium
+		*out = new(string)
+		**out = **in
+	}
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]AccessControlEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletCellBundleSpec.
+func (in *TabletCellBundleSpec) DeepCopy() *TabletCellBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletCellBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletCellBundleStatus) DeepCopyInto(out *TabletCellBundleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletCellBundleStatus.
+func (in *TabletCellBundleStatus) DeepCopy() *TabletCellBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletCellBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TabletNodesSpec) DeepCopyInto(out *TabletNodesSpec) {
 	*out = *in
@@ -1535,7 +2535,64 @@ func (in *UISpec) DeepCopy() *UISpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UpdateStatus) DeepCopyInto(out *UpdateStatus) {
+func (in *UpdateDeadlinesSpec) DeepCopyInto(out *UpdateDeadlinesSpec) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make(map[UpdateState]metav1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateDeadlinesSpec.
+func (in *UpdateDeadlinesSpec) DeepCopy() *UpdateDeadlinesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateDeadlinesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateHistoryEntry) DeepCopyInto(out *UpdateHistoryEntry) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateHistoryEntry.
+func (in *UpdateHistoryEntry) DeepCopy() *UpdateHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateStatus) DeepCopyInto(out *UpdateStatus) {
 	*out = *in
 	if in.Components != nil {
 		in, out := &in.Components, &out.Components
@@ -1559,6 +2616,14 @@ func (in *UpdateStatus) DeepCopyInto(out *UpdateStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StateTransitionTime != nil {
+		in, out := &in.StateTransitionTime, &out.StateTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStatus.
@@ -1614,6 +2679,231 @@ func (in *Ytsaurus) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusAccount) DeepCopyInto(out *YtsaurusAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusAccount.
+func (in *YtsaurusAccount) DeepCopy() *YtsaurusAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YtsaurusAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusAccountList) DeepCopyInto(out *YtsaurusAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]YtsaurusAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusAccountList.
+func (in *YtsaurusAccountList) DeepCopy() *YtsaurusAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YtsaurusAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusAccountSpec) DeepCopyInto(out *YtsaurusAccountSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.ResourceLimits.DeepCopyInto(&out.ResourceLimits)
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]AccessControlEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusAccountSpec.
+func (in *YtsaurusAccountSpec) DeepCopy() *YtsaurusAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusAccountStatus) DeepCopyInto(out *YtsaurusAccountStatus) {
+	*out = *in
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(AccountResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusAccountStatus.
+func (in *YtsaurusAccountStatus) DeepCopy() *YtsaurusAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusGroup) DeepCopyInto(out *YtsaurusGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusGroup.
+func (in *YtsaurusGroup) DeepCopy() *YtsaurusGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YtsaurusGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusGroupList) DeepCopyInto(out *YtsaurusGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]YtsaurusGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusGroupList.
+func (in *YtsaurusGroupList) DeepCopy() *YtsaurusGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YtsaurusGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusGroupSpec) DeepCopyInto(out *YtsaurusGroupSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusGroupSpec.
+func (in *YtsaurusGroupSpec) DeepCopy() *YtsaurusGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusGroupStatus) DeepCopyInto(out *YtsaurusGroupStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusGroupStatus.
+func (in *YtsaurusGroupStatus) DeepCopy() *YtsaurusGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YtsaurusList) DeepCopyInto(out *YtsaurusList) {
 	*out = *in
@@ -1660,6 +2950,16 @@ func (in *YtsaurusSpec) DeepCopyInto(out *YtsaurusSpec) {
 		*out = new(OauthServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpdateDeadlines != nil {
+		in, out := &in.UpdateDeadlines, &out.UpdateDeadlines
+		*out = new(UpdateDeadlinesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindowSpec, len(*in))
+		copy(*out, *in)
+	}
 	if in.Bootstrap != nil {
 		in, out := &in.Bootstrap, &out.Bootstrap
 		*out = new(BootstrapSpec)
@@ -1766,6 +3066,11 @@ func (in *YtsaurusSpec) DeepCopyInto(out *YtsaurusSpec) {
 		*out = new(UISpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusSpec.
@@ -1789,6 +3094,25 @@ func (in *YtsaurusStatus) DeepCopyInto(out *YtsaurusStatus) {
 		}
 	}
 	in.UpdateStatus.DeepCopyInto(&out.UpdateStatus)
+	if in.UpdateHistory != nil {
+		in, out := &in.UpdateHistory, &out.UpdateHistory
+		*out = make([]UpdateHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueryTrackerStages != nil {
+		in, out := &in.QueryTrackerStages, &out.QueryTrackerStages
+		*out = make([]QueryTrackerStageStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusStatus.
@@ -1800,3 +3124,119 @@ func (in *YtsaurusStatus) DeepCopy() *YtsaurusStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusUser) DeepCopyInto(out *YtsaurusUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusUser.
+func (in *YtsaurusUser) DeepCopy() *YtsaurusUser {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YtsaurusUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusUserList) DeepCopyInto(out *YtsaurusUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]YtsaurusUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusUserList.
+func (in *YtsaurusUserList) DeepCopy() *YtsaurusUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YtsaurusUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusUserSpec) DeepCopyInto(out *YtsaurusUserSpec) {
+	*out = *in
+	if in.Ytsaurus != nil {
+		in, out := &in.Ytsaurus, &out.Ytsaurus
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.MemberOf != nil {
+		in, out := &in.MemberOf, &out.MemberOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusUserSpec.
+func (in *YtsaurusUserSpec) DeepCopy() *YtsaurusUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YtsaurusUserStatus) DeepCopyInto(out *YtsaurusUserStatus) {
+	*out = *in
+	if in.MemberOf != nil {
+		in, out := &in.MemberOf, &out.MemberOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YtsaurusUserStatus.
+func (in *YtsaurusUserStatus) DeepCopy() *YtsaurusUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(YtsaurusUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
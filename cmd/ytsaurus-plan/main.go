@@ -0,0 +1,122 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// ytsaurus-plan reports what the operator would do if the given Ytsaurus spec was applied:
+// the chosen update flow, the components to be updated and their config diffs.
+// The spec is submitted to the API server in dry-run mode only, so defaults and
+// validation webhooks are applied, but nothing is changed in the cluster.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/controllers"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ytv1.AddToScheme(scheme))
+}
+
+func main() {
+	var fileName string
+	var namespace string
+	flag.StringVar(&fileName, "f", "", "File with the Ytsaurus resource to be applied.")
+	flag.StringVar(&namespace, "n", "", "Namespace of the Ytsaurus resource, overrides the one from the file.")
+	flag.Parse()
+
+	if fileName == "" {
+		fmt.Fprintln(os.Stderr, "ytsaurus-plan: -f is required")
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), fileName, namespace); err != nil {
+		fmt.Fprintf(os.Stderr, "ytsaurus-plan: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, fileName, namespace string) error {
+	// Component manager logs statuses of all components, they are not a part of the plan.
+	ctrl.SetLogger(logr.Discard())
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	desired := &ytv1.Ytsaurus{}
+	if err := yaml.UnmarshalStrict(data, desired); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+	if namespace != "" {
+		desired.Namespace = namespace
+	}
+	if desired.Namespace == "" {
+		desired.Namespace = "default"
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	// All writes are sent in dry-run mode, so the plan could be safely computed against a production cluster.
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme, DryRun: ptr.To(true)})
+	if err != nil {
+		return err
+	}
+
+	ytsaurus := &ytv1.Ytsaurus{}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(desired), ytsaurus); err != nil {
+		return fmt.Errorf("failed to get current ytsaurus: %w", err)
+	}
+
+	ytsaurus.Spec = desired.Spec
+	if err := k8sClient.Update(ctx, ytsaurus); err != nil {
+		return fmt.Errorf("spec is rejected: %w", err)
+	}
+
+	proxy := apiproxy.NewYtsaurus(ytsaurus, k8sClient, &record.FakeRecorder{}, scheme)
+	plan, err := controllers.PlanUpdate(ctx, proxy)
+	if err != nil {
+		return err
+	}
+
+	output, err := yaml.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(output)
+	return err
+}
@@ -21,15 +21,18 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 )
 
 // ChytReconciler reconciles a Chyt object
@@ -67,6 +70,11 @@ func (r *ChytReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	var ytsaurus ytv1.Ytsaurus
 	ytsaurusName := types.NamespacedName{Name: chyt.Spec.Ytsaurus.Name, Namespace: req.Namespace}
 	if err := r.Get(ctx, ytsaurusName, &ytsaurus); err != nil {
+		// There is nothing to clean up if the cluster itself is gone.
+		if apierrors.IsNotFound(err) && !chyt.DeletionTimestamp.IsZero() {
+			controllerutil.RemoveFinalizer(&chyt, consts.ReleaseCleanupFinalizer)
+			return ctrl.Result{}, r.Update(ctx, &chyt)
+		}
 		logger.Error(err, "unable to fetch Ytsaurus for spyt")
 		return ctrl.Result{RequeueAfter: time.Second * 10}, err
 	}
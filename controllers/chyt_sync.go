@@ -7,8 +7,10 @@ import (
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -27,16 +29,44 @@ func (r *ChytReconciler) Sync(ctx context.Context, resource *ytv1.Chyt, ytsaurus
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	if chyt.GetResource().Status.ReleaseStatus == ytv1.ChytReleaseStatusFinished {
-		return ctrl.Result{}, nil
+	if !resource.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(resource, consts.ReleaseCleanupFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		removed, err := component.Remove(ctx)
+		if err != nil {
+			logger.Error(err, "failed to remove CHYT releases")
+			return ctrl.Result{Requeue: true}, err
+		}
+		if !removed {
+			if err := chyt.APIProxy().UpdateStatus(ctx); err != nil {
+				logger.Error(err, "update chyt status failed")
+				return ctrl.Result{Requeue: true}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		controllerutil.RemoveFinalizer(resource, consts.ReleaseCleanupFinalizer)
+		return ctrl.Result{}, r.Update(ctx, resource)
 	}
 
+	if controllerutil.AddFinalizer(resource, consts.ReleaseCleanupFinalizer) {
+		if err := r.Update(ctx, resource); err != nil {
+			logger.Error(err, "failed to add finalizer to chyt")
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	// Image changes are detected by the component, so the release is checked even if it was finished.
+	finished := resource.Status.ReleaseStatus == ytv1.ChytReleaseStatusFinished
 	status := component.Status(ctx)
 	if status.SyncStatus == components.SyncStatusBlocked {
 		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
 	}
 
 	if status.SyncStatus == components.SyncStatusReady {
+		if finished {
+			return ctrl.Result{}, nil
+		}
 		logger.Info("CHYT initialization finished")
 
 		err := chyt.SaveReleaseStatus(ctx, ytv1.ChytReleaseStatusFinished)
@@ -0,0 +1,127 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+// ChytCliqueReconciler reconciles a ChytClique object
+type ChytCliqueReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=chytcliques,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=chytcliques/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=chytcliques/finalizers,verbs=update
+
+// Reconcile brings the clique in YTsaurus to the state declared by ChytClique.
+// The clique is removed from YTsaurus before the resource is deleted.
+func (r *ChytCliqueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var chytClique ytv1.ChytClique
+	if err := r.Get(ctx, req.NamespacedName, &chytClique); err != nil {
+		logger.Error(err, "unable to fetch ChytClique")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	chyt, ytsaurusName, err := r.getReferences(ctx, &chytClique)
+	if apierrors.IsNotFound(err) && !chytClique.DeletionTimestamp.IsZero() {
+		// Chyt could be deleted before the clique, e.g. together with its default clique,
+		// the clique is still removed from the cluster it was created in.
+		chyt, ytsaurusName, err = nil, getChytCliqueYtsaurusName(&chytClique), nil
+		if ytsaurusName.Name == "" {
+			err = fmt.Errorf("chyt %s is not found and the cluster of the clique is unknown", chytClique.Spec.Chyt.Name)
+		}
+	}
+	if err != nil {
+		logger.Error(err, "unable to resolve Ytsaurus for chyt clique")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, err
+	}
+
+	var ytsaurus ytv1.Ytsaurus
+	if err = r.Get(ctx, ytsaurusName, &ytsaurus); err != nil {
+		// There is nothing to clean up if the cluster itself is gone.
+		if apierrors.IsNotFound(err) && !chytClique.DeletionTimestamp.IsZero() {
+			controllerutil.RemoveFinalizer(&chytClique, consts.ChytCliqueFinalizer)
+			return ctrl.Result{}, r.Update(ctx, &chytClique)
+		}
+		logger.Error(err, "unable to fetch Ytsaurus for chyt clique")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, err
+	}
+
+	logger.V(1).Info("found ChytClique")
+	return r.Sync(ctx, &chytClique, &ytsaurus, chyt)
+}
+
+// getChytCliqueYtsaurusName returns the cluster of the clique without the referenced Chyt,
+// empty name if the clique has never been synced.
+func getChytCliqueYtsaurusName(chytClique *ytv1.ChytClique) types.NamespacedName {
+	name := chytClique.Labels[consts.ChytCliqueYtsaurusLabel]
+	if chytClique.Spec.Ytsaurus != nil {
+		name = chytClique.Spec.Ytsaurus.Name
+	}
+	return types.NamespacedName{Name: name, Namespace: chytClique.Namespace}
+}
+
+// getReferences returns the referenced Chyt, if any, and the name of the cluster running the clique.
+func (r *ChytCliqueReconciler) getReferences(ctx context.Context, chytClique *ytv1.ChytClique) (*ytv1.Chyt, types.NamespacedName, error) {
+	spec := chytClique.Spec
+	if spec.Chyt == nil {
+		if spec.Ytsaurus == nil {
+			return nil, types.NamespacedName{}, fmt.Errorf("either ytsaurus or chyt should be set")
+		}
+		return nil, types.NamespacedName{Name: spec.Ytsaurus.Name, Namespace: chytClique.Namespace}, nil
+	}
+
+	var chyt ytv1.Chyt
+	chytName := types.NamespacedName{Name: spec.Chyt.Name, Namespace: chytClique.Namespace}
+	if err := r.Get(ctx, chytName, &chyt); err != nil {
+		return nil, types.NamespacedName{}, err
+	}
+	if chyt.Spec.Ytsaurus == nil {
+		return nil, types.NamespacedName{}, fmt.Errorf("chyt %s has no ytsaurus", chyt.Name)
+	}
+	if spec.Ytsaurus != nil && spec.Ytsaurus.Name != chyt.Spec.Ytsaurus.Name {
+		return nil, types.NamespacedName{}, fmt.Errorf("chyt %s belongs to ytsaurus %s", chyt.Name, chyt.Spec.Ytsaurus.Name)
+	}
+	return &chyt, types.NamespacedName{Name: chyt.Spec.Ytsaurus.Name, Namespace: chytClique.Namespace}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ChytCliqueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ytv1.ChytClique{}).
+		Complete(r)
+}
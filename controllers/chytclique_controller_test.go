@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+// reconcileDeletedChytClique reconciles the deleted clique which references the missing chyt.
+func reconcileDeletedChytClique(t *testing.T, labels map[string]string, objects ...client.Object) (client.Client, error) {
+	t.Setenv("K8S_CLUSTER_DOMAIN", "cluster.local")
+	scheme := runtime.NewScheme()
+	require.NoError(t, ytv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	clique := &ytv1.ChytClique{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ch-public",
+			Namespace:         "default",
+			Labels:            labels,
+			Finalizers:        []string{consts.ChytCliqueFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+		},
+		Spec: ytv1.ChytCliqueSpec{
+			Chyt: &corev1.LocalObjectReference{Name: "chyt"},
+			Pool: "chyt",
+		},
+	}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(objects, clique)...).
+		WithStatusSubresource(clique).
+		Build()
+	r := &ChytCliqueReconciler{Client: k8sClient, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(clique)})
+	return k8sClient, err
+}
+
+func getChytClique(t *testing.T, k8sClient client.Client) (*ytv1.ChytClique, error) {
+	clique := &ytv1.ChytClique{}
+	err := k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "ch-public"}, clique)
+	return clique, err
+}
+
+func TestChytCliqueIsRemovedFromClusterAfterChyt(t *testing.T) {
+	ytsaurus := &ytv1.Ytsaurus{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	k8sClient, err := reconcileDeletedChytClique(t, map[string]string{consts.ChytCliqueYtsaurusLabel: "test"}, ytsaurus)
+	require.NoError(t, err)
+
+	_, err = getChytClique(t, k8sClient)
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestChytCliqueIsReleasedWithoutCluster(t *testing.T) {
+	k8sClient, err := reconcileDeletedChytClique(t, map[string]string{consts.ChytCliqueYtsaurusLabel: "test"})
+	require.NoError(t, err)
+
+	_, err = getChytClique(t, k8sClient)
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestChytCliqueIsKeptWithUnknownCluster(t *testing.T) {
+	ytsaurus := &ytv1.Ytsaurus{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	k8sClient, err := reconcileDeletedChytClique(t, nil, ytsaurus)
+	require.Error(t, err)
+
+	clique, err := getChytClique(t, k8sClient)
+	require.NoError(t, err)
+	require.Contains(t, clique.Finalizers, consts.ChytCliqueFinalizer)
+}
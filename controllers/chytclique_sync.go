@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+func (r *ChytCliqueReconciler) Sync(ctx context.Context, resource *ytv1.ChytClique, ytsaurus *ytv1.Ytsaurus, chyt *ytv1.Chyt) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	chytClique := apiproxy.NewChytClique(resource, r.Client, r.Recorder, r.Scheme)
+
+	cfgen := ytconfig.NewGenerator(ytsaurus, getClusterDomain(chytClique.APIProxy().Client()))
+
+	component := components.NewChytClique(cfgen, chytClique, ytsaurus, chyt)
+
+	if err := component.Fetch(ctx); err != nil {
+		logger.Error(err, "failed to fetch chyt clique status for controller")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if !resource.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(resource, consts.ChytCliqueFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if !component.Remove(ctx) {
+			if err := chytClique.APIProxy().UpdateStatus(ctx); err != nil {
+				logger.Error(err, "update chyt clique status failed")
+				return ctrl.Result{Requeue: true}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		controllerutil.RemoveFinalizer(resource, consts.ChytCliqueFinalizer)
+		return ctrl.Result{}, r.Update(ctx, resource)
+	}
+
+	// Cluster is remembered to remove the clique even if the referenced chyt is deleted first.
+	labelChanged := resource.Labels[consts.ChytCliqueYtsaurusLabel] != ytsaurus.Name
+	metav1.SetMetaDataLabel(&resource.ObjectMeta, consts.ChytCliqueYtsaurusLabel, ytsaurus.Name)
+	if controllerutil.AddFinalizer(resource, consts.ChytCliqueFinalizer) || labelChanged {
+		if err := r.Update(ctx, resource); err != nil {
+			logger.Error(err, "failed to add finalizer to chyt clique")
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	if err := component.Sync(ctx); err != nil {
+		logger.Error(err, "component sync failed", "component", "chytClique")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := chytClique.APIProxy().UpdateStatus(ctx); err != nil {
+		logger.Error(err, "update chyt clique status failed")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if resource.Status.State != ytv1.ChytCliqueStateSynced {
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	// Health of the clique is refreshed periodically.
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
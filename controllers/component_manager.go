@@ -11,15 +11,18 @@ import (
 	apiProxy "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/metrics"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 )
 
 type ComponentManager struct {
-	ytsaurus              *apiProxy.Ytsaurus
-	allComponents         []components.Component
-	queryTrackerComponent components.Component
-	schedulerComponent    components.Component
-	status                ComponentManagerStatus
+	ytsaurus                *apiProxy.Ytsaurus
+	allComponents           []components.Component
+	queryTrackerComponent   components.Component
+	schedulerComponent      components.Component
+	execNodeComponents      []components.Component
+	remoteClustersComponent *components.RemoteClusters
+	status                  ComponentManagerStatus
 }
 
 type ComponentManagerStatus struct {
@@ -51,7 +54,7 @@ func NewComponentManager(
 	nodeCfgGen := ytconfig.NewLocalNodeGenerator(ytsaurus.GetResource(), clusterDomain)
 	if resource.Spec.DataNodes != nil && len(resource.Spec.DataNodes) > 0 {
 		for _, dndSpec := range ytsaurus.GetResource().Spec.DataNodes {
-			dnds = append(dnds, components.NewDataNode(nodeCfgGen, ytsaurus, m, dndSpec))
+			dnds = append(dnds, components.NewDataNode(nodeCfgGen, ytsaurus, m, yc, dndSpec))
 		}
 	}
 
@@ -60,7 +63,18 @@ func NewComponentManager(
 	allComponents := []components.Component{
 		d, m, yc,
 	}
+	for idx := range resource.Spec.SecondaryMasters {
+		allComponents = append(allComponents, components.NewSecondaryMaster(cfgen, ytsaurus, yc, &resource.Spec.SecondaryMasters[idx]))
+	}
 	allComponents = append(allComponents, dnds...)
+
+	removedDataNodeGroups, err := components.GetRemovedDataNodeGroups(ctx, ytsaurus)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range removedDataNodeGroups {
+		allComponents = append(allComponents, components.NewRemovedDataNode(nodeCfgGen, ytsaurus, m, yc, name))
+	}
 	allComponents = append(allComponents, hps...)
 
 	if resource.Spec.UI != nil {
@@ -87,7 +101,7 @@ func NewComponentManager(
 	var ends []components.Component
 	if resource.Spec.ExecNodes != nil && len(resource.Spec.ExecNodes) > 0 {
 		for _, endSpec := range ytsaurus.GetResource().Spec.ExecNodes {
-			ends = append(ends, components.NewExecNode(nodeCfgGen, ytsaurus, m, endSpec))
+			ends = append(ends, components.NewExecNode(nodeCfgGen, ytsaurus, m, yc, endSpec))
 		}
 	}
 	allComponents = append(allComponents, ends...)
@@ -114,6 +128,14 @@ func NewComponentManager(
 	if resource.Spec.QueryTrackers != nil && resource.Spec.Schedulers != nil && resource.Spec.TabletNodes != nil && len(resource.Spec.TabletNodes) > 0 {
 		q = components.NewQueryTracker(cfgen, ytsaurus, yc, tnds)
 		allComponents = append(allComponents, q)
+
+		for i := range resource.Spec.QueryTrackers.Stages {
+			qts := components.NewQueryTrackerStage(cfgen, ytsaurus, yc, tnds, &resource.Spec.QueryTrackers.Stages[i])
+			allComponents = append(allComponents, qts)
+		}
+	}
+	for _, name := range components.GetRemovedQueryTrackerStages(ytsaurus) {
+		allComponents = append(allComponents, components.NewRemovedQueryTrackerStage(cfgen, ytsaurus, yc, name))
 	}
 
 	if resource.Spec.QueueAgents != nil && resource.Spec.TabletNodes != nil && len(resource.Spec.TabletNodes) > 0 {
@@ -135,6 +157,13 @@ func NewComponentManager(
 		mc := components.NewMasterCache(cfgen, ytsaurus)
 		allComponents = append(allComponents, mc)
 	}
+
+	// Previously registered remote clusters are unregistered when all of them are removed from spec.
+	var rc *components.RemoteClusters
+	if len(resource.Spec.GetRemoteClusters()) != 0 || len(resource.Status.RemoteClusters) != 0 {
+		rc = components.NewRemoteClusters(cfgen, ytsaurus, yc)
+		allComponents = append(allComponents, rc)
+	}
 	// Fetch component status.
 	var readyComponents []string
 	var notReadyComponents []string
@@ -145,20 +174,24 @@ func NewComponentManager(
 		needUpdate:         nil,
 		allReadyOrUpdating: true,
 	}
+	metrics.ResetComponentSyncStatuses(resource.Namespace, resource.Name)
 	for _, c := range allComponents {
 		err := c.Fetch(ctx)
 		if err != nil {
 			logger.Error(err, "failed to fetch status for controller", "component", c.GetName())
+			metrics.ReportComponentError(resource.Namespace, resource.Name, c.GetName())
 			return nil, err
 		}
 
 		componentStatus, err := c.Status(ctx)
 		if err != nil {
+			metrics.ReportComponentError(resource.Namespace, resource.Name, c.GetName())
 			return nil, fmt.Errorf("failed to get component %s status: %w", c.GetName(), err)
 		}
 
 		c.SetReadyCondition(componentStatus)
 		syncStatus := componentStatus.SyncStatus
+		metrics.ReportComponentSyncStatus(resource.Namespace, resource.Name, c.GetName(), string(syncStatus))
 
 		if syncStatus == components.SyncStatusNeedLocalUpdate {
 			status.needUpdate = append(status.needUpdate, c)
@@ -188,21 +221,25 @@ func NewComponentManager(
 		"clusterState", resource.Status.State)
 
 	return &ComponentManager{
-		ytsaurus:              ytsaurus,
-		allComponents:         allComponents,
-		queryTrackerComponent: q,
-		schedulerComponent:    s,
-		status:                status,
+		ytsaurus:                ytsaurus,
+		allComponents:           allComponents,
+		queryTrackerComponent:   q,
+		schedulerComponent:      s,
+		execNodeComponents:      ends,
+		remoteClustersComponent: rc,
+		status:                  status,
 	}, nil
 }
 
 func (cm *ComponentManager) Sync(ctx context.Context) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
+	resource := cm.ytsaurus.GetResource()
 
 	hasPending := false
 	for _, c := range cm.allComponents {
 		status, err := c.Status(ctx)
 		if err != nil {
+			metrics.ReportComponentError(resource.Namespace, resource.Name, c.GetName())
 			return ctrl.Result{Requeue: true}, fmt.Errorf("failed to get status for %s: %w", c.GetName(), err)
 		}
 
@@ -212,6 +249,7 @@ func (cm *ComponentManager) Sync(ctx context.Context) (ctrl.Result, error) {
 			logger.Info("component sync", "component", c.GetName())
 			if err := c.Sync(ctx); err != nil {
 				logger.Error(err, "component sync failed", "component", c.GetName())
+				metrics.ReportComponentError(resource.Namespace, resource.Name, c.GetName())
 				return ctrl.Result{Requeue: true}, err
 			}
 		}
@@ -246,6 +284,19 @@ func (cm *ComponentManager) allReadyOrUpdating() bool {
 	return cm.status.allReadyOrUpdating
 }
 
+// checkRemoteClusters checks connectivity of remote clusters when it is due and saves results of finished checks
+// in the status, it returns true while checks are running.
+func (cm *ComponentManager) checkRemoteClusters(ctx context.Context) (bool, error) {
+	if cm.remoteClustersComponent == nil {
+		return false, nil
+	}
+	running, reported, err := cm.remoteClustersComponent.CheckConnectivity(ctx)
+	if err != nil || !reported {
+		return running, err
+	}
+	return running, cm.ytsaurus.APIProxy().UpdateStatus(ctx)
+}
+
 func (cm *ComponentManager) needQueryTrackerUpdate() bool {
 	return cm.queryTrackerComponent != nil && components.IsUpdatingComponent(cm.ytsaurus, cm.queryTrackerComponent)
 }
@@ -265,5 +316,39 @@ func (cm *ComponentManager) arePodsRemoved() bool {
 }
 
 func (cm *ComponentManager) areComponentPodsRemoved(component components.Component) bool {
-	return cm.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetPodsRemovedCondition(component.GetName()))
+	return cm.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetPodsRemovedCondition(component.GetName())) ||
+		cm.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetPodsUpdatedCondition(component.GetName()))
+}
+
+// getDrainingExecNodes returns updating exec node components which should be drained before pods removal.
+func (cm *ComponentManager) getDrainingExecNodes() []components.Component {
+	var result []components.Component
+	for _, cmp := range cm.execNodeComponents {
+		if components.IsUpdatingComponent(cm.ytsaurus, cmp) && cmp.(*components.ExecNode).IsDrainEnabled() {
+			result = append(result, cmp)
+		}
+	}
+	return result
+}
+
+func (cm *ComponentManager) needExecNodesDrain() bool {
+	return len(cm.getDrainingExecNodes()) > 0
+}
+
+func (cm *ComponentManager) areExecNodesDrained() bool {
+	for _, cmp := range cm.getDrainingExecNodes() {
+		if !cm.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesDrainedCondition(cmp.GetName())) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cm *ComponentManager) areExecNodesUndrained() bool {
+	for _, cmp := range cm.getDrainingExecNodes() {
+		if !cm.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesUndrainedCondition(cmp.GetName())) {
+			return false
+		}
+	}
+	return true
 }
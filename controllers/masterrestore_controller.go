@@ -0,0 +1,79 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+)
+
+// MasterRestoreReconciler reconciles a MasterRestore object
+type MasterRestoreReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=masterrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=masterrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=masterrestores/finalizers,verbs=update
+
+// Reconcile moves the restore of masters through its states,
+// the outcome is reported in the MasterRestore status.
+func (r *MasterRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var masterRestore ytv1.MasterRestore
+	if err := r.Get(ctx, req.NamespacedName, &masterRestore); err != nil {
+		logger.Error(err, "unable to fetch MasterRestore")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch masterRestore.Status.State {
+	case ytv1.MasterRestoreStateCompleted, ytv1.MasterRestoreStateFailed:
+		return ctrl.Result{}, nil
+	}
+
+	var ytsaurus ytv1.Ytsaurus
+	ytsaurusName := types.NamespacedName{Name: masterRestore.Spec.Ytsaurus.Name, Namespace: req.Namespace}
+	if err := r.Get(ctx, ytsaurusName, &ytsaurus); err != nil {
+		logger.Error(err, "unable to fetch Ytsaurus for master restore")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, err
+	}
+
+	logger.V(1).Info("found MasterRestore")
+
+	return r.Sync(ctx, &masterRestore, &ytsaurus)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MasterRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ytv1.MasterRestore{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
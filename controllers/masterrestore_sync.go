@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+func (r *MasterRestoreReconciler) Sync(ctx context.Context, resource *ytv1.MasterRestore, ytsaurus *ytv1.Ytsaurus) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	masterRestore := apiproxy.NewMasterRestore(resource, r.Client, r.Recorder, r.Scheme)
+
+	cfgen := ytconfig.NewGenerator(ytsaurus, getClusterDomain(masterRestore.APIProxy().Client()))
+
+	component := components.NewMasterRestore(cfgen, masterRestore, ytsaurus)
+
+	if err := component.Fetch(ctx); err != nil {
+		logger.Error(err, "failed to fetch master restore status for controller")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := component.Sync(ctx); err != nil {
+		logger.Error(err, "component sync failed", "component", "masterRestore")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := masterRestore.APIProxy().UpdateStatus(ctx); err != nil {
+		logger.Error(err, "update master restore status failed")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	apiProxy "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+)
+
+// UpdatePlan describes the update which the operator would start for the current spec of the cluster.
+type UpdatePlan struct {
+	ClusterState ytv1.ClusterState `json:"clusterState"`
+	// Flow is the update flow chosen for the spec, it is empty if there is nothing to update or update is blocked.
+	Flow ytv1.UpdateFlow `json:"flow,omitempty"`
+	// BlockMessage explains why the update would be blocked.
+	BlockMessage string `json:"blockMessage,omitempty"`
+	// Components which would be updated, or all components needing update if the update is blocked.
+	Components []components.ComponentPlan `json:"components,omitempty"`
+}
+
+// PlanUpdate computes the update plan for the spec of the ytsaurus resource without changing anything in the cluster.
+// The resource is expected to hold the spec to be applied and the status of the running cluster.
+func PlanUpdate(ctx context.Context, ytsaurus *apiProxy.Ytsaurus) (*UpdatePlan, error) {
+	resource := ytsaurus.GetResource()
+	// The plan is computed for the spec components would be rendered from by Sync.
+	if _, err := prepareRenderedSpec(ctx, ytsaurus); err != nil {
+		return nil, err
+	}
+	componentManager, err := NewComponentManager(ctx, ytsaurus)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UpdatePlan{
+		ClusterState: resource.Status.State,
+	}
+	needUpdate := componentManager.status.needUpdate
+	if len(needUpdate) == 0 {
+		return plan, nil
+	}
+
+	meta, blockMsg := chooseUpdateFlow(resource.Spec, needUpdate)
+	if blockMsg != "" {
+		plan.BlockMessage = blockMsg
+	} else {
+		plan.Flow = meta.flow
+	}
+
+	for _, c := range needUpdate {
+		if blockMsg == "" && !slices.Contains(meta.componentNames, c.GetName()) {
+			continue
+		}
+		componentPlan, err := components.PlanComponent(c)
+		if err != nil {
+			return nil, err
+		}
+		plan.Components = append(plan.Components, componentPlan)
+	}
+
+	return plan, nil
+}
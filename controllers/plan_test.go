@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+)
+
+func TestPlanUpdateOfRolledBackUpdate(t *testing.T) {
+	ctx := context.Background()
+	ytsaurus, _ := newUpdatingYtsaurus(t, ytv1.UpdateStateWaitingForPodsCreation, 2*time.Hour)
+	deadlines := &ytv1.UpdateDeadlinesSpec{Default: &metav1.Duration{Duration: time.Hour}}
+	_, err := handleUpdateDeadline(ctx, ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.True(t, ytsaurus.IsRolledBack())
+
+	plan, err := PlanUpdate(ctx, ytsaurus)
+	require.NoError(t, err)
+	require.Equal(t, ytv1.ClusterStateUpdating, plan.ClusterState)
+	// The plan is computed for the previous spec, as the update is being rolled back.
+	require.Equal(t, "ytsaurus/ytsaurus:old", ytsaurus.GetResource().Spec.CoreImage)
+}
+
+func TestPlanUpdateOfMasterResize(t *testing.T) {
+	ytsaurus, _ := newUpdatedYtsaurus(t, ytv1.UpdateStateWaitingForMasterQuorum, time.Minute, func(spec *ytv1.YtsaurusSpec) {
+		spec.PrimaryMasters.InstanceCount = 3
+	})
+
+	_, err := PlanUpdate(context.Background(), ytsaurus)
+	require.NoError(t, err)
+	// Masters are planned with the peers of the current resize step.
+	require.Equal(t, int32(2), ytsaurus.GetResource().Spec.PrimaryMasters.InstanceCount)
+}
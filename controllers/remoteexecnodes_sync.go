@@ -26,6 +26,7 @@ func (r *RemoteExecNodesReconciler) Sync(
 		getClusterDomain(r.Client),
 		resource.Spec.CommonSpec,
 		remoteYtsaurus.Spec.MasterConnectionSpec,
+		remoteYtsaurus.Spec.SecondaryMasters,
 		&remoteYtsaurus.Spec.MasterCachesSpec,
 	)
 
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+func (r *SchedulerPoolTreeReconciler) Sync(ctx context.Context, resource *ytv1.SchedulerPoolTree, ytsaurus *ytv1.Ytsaurus) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	schedulerPoolTree := apiproxy.NewSchedulerPoolTree(resource, r.Client, r.Recorder, r.Scheme)
+
+	cfgen := ytconfig.NewGenerator(ytsaurus, getClusterDomain(schedulerPoolTree.APIProxy().Client()))
+
+	component := components.NewSchedulerPoolTree(cfgen, schedulerPoolTree, ytsaurus)
+
+	if err := component.Fetch(ctx); err != nil {
+		logger.Error(err, "failed to fetch scheduler pool tree status for controller")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := component.Sync(ctx); err != nil {
+		logger.Error(err, "component sync failed", "component", "schedulerPoolTree")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := schedulerPoolTree.APIProxy().UpdateStatus(ctx); err != nil {
+		logger.Error(err, "update scheduler pool tree status failed")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if resource.Status.State != ytv1.SchedulerPoolTreeStateSynced {
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
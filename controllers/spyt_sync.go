@@ -7,8 +7,10 @@ import (
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -27,10 +29,35 @@ func (r *SpytReconciler) Sync(ctx context.Context, resource *ytv1.Spyt, ytsaurus
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	if spyt.GetResource().Status.ReleaseStatus == ytv1.SpytReleaseStatusFinished {
-		return ctrl.Result{}, nil
+	if !resource.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(resource, consts.ReleaseCleanupFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		removed, err := component.Remove(ctx)
+		if err != nil {
+			logger.Error(err, "failed to remove SPYT releases")
+			return ctrl.Result{Requeue: true}, err
+		}
+		if !removed {
+			if err := spyt.APIProxy().UpdateStatus(ctx); err != nil {
+				logger.Error(err, "update spyt status failed")
+				return ctrl.Result{Requeue: true}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		controllerutil.RemoveFinalizer(resource, consts.ReleaseCleanupFinalizer)
+		return ctrl.Result{}, r.Update(ctx, resource)
 	}
 
+	if controllerutil.AddFinalizer(resource, consts.ReleaseCleanupFinalizer) {
+		if err := r.Update(ctx, resource); err != nil {
+			logger.Error(err, "failed to add finalizer to spyt")
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	// Image changes are detected by the component, so the release is checked even if it was finished.
+	finished := resource.Status.ReleaseStatus == ytv1.SpytReleaseStatusFinished
 	componentStatus := component.Status(ctx)
 
 	if componentStatus.SyncStatus == components.SyncStatusBlocked {
@@ -38,6 +65,9 @@ func (r *SpytReconciler) Sync(ctx context.Context, resource *ytv1.Spyt, ytsaurus
 	}
 
 	if componentStatus.SyncStatus == components.SyncStatusReady {
+		if finished {
+			return ctrl.Result{}, nil
+		}
 		logger.Info("SPYT initialization finished")
 
 		err := spyt.SaveReleaseStatus(ctx, ytv1.SpytReleaseStatusFinished)
@@ -7,7 +7,12 @@ import (
 
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/metrics"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -19,6 +24,7 @@ func (r *YtsaurusReconciler) handleEverything(
 	ctx context.Context,
 	ytsaurus *apiProxy.Ytsaurus,
 	componentManager *ComponentManager,
+	masterInstanceCount int32,
 ) (*ctrl.Result, error) {
 	resource := ytsaurus.GetResource()
 
@@ -76,6 +82,18 @@ func (r *YtsaurusReconciler) handleEverything(
 
 	case ytv1.UpdateStateWaitingForSnapshots:
 		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionSnaphotsSaved) {
+			if resource.Spec.PrimaryMasters.SnapshotsExport != nil {
+				ytsaurus.LogUpdate(ctx, "Waiting for master snapshots export")
+				err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForMasterSnapshotsExport)
+				return &ctrl.Result{Requeue: true}, err
+			}
+			ytsaurus.LogUpdate(ctx, "Waiting for pods removal")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
+			return &ctrl.Result{Requeue: true}, err
+		}
+
+	case ytv1.UpdateStateWaitingForMasterSnapshotsExport:
+		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExported) {
 			ytsaurus.LogUpdate(ctx, "Waiting for pods removal")
 			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
 			return &ctrl.Result{Requeue: true}, err
@@ -97,7 +115,17 @@ func (r *YtsaurusReconciler) handleEverything(
 
 	case ytv1.UpdateStateWaitingForMasterExitReadOnly:
 		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterExitedReadOnly) {
-			ytsaurus.LogUpdate(ctx, "Masters exited read-only state")
+			ytsaurus.LogUpdate(ctx, "Masters exited read-only state, waiting for master quorum")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForMasterQuorum)
+			return &ctrl.Result{Requeue: true}, err
+		}
+
+	case ytv1.UpdateStateWaitingForMasterQuorum:
+		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterQuorumVerified) {
+			ytsaurus.LogUpdate(ctx, "Masters have a leader and all peers are following")
+			if result, err := handleMasterResizeStep(ctx, ytsaurus, masterInstanceCount); result != nil {
+				return result, err
+			}
 			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForTabletCellsRecovery)
 			return &ctrl.Result{Requeue: true}, err
 		}
@@ -170,10 +198,22 @@ func (r *YtsaurusReconciler) handleStateless(
 
 	switch resource.Status.UpdateStatus.State {
 	case ytv1.UpdateStateNone:
+		if componentManager.needExecNodesDrain() {
+			ytsaurus.LogUpdate(ctx, "Waiting for exec nodes drain")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForExecNodesDrain)
+			return &ctrl.Result{Requeue: true}, err
+		}
 		ytsaurus.LogUpdate(ctx, "Waiting for pods removal")
 		err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
 		return &ctrl.Result{Requeue: true}, err
 
+	case ytv1.UpdateStateWaitingForExecNodesDrain:
+		if componentManager.areExecNodesDrained() {
+			ytsaurus.LogUpdate(ctx, "Waiting for pods removal")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
+			return &ctrl.Result{Requeue: true}, err
+		}
+
 	case ytv1.UpdateStateWaitingForPodsRemoval:
 		if componentManager.arePodsRemoved() {
 			ytsaurus.LogUpdate(ctx, "Waiting for pods creation")
@@ -184,6 +224,18 @@ func (r *YtsaurusReconciler) handleStateless(
 	case ytv1.UpdateStateWaitingForPodsCreation:
 		if componentManager.allReadyOrUpdating() {
 			ytsaurus.LogUpdate(ctx, "All components were recreated")
+			if componentManager.needExecNodesDrain() {
+				ytsaurus.LogUpdate(ctx, "Waiting for exec nodes undrain")
+				err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForExecNodesUndrain)
+				return &ctrl.Result{Requeue: true}, err
+			}
+			ytsaurus.LogUpdate(ctx, "Waiting for operations archive prepare for updating")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForOpArchiveUpdatingPrepare)
+			return &ctrl.Result{Requeue: true}, err
+		}
+
+	case ytv1.UpdateStateWaitingForExecNodesUndrain:
+		if componentManager.areExecNodesUndrained() {
 			ytsaurus.LogUpdate(ctx, "Waiting for operations archive prepare for updating")
 			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForOpArchiveUpdatingPrepare)
 			return &ctrl.Result{Requeue: true}, err
@@ -237,6 +289,7 @@ func (r *YtsaurusReconciler) handleMasterOnly(
 	ctx context.Context,
 	ytsaurus *apiProxy.Ytsaurus,
 	componentManager *ComponentManager,
+	masterInstanceCount int32,
 ) (*ctrl.Result, error) {
 	resource := ytsaurus.GetResource()
 
@@ -273,6 +326,18 @@ func (r *YtsaurusReconciler) handleMasterOnly(
 
 	case ytv1.UpdateStateWaitingForSnapshots:
 		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionSnaphotsSaved) {
+			if resource.Spec.PrimaryMasters.SnapshotsExport != nil {
+				ytsaurus.LogUpdate(ctx, "Waiting for master snapshots export")
+				err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForMasterSnapshotsExport)
+				return &ctrl.Result{Requeue: true}, err
+			}
+			ytsaurus.LogUpdate(ctx, "Waiting for pods removal")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
+			return &ctrl.Result{Requeue: true}, err
+		}
+
+	case ytv1.UpdateStateWaitingForMasterSnapshotsExport:
+		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExported) {
 			ytsaurus.LogUpdate(ctx, "Waiting for pods removal")
 			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
 			return &ctrl.Result{Requeue: true}, err
@@ -294,7 +359,17 @@ func (r *YtsaurusReconciler) handleMasterOnly(
 
 	case ytv1.UpdateStateWaitingForMasterExitReadOnly:
 		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterExitedReadOnly) {
-			ytsaurus.LogUpdate(ctx, "Masters exited read-only state")
+			ytsaurus.LogUpdate(ctx, "Masters exited read-only state, waiting for master quorum")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForMasterQuorum)
+			return &ctrl.Result{Requeue: true}, err
+		}
+
+	case ytv1.UpdateStateWaitingForMasterQuorum:
+		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterQuorumVerified) {
+			ytsaurus.LogUpdate(ctx, "Masters have a leader and all peers are following")
+			if result, err := handleMasterResizeStep(ctx, ytsaurus, masterInstanceCount); result != nil {
+				return result, err
+			}
 			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForSafeModeDisabled)
 			return &ctrl.Result{Requeue: true}, err
 		}
@@ -323,7 +398,11 @@ func (r *YtsaurusReconciler) handleTabletNodesOnly(
 		return &ctrl.Result{Requeue: true}, err
 
 	case ytv1.UpdateStatePossibilityCheck:
-		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionHasPossibility) {
+		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionHasPossibility) && isTabletNodesRollingUpdate(resource) {
+			ytsaurus.LogUpdate(ctx, "Waiting for tablet nodes rolling update")
+			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForTabletNodesRollingUpdate)
+			return &ctrl.Result{Requeue: true}, err
+		} else if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionHasPossibility) {
 			ytsaurus.LogUpdate(ctx, "Waiting for safe mode enabled")
 			err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForTabletCellsSaving)
 			return &ctrl.Result{Requeue: true}, err
@@ -381,11 +460,30 @@ func (r *YtsaurusReconciler) handleTabletNodesOnly(
 			err := ytsaurus.SaveClusterState(ctx, ytv1.ClusterStateUpdateFinishing)
 			return &ctrl.Result{Requeue: true}, err
 		}
+
+	case ytv1.UpdateStateWaitingForTabletNodesRollingUpdate:
+		if componentManager.arePodsRemoved() {
+			ytsaurus.LogUpdate(ctx, "Finishing")
+			err := ytsaurus.SaveClusterState(ctx, ytv1.ClusterStateUpdateFinishing)
+			return &ctrl.Result{Requeue: true}, err
+		}
 	}
 
 	return nil, nil
 }
 
+// isTabletNodesRollingUpdate checks if tablet nodes are updated node by node
+// without removal of tablet cells.
+func isTabletNodesRollingUpdate(resource *ytv1.Ytsaurus) bool {
+	for _, spec := range resource.Spec.TabletNodes {
+		strategy := spec.UpdateStrategy
+		if strategy == nil || strategy.Type != ytv1.ComponentUpdateStrategyTypeRolling {
+			return false
+		}
+	}
+	return true
+}
+
 func getComponentNames(components []components.Component) []string {
 	if components == nil {
 		return nil
@@ -419,7 +517,7 @@ func chooseUpdateFlow(spec ytv1.YtsaurusSpec, needUpdate []components.Component)
 	var execNodeNames []string
 	var statelessNames []string
 	for _, comp := range needUpdate {
-		if comp.GetType() == consts.MasterType {
+		if comp.GetType() == consts.MasterType || comp.GetType() == consts.SecondaryMasterType {
 			masterNeedsUpdate = true
 			masterNames = append(masterNames, comp.GetName())
 			continue
@@ -504,6 +602,265 @@ func chooseUpdateFlow(spec ytv1.YtsaurusSpec, needUpdate []components.Component)
 	}
 }
 
+// needMaintenanceWindow checks that the update flow enables safe mode or switches masters to read-only,
+// so it should be started in a maintenance window.
+func needMaintenanceWindow(flow ytv1.UpdateFlow) bool {
+	return flow == ytv1.UpdateFlowFull || flow == ytv1.UpdateFlowMaster || flow == ytv1.UpdateFlowTabletNodes
+}
+
+// handleMaintenanceWindow holds the update in WaitingForMaintenanceWindow state until one of
+// maintenance windows is open or the ignore annotation is set.
+func (r *YtsaurusReconciler) handleMaintenanceWindow(
+	ctx context.Context,
+	ytsaurus *apiProxy.Ytsaurus,
+	componentManager *ComponentManager,
+) (*ctrl.Result, error) {
+	resource := ytsaurus.GetResource()
+	state := resource.Status.UpdateStatus.State
+	if state != ytv1.UpdateStateNone && state != ytv1.UpdateStateWaitingForMaintenanceWindow {
+		return nil, nil
+	}
+	if !needMaintenanceWindow(ytsaurus.GetUpdateFlow()) {
+		return nil, nil
+	}
+
+	if state == ytv1.UpdateStateWaitingForMaintenanceWindow && !componentManager.needSync() {
+		ytsaurus.LogUpdate(ctx, "Spec changed back, update is canceling")
+		err := ytsaurus.SaveClusterState(ctx, ytv1.ClusterStateCancelUpdate)
+		return &ctrl.Result{Requeue: true}, err
+	}
+
+	isOpen, nextOpening, err := ytv1.IsMaintenanceWindowOpen(resource.Spec.MaintenanceWindows, time.Now())
+	if err != nil {
+		return &ctrl.Result{Requeue: true}, err
+	}
+	ignoreWindows := resource.Annotations[consts.IgnoreMaintenanceWindowsAnnotation] == "true"
+
+	if isOpen || ignoreWindows {
+		// The annotation allows only the update which is starting now, later updates wait for windows again.
+		if ignoreWindows {
+			if !isOpen {
+				ytsaurus.LogUpdate(ctx, "Maintenance windows are ignored by annotation, checking the possibility of updating")
+			}
+			if err := ytsaurus.RemoveAnnotation(ctx, consts.IgnoreMaintenanceWindowsAnnotation); err != nil {
+				return &ctrl.Result{Requeue: true}, err
+			}
+		}
+		if state == ytv1.UpdateStateNone {
+			return nil, nil
+		}
+		if isOpen {
+			ytsaurus.LogUpdate(ctx, "Maintenance window is open, checking the possibility of updating")
+		}
+		err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStatePossibilityCheck)
+		return &ctrl.Result{Requeue: true}, err
+	}
+
+	if state == ytv1.UpdateStateNone {
+		ytsaurus.LogUpdate(ctx, fmt.Sprintf("Waiting for maintenance window, next opening at %s", nextOpening.Format(time.RFC3339)))
+		if err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForMaintenanceWindow); err != nil {
+			return &ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	requeueAfter := consts.MaintenanceWindowCheckPeriod
+	if untilOpening := time.Until(nextOpening); !nextOpening.IsZero() && untilOpening < requeueAfter {
+		requeueAfter = untilOpening
+	}
+	return &ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// isRollbackPossible checks that the update can be rolled back from the state by recreating pods with the previous spec.
+func isRollbackPossible(state ytv1.UpdateState) bool {
+	return state == ytv1.UpdateStateWaitingForPodsRemoval || state == ytv1.UpdateStateWaitingForPodsCreation
+}
+
+// nextMasterInstanceCount returns the number of primary masters for the next step of masters resize.
+func nextMasterInstanceCount(current, target int32) int32 {
+	switch {
+	case current < target:
+		return current + 1
+	case current > target:
+		return current - 1
+	}
+	return current
+}
+
+// startMasterResize starts masters resize from the number of masters the cluster was running with,
+// so the first update step adds or removes a single peer.
+func startMasterResize(ytsaurus *apiProxy.Ytsaurus) {
+	resource := ytsaurus.GetResource()
+	previousSpec := ytsaurus.GetPreviousSpec()
+	if previousSpec == nil || previousSpec.PrimaryMasters.InstanceCount == 0 {
+		return
+	}
+	current := previousSpec.PrimaryMasters.InstanceCount
+	target := resource.Spec.PrimaryMasters.InstanceCount
+	if current != target {
+		resource.Status.UpdateStatus.MasterInstanceCount = nextMasterInstanceCount(current, target)
+	}
+}
+
+// applyMasterResizeStep renders primary masters with the number of peers of the current resize step.
+// Rolled back cluster is rendered from the previous spec as is.
+func applyMasterResizeStep(ytsaurus *apiProxy.Ytsaurus) {
+	resource := ytsaurus.GetResource()
+	if count := resource.Status.UpdateStatus.MasterInstanceCount; count != 0 && !ytsaurus.IsRolledBack() {
+		resource.Spec.PrimaryMasters.InstanceCount = count
+	}
+}
+
+// handleMasterResizeStep moves masters resize to the next step once the quorum of the current one is verified.
+// Snapshots are built again, then masters are restarted with the new peer list. Nil result means that resize is done.
+func handleMasterResizeStep(ctx context.Context, ytsaurus *apiProxy.Ytsaurus, masterInstanceCount int32) (*ctrl.Result, error) {
+	updateStatus := &ytsaurus.GetResource().Status.UpdateStatus
+	current := updateStatus.MasterInstanceCount
+	if current == 0 || current == masterInstanceCount || ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionRollingBack) {
+		return nil, nil
+	}
+
+	next := nextMasterInstanceCount(current, masterInstanceCount)
+	ytsaurus.LogUpdate(ctx, fmt.Sprintf("Resizing masters from %d to %d peers, waiting for snapshots", current, next))
+	updateStatus.MasterInstanceCount = next
+	ytsaurus.RemoveUpdateStatusConditions(
+		consts.ConditionSnapshotsMonitoringInfoSaved,
+		consts.ConditionSnapshotsBuildingStarted,
+		consts.ConditionSnaphotsSaved,
+		consts.ConditionMasterSnapshotsExportPrepared,
+		consts.ConditionMasterSnapshotsExported,
+		labeller.GetPodsRemovingStartedCondition(string(consts.MasterType)),
+		labeller.GetPodsRemovedCondition(string(consts.MasterType)),
+		consts.ConditionMasterExitedReadOnly,
+		consts.ConditionMasterQuorumVerified,
+	)
+	err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForSnapshots)
+	return &ctrl.Result{Requeue: true}, err
+}
+
+// isMasterResizeRollbackPossible checks that masters have not gone further than the first resize step,
+// so the previous peer list differs by a single peer.
+func isMasterResizeRollbackPossible(ytsaurus *apiProxy.Ytsaurus) bool {
+	count := ytsaurus.GetResource().Status.UpdateStatus.MasterInstanceCount
+	if count == 0 {
+		return true
+	}
+	previous := ytsaurus.GetPreviousSpec().PrimaryMasters.InstanceCount
+	return nextMasterInstanceCount(previous, count) == count
+}
+
+// handleUpdateDeadline checks whether the current update state has exceeded its deadline.
+// If pods are stuck on removal or creation, the update continues from the pods removal with components
+// rendered from the previous spec, so they are recreated with the previous images and configs,
+// then masters exit read-only and safe mode is disabled as usual. Otherwise the update is only marked as failed.
+// Deadlines are taken from the spec of the object, since the rolled back cluster is rendered from the previous one.
+func handleUpdateDeadline(ctx context.Context, ytsaurus *apiProxy.Ytsaurus, deadlines *ytv1.UpdateDeadlinesSpec) (*ctrl.Result, error) {
+	resource := ytsaurus.GetResource()
+	updateStatus := resource.Status.UpdateStatus
+
+	// Waiting for a maintenance window is expected to be long and does not affect the cluster.
+	if updateStatus.State == ytv1.UpdateStateWaitingForMaintenanceWindow {
+		return nil, nil
+	}
+
+	if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionRollingBack) {
+		return handleRollbackDeadline(ctx, ytsaurus, deadlines)
+	}
+
+	deadline := deadlines.GetStateDeadline(updateStatus.State)
+	if deadline == nil || updateStatus.StateTransitionTime == nil {
+		return nil, nil
+	}
+	if time.Since(updateStatus.StateTransitionTime.Time) < *deadline {
+		return nil, nil
+	}
+
+	if !isRollbackPossible(updateStatus.State) || ytsaurus.GetPreviousSpec() == nil || !isMasterResizeRollbackPossible(ytsaurus) {
+		if ytsaurus.IsStatusConditionTrue(consts.ConditionUpdateFailed) {
+			return nil, nil
+		}
+		message := fmt.Sprintf("Update state %s has exceeded deadline %s, update can't be rolled back automatically",
+			updateStatus.State, *deadline)
+		ytsaurus.APIProxy().RecordWarning("UpdateFailed", message)
+		ytsaurus.SetStatusCondition(metav1.Condition{
+			Type:               consts.ConditionUpdateFailed,
+			Status:             metav1.ConditionTrue,
+			Reason:             "DeadlineExceeded",
+			Message:            message,
+			ObservedGeneration: resource.Generation,
+		})
+		return &ctrl.Result{Requeue: true}, ytsaurus.APIProxy().UpdateStatus(ctx)
+	}
+
+	message := fmt.Sprintf("Update state %s has exceeded deadline %s, rolling back to the previous spec",
+		updateStatus.State, *deadline)
+	ytsaurus.APIProxy().RecordWarning("UpdateFailed", message)
+	ytsaurus.SetStatusCondition(metav1.Condition{
+		Type:               consts.ConditionUpdateFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             consts.UpdateFailedReasonRolledBack,
+		Message:            message,
+		ObservedGeneration: resource.Generation,
+	})
+	ytsaurus.ClearUpdateStatusConditions()
+	ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+		Type:    consts.ConditionRollingBack,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Update",
+		Message: message,
+	})
+	ytsaurus.LogUpdate(ctx, "Rolling back: waiting for pods removal")
+	err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval)
+	return &ctrl.Result{Requeue: true}, err
+}
+
+// handleRollbackDeadline marks the rollback as failed if it has not finished in time.
+// The update is not interrupted, since there is no spec to go back to anymore.
+func handleRollbackDeadline(ctx context.Context, ytsaurus *apiProxy.Ytsaurus, deadlines *ytv1.UpdateDeadlinesSpec) (*ctrl.Result, error) {
+	resource := ytsaurus.GetResource()
+	rollingBack := ytsaurus.GetUpdateStatusCondition(consts.ConditionRollingBack)
+	deadline := deadlines.GetRollbackDeadline()
+	if deadline == nil || time.Since(rollingBack.LastTransitionTime.Time) < *deadline {
+		return nil, nil
+	}
+
+	updateFailed := meta.FindStatusCondition(resource.Status.Conditions, consts.ConditionUpdateFailed)
+	if updateFailed != nil && updateFailed.Reason == consts.UpdateFailedReasonRollbackFailed {
+		return nil, nil
+	}
+	observedGeneration := resource.Generation
+	if updateFailed != nil {
+		observedGeneration = updateFailed.ObservedGeneration
+	}
+
+	message := fmt.Sprintf("Rollback has exceeded deadline %s in update state %s",
+		*deadline, resource.Status.UpdateStatus.State)
+	ytsaurus.APIProxy().RecordWarning("RollbackFailed", message)
+	ytsaurus.SetStatusCondition(metav1.Condition{
+		Type:               consts.ConditionUpdateFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             consts.UpdateFailedReasonRollbackFailed,
+		Message:            message,
+		ObservedGeneration: observedGeneration,
+	})
+	return &ctrl.Result{Requeue: true}, ytsaurus.APIProxy().UpdateStatus(ctx)
+}
+
+// prepareRenderedSpec sets the spec components are rendered from: the previous spec while the update is rolled back,
+// and primary masters of the current resize step. The object itself is not modified.
+// It returns the number of primary masters of the spec to be reached by the resize.
+func prepareRenderedSpec(ctx context.Context, ytsaurus *apiProxy.Ytsaurus) (int32, error) {
+	resource := ytsaurus.GetResource()
+	if err := ytsaurus.FetchPreviousSpec(ctx); err != nil {
+		return 0, err
+	}
+	if previousSpec := ytsaurus.GetPreviousSpec(); previousSpec != nil && ytsaurus.IsRolledBack() {
+		resource.Spec = *previousSpec.DeepCopy()
+	}
+	masterInstanceCount := resource.Spec.PrimaryMasters.InstanceCount
+	applyMasterResizeStep(ytsaurus)
+	return masterInstanceCount, nil
+}
+
 func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -513,6 +870,26 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 	}
 
 	ytsaurus := apiProxy.NewYtsaurus(resource, r.Client, r.Recorder, r.Scheme)
+	defer metrics.ReportClusterState(resource)
+
+	updateDeadlines := resource.Spec.UpdateDeadlines
+	masterInstanceCount, err := prepareRenderedSpec(ctx, ytsaurus)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	// Certificates are issued before components, so pods never start without them.
+	if resource.Spec.ManagedCertificates != nil {
+		ready, err := r.syncCertificates(ctx, ytsaurus)
+		if err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
+		if !ready {
+			logger.Info("Waiting for certificates to be issued")
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+
 	componentManager, err := NewComponentManager(ctx, ytsaurus)
 	if err != nil {
 		return ctrl.Result{Requeue: true}, err
@@ -537,7 +914,24 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 		switch {
 		case !componentManager.needSync():
 			logger.Info("Ytsaurus is running and happy")
-			return ctrl.Result{}, nil
+			if err := ytsaurus.SavePreviousSpec(ctx); err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
+			var requeueAfter time.Duration
+			if resource.Spec.ManagedCertificates != nil {
+				// Check renewal of certificates, which are not owned by the cluster when issued by cert-manager.
+				requeueAfter = consts.ManagedCertificatesCheckPeriod
+			}
+			if len(resource.Spec.GetRemoteClusters()) != 0 {
+				// Check connectivity of remote clusters, the period is shorter than the certificates one.
+				requeueAfter = consts.RemoteClustersCheckPeriod
+			}
+			checking, err := componentManager.checkRemoteClusters(ctx)
+			if checking {
+				// Results of running checks are collected once their jobs are finished.
+				requeueAfter = 10 * time.Second
+			}
+			return ctrl.Result{RequeueAfter: requeueAfter}, err
 
 		case componentManager.needInit():
 			logger.Info("Ytsaurus needs initialization of some components")
@@ -559,6 +953,17 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 				"componentsForUpdateSelected", meta.componentNames,
 				"flow", meta.flow,
 			)
+			if ytsaurus.IsStatusConditionTrue(consts.ConditionUpdateFailed) {
+				ytsaurus.SetStatusCondition(metav1.Condition{
+					Type:    consts.ConditionUpdateFailed,
+					Status:  metav1.ConditionFalse,
+					Reason:  "UpdateStarted",
+					Message: "New update has started",
+				})
+			}
+			if meta.flow == ytv1.UpdateFlowFull || meta.flow == ytv1.UpdateFlowMaster {
+				startMasterResize(ytsaurus)
+			}
 			err = ytsaurus.SaveUpdatingClusterState(ctx, meta.flow, meta.componentNames)
 			if err != nil {
 				return ctrl.Result{}, err
@@ -567,16 +972,23 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 		}
 
 	case ytv1.ClusterStateUpdating:
-		var result *ctrl.Result
-		var err error
+		result, err := handleUpdateDeadline(ctx, ytsaurus, updateDeadlines)
+		if result != nil {
+			return *result, err
+		}
+
+		result, err = r.handleMaintenanceWindow(ctx, ytsaurus, componentManager)
+		if result != nil {
+			return *result, err
+		}
 
 		switch ytsaurus.GetUpdateFlow() {
 		case ytv1.UpdateFlowFull:
-			result, err = r.handleEverything(ctx, ytsaurus, componentManager)
+			result, err = r.handleEverything(ctx, ytsaurus, componentManager, masterInstanceCount)
 		case ytv1.UpdateFlowStateless:
 			result, err = r.handleStateless(ctx, ytsaurus, componentManager)
 		case ytv1.UpdateFlowMaster:
-			result, err = r.handleMasterOnly(ctx, ytsaurus, componentManager)
+			result, err = r.handleMasterOnly(ctx, ytsaurus, componentManager, masterInstanceCount)
 		case ytv1.UpdateFlowTabletNodes:
 			result, err = r.handleTabletNodesOnly(ctx, ytsaurus, componentManager)
 		}
@@ -586,6 +998,11 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 		}
 
 	case ytv1.ClusterStateCancelUpdate:
+		cancelMessage := ""
+		if condition := ytsaurus.GetUpdateStatusCondition(consts.ConditionNoPossibility); condition != nil {
+			cancelMessage = condition.Message
+		}
+		ytsaurus.RecordUpdateHistory(ytv1.UpdateResultCanceled, cancelMessage)
 		if err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateNone); err != nil {
 			return ctrl.Result{Requeue: true}, err
 		}
@@ -599,6 +1016,11 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 		return ctrl.Result{}, err
 
 	case ytv1.ClusterStateUpdateFinishing:
+		if ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionRollingBack) {
+			ytsaurus.RecordUpdateHistory(ytv1.UpdateResultRolledBack, ytsaurus.GetUpdateStatusCondition(consts.ConditionRollingBack).Message)
+		} else {
+			ytsaurus.RecordUpdateHistory(ytv1.UpdateResultFinished, "")
+		}
 		if err := ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateNone); err != nil {
 			return ctrl.Result{Requeue: true}, err
 		}
@@ -621,3 +1043,28 @@ func (r *YtsaurusReconciler) Sync(ctx context.Context, resource *ytv1.Ytsaurus)
 
 	return componentManager.Sync(ctx)
 }
+
+func (r *YtsaurusReconciler) syncCertificates(ctx context.Context, ytsaurus *apiProxy.Ytsaurus) (bool, error) {
+	clusterDomain := getClusterDomain(ytsaurus.APIProxy().Client())
+	certificates := components.NewCertificates(ytconfig.NewGenerator(ytsaurus.GetResource(), clusterDomain), ytsaurus)
+	if err := certificates.Fetch(ctx); err != nil {
+		return false, err
+	}
+
+	resource := ytsaurus.GetResource()
+	oldCondition := meta.FindStatusCondition(resource.Status.Conditions, consts.ConditionCertificatesReady)
+	if oldCondition != nil {
+		oldCondition = oldCondition.DeepCopy()
+	}
+	ready, err := certificates.Sync(ctx)
+	if err != nil {
+		return false, err
+	}
+	newCondition := meta.FindStatusCondition(resource.Status.Conditions, consts.ConditionCertificatesReady)
+	if oldCondition == nil || oldCondition.Status != newCondition.Status || oldCondition.Reason != newCondition.Reason {
+		if err := ytsaurus.APIProxy().UpdateStatus(ctx); err != nil {
+			return false, err
+		}
+	}
+	return ready, nil
+}
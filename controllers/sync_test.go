@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	apiProxy "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+func newUpdatingYtsaurus(t *testing.T, state ytv1.UpdateState, stateDuration time.Duration) (*apiProxy.Ytsaurus, client.Client) {
+	return newUpdatedYtsaurus(t, state, stateDuration, func(spec *ytv1.YtsaurusSpec) {
+		spec.CoreImage = "ytsaurus/ytsaurus:new"
+	})
+}
+
+func newUpdatedYtsaurus(t *testing.T, state ytv1.UpdateState, stateDuration time.Duration, update func(spec *ytv1.YtsaurusSpec)) (*apiProxy.Ytsaurus, client.Client) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, ytv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	resource := &ytv1.Ytsaurus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+		Spec: ytv1.YtsaurusSpec{
+			CommonSpec:     ytv1.CommonSpec{CoreImage: "ytsaurus/ytsaurus:old"},
+			PrimaryMasters: ytv1.MastersSpec{InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1}},
+			HTTPProxies: []ytv1.HTTPProxiesSpec{
+				{InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1}, Role: consts.DefaultHTTPProxyRole},
+			},
+		},
+		Status: ytv1.YtsaurusStatus{
+			State: ytv1.ClusterStateRunning,
+		},
+	}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(resource).
+		WithStatusSubresource(resource).
+		Build()
+	ytsaurus := apiProxy.NewYtsaurus(resource, k8sClient, record.NewFakeRecorder(100), scheme)
+	require.NoError(t, ytsaurus.FetchPreviousSpec(ctx))
+	require.NoError(t, ytsaurus.SavePreviousSpec(ctx))
+
+	update(&resource.Spec)
+	require.NoError(t, k8sClient.Update(ctx, resource))
+
+	startMasterResize(ytsaurus)
+	require.NoError(t, ytsaurus.SaveUpdatingClusterState(ctx, ytv1.UpdateFlowMaster, nil))
+	require.NoError(t, ytsaurus.SaveUpdateState(ctx, state))
+	resource.Status.UpdateStatus.StateTransitionTime = &metav1.Time{Time: time.Now().Add(-stateDuration)}
+	return ytsaurus, k8sClient
+}
+
+func TestHandleUpdateDeadlineNotExceeded(t *testing.T) {
+	ytsaurus, _ := newUpdatingYtsaurus(t, ytv1.UpdateStateWaitingForPodsCreation, time.Minute)
+
+	result, err := handleUpdateDeadline(context.Background(), ytsaurus, nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	deadlines := &ytv1.UpdateDeadlinesSpec{Default: &metav1.Duration{Duration: time.Hour}}
+	result, err = handleUpdateDeadline(context.Background(), ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.False(t, ytsaurus.IsRolledBack())
+}
+
+func TestHandleUpdateDeadlineRollback(t *testing.T) {
+	ctx := context.Background()
+	ytsaurus, k8sClient := newUpdatingYtsaurus(t, ytv1.UpdateStateWaitingForPodsCreation, 2*time.Hour)
+	deadlines := &ytv1.UpdateDeadlinesSpec{
+		Default:  &metav1.Duration{Duration: time.Hour},
+		Rollback: &metav1.Duration{Duration: 3 * time.Hour},
+	}
+
+	result, err := handleUpdateDeadline(ctx, ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	resource := ytsaurus.GetResource()
+	require.Equal(t, ytv1.UpdateStateWaitingForPodsRemoval, resource.Status.UpdateStatus.State)
+	require.True(t, ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionRollingBack))
+	updateFailed := meta.FindStatusCondition(resource.Status.Conditions, consts.ConditionUpdateFailed)
+	require.NotNil(t, updateFailed)
+	require.Equal(t, consts.UpdateFailedReasonRolledBack, updateFailed.Reason)
+	require.Equal(t, resource.Generation, updateFailed.ObservedGeneration)
+	require.True(t, ytsaurus.IsRolledBack())
+	require.Equal(t, "ytsaurus/ytsaurus:old", ytsaurus.GetPreviousSpec().CoreImage)
+
+	// Spec of the object is kept as is.
+	stored := &ytv1.Ytsaurus{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(resource), stored))
+	require.Equal(t, "ytsaurus/ytsaurus:new", stored.Spec.CoreImage)
+
+	// The rollback has its own deadline, state deadlines are not checked anymore.
+	resource.Status.UpdateStatus.StateTransitionTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	result, err = handleUpdateDeadline(ctx, ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	// Rollback has finished, the rolled back generation keeps running the previous spec.
+	ytsaurus.ClearUpdateStatusConditions()
+	require.True(t, ytsaurus.IsRolledBack())
+	resource.Generation++
+	require.False(t, ytsaurus.IsRolledBack())
+}
+
+func TestHandleUpdateDeadlineRollbackFailed(t *testing.T) {
+	ctx := context.Background()
+	ytsaurus, _ := newUpdatingYtsaurus(t, ytv1.UpdateStateWaitingForPodsCreation, 2*time.Hour)
+	deadlines := &ytv1.UpdateDeadlinesSpec{Default: &metav1.Duration{Duration: time.Hour}}
+
+	_, err := handleUpdateDeadline(ctx, ytsaurus, deadlines)
+	require.NoError(t, err)
+
+	resource := ytsaurus.GetResource()
+	rollingBack := ytsaurus.GetUpdateStatusCondition(consts.ConditionRollingBack)
+	rollingBack.LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	result, err := handleUpdateDeadline(ctx, ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	updateFailed := meta.FindStatusCondition(resource.Status.Conditions, consts.ConditionUpdateFailed)
+	require.Equal(t, consts.UpdateFailedReasonRollbackFailed, updateFailed.Reason)
+	require.Equal(t, resource.Generation, updateFailed.ObservedGeneration)
+	require.Equal(t, ytv1.UpdateStateWaitingForPodsRemoval, resource.Status.UpdateStatus.State)
+	require.True(t, ytsaurus.IsRolledBack())
+
+	// Failure is reported once.
+	result, err = handleUpdateDeadline(ctx, ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestHandleUpdateDeadlineRollbackImpossible(t *testing.T) {
+	ytsaurus, _ := newUpdatingYtsaurus(t, ytv1.UpdateStateWaitingForSafeModeEnabled, 2*time.Hour)
+	deadlines := &ytv1.UpdateDeadlinesSpec{Default: &metav1.Duration{Duration: time.Hour}}
+
+	result, err := handleUpdateDeadline(context.Background(), ytsaurus, deadlines)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	resource := ytsaurus.GetResource()
+	require.Equal(t, ytv1.UpdateStateWaitingForSafeModeEnabled, resource.Status.UpdateStatus.State)
+	require.False(t, ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionRollingBack))
+	updateFailed := meta.FindStatusCondition(resource.Status.Conditions, consts.ConditionUpdateFailed)
+	require.Equal(t, "DeadlineExceeded", updateFailed.Reason)
+	require.False(t, ytsaurus.IsRolledBack())
+}
+
+func TestMasterResizeSteps(t *testing.T) {
+	ctx := context.Background()
+	r := &YtsaurusReconciler{}
+	ytsaurus, _ := newUpdatedYtsaurus(t, ytv1.UpdateStateWaitingForMasterQuorum, time.Minute, func(spec *ytv1.YtsaurusSpec) {
+		spec.PrimaryMasters.InstanceCount = 3
+	})
+	resource := ytsaurus.GetResource()
+	require.Equal(t, int32(2), resource.Status.UpdateStatus.MasterInstanceCount)
+
+	// Masters are rendered with the peers of the current step.
+	applyMasterResizeStep(ytsaurus)
+	require.Equal(t, int32(2), resource.Spec.PrimaryMasters.InstanceCount)
+
+	ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{Type: consts.ConditionSnaphotsSaved, Status: metav1.ConditionTrue, Reason: "Update"})
+	ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{Type: "MasterPodsRemoved", Status: metav1.ConditionTrue, Reason: "Update"})
+	ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{Type: consts.ConditionSafeModeEnabled, Status: metav1.ConditionTrue, Reason: "Update"})
+
+	// Quorum of the current step is not verified yet.
+	result, err := r.handleMasterOnly(ctx, ytsaurus, nil, 3)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	// Next peer is added after the quorum of two masters is verified.
+	ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{Type: consts.ConditionMasterQuorumVerified, Status: metav1.ConditionTrue, Reason: "Update"})
+	result, err = r.handleMasterOnly(ctx, ytsaurus, nil, 3)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, int32(3), resource.Status.UpdateStatus.MasterInstanceCount)
+	require.Equal(t, ytv1.UpdateStateWaitingForSnapshots, resource.Status.UpdateStatus.State)
+	require.False(t, ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionSnaphotsSaved))
+	require.False(t, ytsaurus.IsUpdateStatusConditionTrue("MasterPodsRemoved"))
+	require.False(t, ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterQuorumVerified))
+	require.True(t, ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionSafeModeEnabled))
+	require.False(t, isMasterResizeRollbackPossible(ytsaurus))
+
+	// Resize is finished after the quorum of all masters is verified.
+	require.NoError(t, ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForMasterQuorum))
+	ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{Type: consts.ConditionMasterQuorumVerified, Status: metav1.ConditionTrue, Reason: "Update"})
+	result, err = r.handleMasterOnly(ctx, ytsaurus, nil, 3)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, ytv1.UpdateStateWaitingForSafeModeDisabled, resource.Status.UpdateStatus.State)
+
+	require.NoError(t, ytsaurus.ClearUpdateStatus(ctx))
+	require.Zero(t, resource.Status.UpdateStatus.MasterInstanceCount)
+}
+
+func TestMasterResizeFirstStep(t *testing.T) {
+	ytsaurus, _ := newUpdatedYtsaurus(t, ytv1.UpdateStateWaitingForMasterQuorum, time.Minute, func(spec *ytv1.YtsaurusSpec) {
+		spec.PrimaryMasters.InstanceCount = 5
+	})
+	require.Equal(t, int32(2), ytsaurus.GetResource().Status.UpdateStatus.MasterInstanceCount)
+	// Rollback to the previous peer list is possible only from the first step.
+	require.True(t, isMasterResizeRollbackPossible(ytsaurus))
+
+	require.Equal(t, int32(4), nextMasterInstanceCount(5, 3))
+	require.Equal(t, int32(3), nextMasterInstanceCount(3, 3))
+}
+
+func TestMasterResizeIsNotStartedWithoutResize(t *testing.T) {
+	ytsaurus, _ := newUpdatingYtsaurus(t, ytv1.UpdateStateWaitingForMasterQuorum, time.Minute)
+	require.Zero(t, ytsaurus.GetResource().Status.UpdateStatus.MasterInstanceCount)
+
+	ytsaurus.SetUpdateStatusCondition(context.Background(), metav1.Condition{Type: consts.ConditionMasterQuorumVerified, Status: metav1.ConditionTrue, Reason: "Update"})
+	result, err := handleMasterResizeStep(context.Background(), ytsaurus, 1)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+// newMaintenanceWindowYtsaurus returns a cluster whose master update waits for a maintenance window,
+// the only window opens once a year, so it is closed.
+func newMaintenanceWindowYtsaurus(t *testing.T, state ytv1.UpdateState, annotations map[string]string) (*apiProxy.Ytsaurus, client.Client) {
+	ytsaurus, k8sClient := newUpdatedYtsaurus(t, state, time.Minute, func(spec *ytv1.YtsaurusSpec) {
+		spec.CoreImage = "ytsaurus/ytsaurus:new"
+		spec.MaintenanceWindows = []ytv1.MaintenanceWindowSpec{
+			{Schedule: "0 0 1 1 *", Duration: metav1.Duration{Duration: time.Minute}},
+		}
+	})
+	resource := ytsaurus.GetResource()
+	resource.Annotations = annotations
+	require.NoError(t, k8sClient.Update(context.Background(), resource))
+	return ytsaurus, k8sClient
+}
+
+func TestMaintenanceWindowHold(t *testing.T) {
+	ytsaurus, _ := newMaintenanceWindowYtsaurus(t, ytv1.UpdateStateNone, nil)
+	componentManager := &ComponentManager{status: ComponentManagerStatus{needSync: true}}
+
+	result, err := (&YtsaurusReconciler{}).handleMaintenanceWindow(context.Background(), ytsaurus, componentManager)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, consts.MaintenanceWindowCheckPeriod, result.RequeueAfter)
+	require.Equal(t, ytv1.UpdateStateWaitingForMaintenanceWindow, ytsaurus.GetResource().Status.UpdateStatus.State)
+
+	// The update keeps waiting.
+	result, err = (&YtsaurusReconciler{}).handleMaintenanceWindow(context.Background(), ytsaurus, componentManager)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, ytv1.UpdateStateWaitingForMaintenanceWindow, ytsaurus.GetResource().Status.UpdateStatus.State)
+}
+
+func TestMaintenanceWindowOverride(t *testing.T) {
+	ctx := context.Background()
+	ytsaurus, k8sClient := newMaintenanceWindowYtsaurus(t, ytv1.UpdateStateWaitingForMaintenanceWindow, map[string]string{
+		consts.IgnoreMaintenanceWindowsAnnotation: "true",
+	})
+	componentManager := &ComponentManager{status: ComponentManagerStatus{needSync: true}}
+
+	result, err := (&YtsaurusReconciler{}).handleMaintenanceWindow(ctx, ytsaurus, componentManager)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.True(t, result.Requeue)
+	resource := ytsaurus.GetResource()
+	require.Equal(t, ytv1.UpdateStatePossibilityCheck, resource.Status.UpdateStatus.State)
+
+	// The annotation allows only this update.
+	require.NotContains(t, resource.Annotations, consts.IgnoreMaintenanceWindowsAnnotation)
+	stored := &ytv1.Ytsaurus{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(resource), stored))
+	require.NotContains(t, stored.Annotations, consts.IgnoreMaintenanceWindowsAnnotation)
+	require.Equal(t, ytv1.UpdateStatePossibilityCheck, stored.Status.UpdateStatus.State)
+
+	// Status is still saved after the annotation removal.
+	require.NoError(t, ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateNone))
+
+	// The next update waits for the window again.
+	result, err = (&YtsaurusReconciler{}).handleMaintenanceWindow(ctx, ytsaurus, componentManager)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, ytv1.UpdateStateWaitingForMaintenanceWindow, resource.Status.UpdateStatus.State)
+}
+
+func TestMaintenanceWindowOverrideOfStartingUpdate(t *testing.T) {
+	ytsaurus, _ := newMaintenanceWindowYtsaurus(t, ytv1.UpdateStateNone, map[string]string{
+		consts.IgnoreMaintenanceWindowsAnnotation: "true",
+	})
+	componentManager := &ComponentManager{status: ComponentManagerStatus{needSync: true}}
+
+	// The update goes on with its flow.
+	result, err := (&YtsaurusReconciler{}).handleMaintenanceWindow(context.Background(), ytsaurus, componentManager)
+	require.NoError(t, err)
+	require.Nil(t, result)
+	resource := ytsaurus.GetResource()
+	require.Equal(t, ytv1.UpdateStateNone, resource.Status.UpdateStatus.State)
+	require.NotContains(t, resource.Annotations, consts.IgnoreMaintenanceWindowsAnnotation)
+}
+
+func TestMaintenanceWindowCancel(t *testing.T) {
+	ytsaurus, _ := newMaintenanceWindowYtsaurus(t, ytv1.UpdateStateWaitingForMaintenanceWindow, nil)
+	// Spec is changed back while the update waits.
+	componentManager := &ComponentManager{status: ComponentManagerStatus{needSync: false}}
+
+	result, err := (&YtsaurusReconciler{}).handleMaintenanceWindow(context.Background(), ytsaurus, componentManager)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.True(t, result.Requeue)
+	require.Equal(t, ytv1.ClusterStateCancelUpdate, ytsaurus.GetResource().Status.State)
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+func (r *TabletCellBundleReconciler) Sync(ctx context.Context, resource *ytv1.TabletCellBundle, ytsaurus *ytv1.Ytsaurus) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	tabletCellBundle := apiproxy.NewTabletCellBundle(resource, r.Client, r.Recorder, r.Scheme)
+
+	cfgen := ytconfig.NewGenerator(ytsaurus, getClusterDomain(tabletCellBundle.APIProxy().Client()))
+
+	component := components.NewTabletCellBundle(cfgen, tabletCellBundle, ytsaurus)
+
+	if err := component.Fetch(ctx); err != nil {
+		logger.Error(err, "failed to fetch tablet cell bundle status for controller")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := component.Sync(ctx); err != nil {
+		logger.Error(err, "component sync failed", "component", "tabletCellBundle")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := tabletCellBundle.APIProxy().UpdateStatus(ctx); err != nil {
+		logger.Error(err, "update tablet cell bundle status failed")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if resource.Status.State != ytv1.TabletCellBundleStateSynced {
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
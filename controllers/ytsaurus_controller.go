@@ -21,6 +21,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/metrics"
 )
 
 // YtsaurusReconciler reconciles a Ytsaurus object
@@ -44,6 +46,7 @@ type YtsaurusReconciler struct {
 }
 
 const configOverridesField = ".spec.configOverrides"
+const remoteClustersField = ".spec.remoteClusters"
 
 // +kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurus,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurus/status,verbs=get;update;patch
@@ -53,6 +56,10 @@ const configOverridesField = ".spec.configOverrides"
 // +kubebuilder:rbac:groups=core,resources=pod,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pod/status,verbs=get
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+
 func (r *YtsaurusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -62,6 +69,9 @@ func (r *YtsaurusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
 		// requeue (we'll need to wait for a new notification), and we can get them
 		// on deleted requests.
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteCluster(req.Namespace, req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	logger.V(1).Info("found Ytsaurus cluster")
@@ -82,6 +92,17 @@ func (r *YtsaurusReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &ytv1.Ytsaurus{}, remoteClustersField, func(rawObj client.Object) []string {
+		ytsaurusResource := rawObj.(*ytv1.Ytsaurus)
+		var names []string
+		for _, ref := range ytsaurusResource.Spec.GetRemoteClusters() {
+			names = append(names, ref.Name)
+		}
+		return names
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ytv1.Ytsaurus{}).
 		Owns(&appsv1.StatefulSet{}).
@@ -94,9 +115,37 @@ func (r *YtsaurusReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForConfigMap),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&ytv1.RemoteYtsaurus{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForRemoteYtsaurus),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Complete(r)
 }
 
+func (r *YtsaurusReconciler) findObjectsForRemoteYtsaurus(ctx context.Context, remoteYtsaurus client.Object) []reconcile.Request {
+	attachedYtsauruses := &ytv1.YtsaurusList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(remoteClustersField, remoteYtsaurus.GetName()),
+		Namespace:     remoteYtsaurus.GetNamespace(),
+	}
+	err := r.List(ctx, attachedYtsauruses, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(attachedYtsauruses.Items))
+	for i, item := range attachedYtsauruses.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
 func (r *YtsaurusReconciler) findObjectsForConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
 	// See https://book.kubebuilder.io/reference/watching-resources/externally-managed for the reference implementation
 	attachedYtsauruses := &ytv1.YtsaurusList{}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+)
+
+// YtsaurusAccountReconciler reconciles a YtsaurusAccount object
+type YtsaurusAccountReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusaccounts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurusaccounts/finalizers,verbs=update
+
+// Reconcile brings the account in YTsaurus to the state declared by YtsaurusAccount.
+// Accounts are reconciled periodically, so manual changes are reverted.
+func (r *YtsaurusAccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var ytsaurusAccount ytv1.YtsaurusAccount
+	if err := r.Get(ctx, req.NamespacedName, &ytsaurusAccount); err != nil {
+		logger.Error(err, "unable to fetch YtsaurusAccount")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var ytsaurus ytv1.Ytsaurus
+	ytsaurusName := types.NamespacedName{Name: ytsaurusAccount.Spec.Ytsaurus.Name, Namespace: req.Namespace}
+	if err := r.Get(ctx, ytsaurusName, &ytsaurus); err != nil {
+		logger.Error(err, "unable to fetch Ytsaurus for account")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, err
+	}
+
+	logger.V(1).Info("found YtsaurusAccount")
+
+	return r.Sync(ctx, &ytsaurusAccount, &ytsaurus)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *YtsaurusAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ytv1.YtsaurusAccount{}).
+		Complete(r)
+}
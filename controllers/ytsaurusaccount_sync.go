@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+func (r *YtsaurusAccountReconciler) Sync(ctx context.Context, resource *ytv1.YtsaurusAccount, ytsaurus *ytv1.Ytsaurus) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	ytsaurusAccount := apiproxy.NewYtsaurusAccount(resource, r.Client, r.Recorder, r.Scheme)
+
+	cfgen := ytconfig.NewGenerator(ytsaurus, getClusterDomain(ytsaurusAccount.APIProxy().Client()))
+
+	component := components.NewYtsaurusAccount(cfgen, ytsaurusAccount, ytsaurus)
+
+	if err := component.Fetch(ctx); err != nil {
+		logger.Error(err, "failed to fetch ytsaurus account status for controller")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := component.Sync(ctx); err != nil {
+		logger.Error(err, "component sync failed", "component", "ytsaurusAccount")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := ytsaurusAccount.APIProxy().UpdateStatus(ctx); err != nil {
+		logger.Error(err, "update ytsaurus account status failed")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if resource.Status.State != ytv1.YtsaurusAccountStateSynced {
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
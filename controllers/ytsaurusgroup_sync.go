@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/components"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+func (r *YtsaurusGroupReconciler) Sync(ctx context.Context, resource *ytv1.YtsaurusGroup, ytsaurus *ytv1.Ytsaurus) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	ytsaurusGroup := apiproxy.NewYtsaurusGroup(resource, r.Client, r.Recorder, r.Scheme)
+
+	cfgen := ytconfig.NewGenerator(ytsaurus, getClusterDomain(ytsaurusGroup.APIProxy().Client()))
+
+	component := components.NewYtsaurusGroup(cfgen, ytsaurusGroup, ytsaurus)
+
+	if err := component.Fetch(ctx); err != nil {
+		logger.Error(err, "failed to fetch ytsaurus group status for controller")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if !resource.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(resource, consts.YtsaurusSubjectFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if !component.Remove(ctx) {
+			if err := ytsaurusGroup.APIProxy().UpdateStatus(ctx); err != nil {
+				logger.Error(err, "update ytsaurus group status failed")
+				return ctrl.Result{Requeue: true}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		controllerutil.RemoveFinalizer(resource, consts.YtsaurusSubjectFinalizer)
+		return ctrl.Result{}, r.Update(ctx, resource)
+	}
+
+	if controllerutil.AddFinalizer(resource, consts.YtsaurusSubjectFinalizer) {
+		if err := r.Update(ctx, resource); err != nil {
+			logger.Error(err, "failed to add finalizer to ytsaurus group")
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	if err := component.Sync(ctx); err != nil {
+		logger.Error(err, "component sync failed", "component", "ytsaurusGroup")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if err := ytsaurusGroup.APIProxy().UpdateStatus(ctx); err != nil {
+		logger.Error(err, "update ytsaurus group status failed")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if resource.Status.State != ytv1.YtsaurusSubjectStateSynced {
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package controllers
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+// YtsaurusUserReconciler reconciles a YtsaurusUser object
+type YtsaurusUserReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurususers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurususers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.ytsaurus.tech,resources=ytsaurususers/finalizers,verbs=update
+
+// Reconcile brings the user in YTsaurus to the state declared by YtsaurusUser.
+// The user is removed from YTsaurus before the resource is deleted.
+func (r *YtsaurusUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var ytsaurusUser ytv1.YtsaurusUser
+	if err := r.Get(ctx, req.NamespacedName, &ytsaurusUser); err != nil {
+		logger.Error(err, "unable to fetch YtsaurusUser")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var ytsaurus ytv1.Ytsaurus
+	ytsaurusName := types.NamespacedName{Name: ytsaurusUser.Spec.Ytsaurus.Name, Namespace: req.Namespace}
+	if err := r.Get(ctx, ytsaurusName, &ytsaurus); err != nil {
+		// There is nothing to clean up if the cluster itself is gone.
+		if apierrors.IsNotFound(err) && !ytsaurusUser.DeletionTimestamp.IsZero() {
+			controllerutil.RemoveFinalizer(&ytsaurusUser, consts.YtsaurusSubjectFinalizer)
+			return ctrl.Result{}, r.Update(ctx, &ytsaurusUser)
+		}
+		logger.Error(err, "unable to fetch Ytsaurus for user")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, err
+	}
+
+	logger.V(1).Info("found YtsaurusUser")
+
+	return r.Sync(ctx, &ytsaurusUser, &ytsaurus)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *YtsaurusUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ytv1.YtsaurusUser{}).
+		Complete(r)
+}
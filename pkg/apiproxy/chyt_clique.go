@@ -0,0 +1,64 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ChytClique struct {
+	apiProxy   APIProxy
+	chytClique *ytv1.ChytClique
+}
+
+func NewChytClique(
+	chytClique *ytv1.ChytClique,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *ChytClique {
+	return &ChytClique{
+		chytClique: chytClique,
+		apiProxy:   NewAPIProxy(chytClique, client, recorder, scheme),
+	}
+}
+
+func (c *ChytClique) GetResource() *ytv1.ChytClique {
+	return c.chytClique
+}
+
+func (c *ChytClique) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *ChytClique) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.chytClique.Status.Conditions, condition)
+}
+
+func (c *ChytClique) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.chytClique.Status.Conditions, conditionType)
+}
+
+func (c *ChytClique) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.chytClique.Status.Conditions, conditionType)
+}
+
+// SetState changes the state of clique reconciliation, it is persisted with the next status update.
+func (c *ChytClique) SetState(ctx context.Context, state ytv1.ChytCliqueState, message string) {
+	logger := log.FromContext(ctx)
+	if c.chytClique.Status.State != state || c.chytClique.Status.Message != message {
+		logger.Info("chyt clique state changed", "state", state, "message", message)
+		if state == ytv1.ChytCliqueStateFailed {
+			c.apiProxy.RecordWarning("ChytClique", message)
+		} else if c.chytClique.Status.State != state {
+			c.apiProxy.RecordNormal("ChytClique", message)
+		}
+	}
+	c.chytClique.Status.State = state
+	c.chytClique.Status.Message = message
+}
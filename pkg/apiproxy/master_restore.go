@@ -0,0 +1,68 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type MasterRestore struct {
+	apiProxy      APIProxy
+	masterRestore *ytv1.MasterRestore
+}
+
+func NewMasterRestore(
+	masterRestore *ytv1.MasterRestore,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *MasterRestore {
+	return &MasterRestore{
+		masterRestore: masterRestore,
+		apiProxy:      NewAPIProxy(masterRestore, client, recorder, scheme),
+	}
+}
+
+func (c *MasterRestore) GetResource() *ytv1.MasterRestore {
+	return c.masterRestore
+}
+
+func (c *MasterRestore) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *MasterRestore) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.masterRestore.Status.Conditions, condition)
+}
+
+func (c *MasterRestore) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.masterRestore.Status.Conditions, conditionType)
+}
+
+func (c *MasterRestore) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.masterRestore.Status.Conditions, conditionType)
+}
+
+func (c *MasterRestore) GetState() ytv1.MasterRestoreState {
+	return c.masterRestore.Status.State
+}
+
+// SetState changes the state of restore, it is persisted with the next status update.
+func (c *MasterRestore) SetState(ctx context.Context, state ytv1.MasterRestoreState, message string) {
+	logger := log.FromContext(ctx)
+	if c.masterRestore.Status.State != state {
+		logger.Info("master restore state changed", "state", state, "message", message)
+		if state == ytv1.MasterRestoreStateFailed {
+			c.apiProxy.RecordWarning("MasterRestore", message)
+		} else {
+			c.apiProxy.RecordNormal("MasterRestore", message)
+		}
+	}
+	c.masterRestore.Status.State = state
+	c.masterRestore.Status.Message = message
+}
@@ -0,0 +1,64 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type SchedulerPoolTree struct {
+	apiProxy          APIProxy
+	schedulerPoolTree *ytv1.SchedulerPoolTree
+}
+
+func NewSchedulerPoolTree(
+	schedulerPoolTree *ytv1.SchedulerPoolTree,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *SchedulerPoolTree {
+	return &SchedulerPoolTree{
+		schedulerPoolTree: schedulerPoolTree,
+		apiProxy:          NewAPIProxy(schedulerPoolTree, client, recorder, scheme),
+	}
+}
+
+func (c *SchedulerPoolTree) GetResource() *ytv1.SchedulerPoolTree {
+	return c.schedulerPoolTree
+}
+
+func (c *SchedulerPoolTree) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *SchedulerPoolTree) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.schedulerPoolTree.Status.Conditions, condition)
+}
+
+func (c *SchedulerPoolTree) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.schedulerPoolTree.Status.Conditions, conditionType)
+}
+
+func (c *SchedulerPoolTree) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.schedulerPoolTree.Status.Conditions, conditionType)
+}
+
+// SetState changes the state of pool tree reconciliation, it is persisted with the next status update.
+func (c *SchedulerPoolTree) SetState(ctx context.Context, state ytv1.SchedulerPoolTreeState, message string) {
+	logger := log.FromContext(ctx)
+	if c.schedulerPoolTree.Status.State != state || c.schedulerPoolTree.Status.Message != message {
+		logger.Info("scheduler pool tree state changed", "state", state, "message", message)
+		if state == ytv1.SchedulerPoolTreeStateFailed {
+			c.apiProxy.RecordWarning("SchedulerPoolTree", message)
+		} else if c.schedulerPoolTree.Status.State != state {
+			c.apiProxy.RecordNormal("SchedulerPoolTree", message)
+		}
+	}
+	c.schedulerPoolTree.Status.State = state
+	c.schedulerPoolTree.Status.Message = message
+}
@@ -0,0 +1,64 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type TabletCellBundle struct {
+	apiProxy         APIProxy
+	tabletCellBundle *ytv1.TabletCellBundle
+}
+
+func NewTabletCellBundle(
+	tabletCellBundle *ytv1.TabletCellBundle,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *TabletCellBundle {
+	return &TabletCellBundle{
+		tabletCellBundle: tabletCellBundle,
+		apiProxy:         NewAPIProxy(tabletCellBundle, client, recorder, scheme),
+	}
+}
+
+func (c *TabletCellBundle) GetResource() *ytv1.TabletCellBundle {
+	return c.tabletCellBundle
+}
+
+func (c *TabletCellBundle) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *TabletCellBundle) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.tabletCellBundle.Status.Conditions, condition)
+}
+
+func (c *TabletCellBundle) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.tabletCellBundle.Status.Conditions, conditionType)
+}
+
+func (c *TabletCellBundle) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.tabletCellBundle.Status.Conditions, conditionType)
+}
+
+// SetState changes the state of bundle reconciliation, it is persisted with the next status update.
+func (c *TabletCellBundle) SetState(ctx context.Context, state ytv1.TabletCellBundleState, message string) {
+	logger := log.FromContext(ctx)
+	if c.tabletCellBundle.Status.State != state || c.tabletCellBundle.Status.Message != message {
+		logger.Info("tablet cell bundle state changed", "state", state, "message", message)
+		if state == ytv1.TabletCellBundleStateFailed {
+			c.apiProxy.RecordWarning("TabletCellBundle", message)
+		} else if c.tabletCellBundle.Status.State != state {
+			c.apiProxy.RecordNormal("TabletCellBundle", message)
+		}
+	}
+	c.tabletCellBundle.Status.State = state
+	c.tabletCellBundle.Status.Message = message
+}
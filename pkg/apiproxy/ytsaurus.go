@@ -3,22 +3,32 @@ package apiproxy
 import (
 	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/metrics"
 )
 
 type Ytsaurus struct {
 	apiProxy APIProxy
 	ytsaurus *ytv1.Ytsaurus
+
+	previousSpecConfigMap corev1.ConfigMap
+	previousSpec          *ytv1.YtsaurusSpec
 }
 
 func NewYtsaurus(
@@ -28,10 +38,27 @@ func NewYtsaurus(
 	scheme *runtime.Scheme) *Ytsaurus {
 	return &Ytsaurus{
 		ytsaurus: ytsaurus,
-		apiProxy: NewAPIProxy(ytsaurus, client, recorder, scheme),
+		apiProxy: &ytsaurusAPIProxy{
+			APIProxy: NewAPIProxy(ytsaurus, client, recorder, scheme),
+			ytsaurus: ytsaurus,
+		},
 	}
 }
 
+// ytsaurusAPIProxy keeps the in-memory spec on status updates,
+// since components of a rolled back cluster are rendered from the previous spec.
+type ytsaurusAPIProxy struct {
+	APIProxy
+	ytsaurus *ytv1.Ytsaurus
+}
+
+func (c *ytsaurusAPIProxy) UpdateStatus(ctx context.Context) error {
+	spec := c.ytsaurus.Spec.DeepCopy()
+	err := c.APIProxy.UpdateStatus(ctx)
+	c.ytsaurus.Spec = *spec
+	return err
+}
+
 func (c *Ytsaurus) APIProxy() APIProxy {
 	return c.apiProxy
 }
@@ -68,6 +95,10 @@ func (c *Ytsaurus) IsUpdateStatusConditionTrue(condition string) bool {
 	return meta.IsStatusConditionTrue(c.ytsaurus.Status.UpdateStatus.Conditions, condition)
 }
 
+func (c *Ytsaurus) GetUpdateStatusCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(c.ytsaurus.Status.UpdateStatus.Conditions, conditionType)
+}
+
 func (c *Ytsaurus) SetUpdateStatusCondition(ctx context.Context, condition metav1.Condition) {
 	logger := log.FromContext(ctx)
 	logger.Info("Setting update status condition", "condition", condition)
@@ -81,9 +112,156 @@ func (c *Ytsaurus) ClearUpdateStatus(ctx context.Context) error {
 	c.ytsaurus.Status.UpdateStatus.MasterMonitoringPaths = make([]string, 0)
 	c.ytsaurus.Status.UpdateStatus.Components = nil
 	c.ytsaurus.Status.UpdateStatus.Flow = ytv1.UpdateFlowNone
+	c.ytsaurus.Status.UpdateStatus.StateTransitionTime = nil
+	c.ytsaurus.Status.UpdateStatus.StartTime = nil
+	c.ytsaurus.Status.UpdateStatus.MasterInstanceCount = 0
+	c.ytsaurus.Status.UpdateStatus.ImageFrom = ""
+	c.ytsaurus.Status.UpdateStatus.ImageTo = ""
 	return c.apiProxy.UpdateStatus(ctx)
 }
 
+// RecordUpdateHistory adds the current update into the update history and emits an event,
+// must be called before ClearUpdateStatus. Repeated calls for the same update replace its entry.
+func (c *Ytsaurus) RecordUpdateHistory(result ytv1.UpdateResult, message string) {
+	status := &c.ytsaurus.Status
+	if status.UpdateStatus.StartTime == nil && status.UpdateStatus.Flow == ytv1.UpdateFlowNone {
+		// Update status has been cleared already, the update is in the history.
+		return
+	}
+	entry := ytv1.UpdateHistoryEntry{
+		StartTime:  status.UpdateStatus.StartTime,
+		EndTime:    metav1.Now(),
+		Flow:       status.UpdateStatus.Flow,
+		Components: status.UpdateStatus.Components,
+		ImageFrom:  status.UpdateStatus.ImageFrom,
+		ImageTo:    status.UpdateStatus.ImageTo,
+		Result:     result,
+		Message:    message,
+	}
+	if entry.ImageTo == "" {
+		// Update was started by the operator which did not capture images.
+		entry.ImageTo = c.ytsaurus.Spec.CoreImage
+		if previousSpec := c.GetPreviousSpec(); previousSpec != nil {
+			entry.ImageFrom = previousSpec.CoreImage
+		}
+	}
+
+	history := status.UpdateHistory
+	if n := len(history); n > 0 && entry.StartTime != nil && history[n-1].StartTime != nil &&
+		history[n-1].StartTime.Equal(entry.StartTime) {
+		history = history[:n-1]
+	}
+	history = append(history, entry)
+	if len(history) > consts.MaxUpdateHistoryLength {
+		history = history[len(history)-consts.MaxUpdateHistoryLength:]
+	}
+	status.UpdateHistory = history
+
+	eventMessage := fmt.Sprintf("Update %s, flow: %q, components: %v", strings.ToLower(string(result)), entry.Flow, entry.Components)
+	if message != "" {
+		eventMessage = fmt.Sprintf("%s: %s", eventMessage, message)
+	}
+	if result == ytv1.UpdateResultFinished {
+		c.apiProxy.RecordNormal("Update"+string(result), eventMessage)
+	} else {
+		c.apiProxy.RecordWarning("Update"+string(result), eventMessage)
+	}
+}
+
+// ClearUpdateStatusConditions forgets about the steps taken by the current update.
+func (c *Ytsaurus) ClearUpdateStatusConditions() {
+	c.ytsaurus.Status.UpdateStatus.Conditions = make([]metav1.Condition, 0)
+}
+
+func (c *Ytsaurus) RemoveUpdateStatusConditions(conditionTypes ...string) {
+	for _, conditionType := range conditionTypes {
+		meta.RemoveStatusCondition(&c.ytsaurus.Status.UpdateStatus.Conditions, conditionType)
+	}
+}
+
+func (c *Ytsaurus) getPreviousSpecConfigMapName() string {
+	return fmt.Sprintf("%s-previous-spec", c.ytsaurus.Name)
+}
+
+// FetchPreviousSpec reads the spec the cluster was running with before the update,
+// it is kept in a config map to not duplicate the whole spec in the status.
+func (c *Ytsaurus) FetchPreviousSpec(ctx context.Context) error {
+	c.previousSpecConfigMap = corev1.ConfigMap{}
+	c.previousSpec = nil
+	if err := c.apiProxy.FetchObject(ctx, c.getPreviousSpecConfigMapName(), &c.previousSpecConfigMap); err != nil {
+		return err
+	}
+	data, ok := c.previousSpecConfigMap.Data[consts.PreviousSpecConfigMapKey]
+	if !ok {
+		return nil
+	}
+	spec := &ytv1.YtsaurusSpec{}
+	if err := json.Unmarshal([]byte(data), spec); err != nil {
+		return err
+	}
+	c.previousSpec = spec
+	return nil
+}
+
+// SavePreviousSpec remembers the current spec as the one to roll back to if the next update fails.
+func (c *Ytsaurus) SavePreviousSpec(ctx context.Context) error {
+	spec, err := json.Marshal(c.ytsaurus.Spec)
+	if err != nil {
+		return err
+	}
+	if c.previousSpecConfigMap.Data[consts.PreviousSpecConfigMapKey] == string(spec) {
+		return nil
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.getPreviousSpecConfigMapName(),
+			Namespace: c.ytsaurus.Namespace,
+		},
+		Data: map[string]string{
+			consts.PreviousSpecConfigMapKey: string(spec),
+		},
+	}
+	if err := c.apiProxy.SyncObject(ctx, &c.previousSpecConfigMap, configMap); err != nil {
+		return err
+	}
+	c.previousSpecConfigMap = *configMap
+	c.previousSpec = c.ytsaurus.Spec.DeepCopy()
+	return nil
+}
+
+// GetPreviousSpec returns the spec fetched by FetchPreviousSpec, nil if it has never been saved.
+func (c *Ytsaurus) GetPreviousSpec() *ytv1.YtsaurusSpec {
+	return c.previousSpec
+}
+
+// IsRolledBack checks that the cluster should run the previous spec: the update is being rolled back,
+// or the current generation of the spec was rolled back and has not been changed since that.
+func (c *Ytsaurus) IsRolledBack() bool {
+	if c.IsUpdateStatusConditionTrue(consts.ConditionRollingBack) {
+		return true
+	}
+	condition := meta.FindStatusCondition(c.ytsaurus.Status.Conditions, consts.ConditionUpdateFailed)
+	return condition != nil && condition.Status == metav1.ConditionTrue &&
+		condition.ObservedGeneration == c.ytsaurus.Generation &&
+		(condition.Reason == consts.UpdateFailedReasonRolledBack || condition.Reason == consts.UpdateFailedReasonRollbackFailed)
+}
+
+// RemoveAnnotation removes the annotation from the object, the in-memory spec and status are kept as is.
+func (c *Ytsaurus) RemoveAnnotation(ctx context.Context, key string) error {
+	if _, ok := c.ytsaurus.Annotations[key]; !ok {
+		return nil
+	}
+	base := c.ytsaurus.DeepCopy()
+	patched := base.DeepCopy()
+	delete(patched.Annotations, key)
+	if err := c.apiProxy.Client().Patch(ctx, patched, client.MergeFrom(base)); err != nil {
+		return err
+	}
+	delete(c.ytsaurus.Annotations, key)
+	c.ytsaurus.ResourceVersion = patched.ResourceVersion
+	return nil
+}
+
 func (c *Ytsaurus) LogUpdate(ctx context.Context, message string) {
 	logger := log.FromContext(ctx)
 	c.apiProxy.RecordNormal("Update", message)
@@ -95,33 +273,59 @@ func (c *Ytsaurus) SaveUpdatingClusterState(ctx context.Context, flow ytv1.Updat
 	c.ytsaurus.Status.State = ytv1.ClusterStateUpdating
 	c.ytsaurus.Status.UpdateStatus.Flow = flow
 	c.ytsaurus.Status.UpdateStatus.Components = components
+	c.ytsaurus.Status.UpdateStatus.StateTransitionTime = ptr.To(metav1.Now())
+	c.ytsaurus.Status.UpdateStatus.StartTime = c.ytsaurus.Status.UpdateStatus.StateTransitionTime
+	// Images are captured at the start, since the spec is replaced by the previous one on rollback.
+	c.ytsaurus.Status.UpdateStatus.ImageTo = c.ytsaurus.Spec.CoreImage
+	c.ytsaurus.Status.UpdateStatus.ImageFrom = ""
+	if previousSpec := c.GetPreviousSpec(); previousSpec != nil {
+		c.ytsaurus.Status.UpdateStatus.ImageFrom = previousSpec.CoreImage
+	}
 
 	if err := c.apiProxy.UpdateStatus(ctx); err != nil {
 		logger.Error(err, "unable to update Ytsaurus cluster status")
 		return err
 	}
 
+	c.apiProxy.RecordNormal("UpdateStarted", fmt.Sprintf("Update started, flow: %q, components: %v", flow, components))
 	return nil
 }
 
 func (c *Ytsaurus) SaveClusterState(ctx context.Context, clusterState ytv1.ClusterState) error {
 	logger := log.FromContext(ctx)
+	oldState := c.ytsaurus.Status.State
 	c.ytsaurus.Status.State = clusterState
 	if err := c.apiProxy.UpdateStatus(ctx); err != nil {
 		logger.Error(err, "unable to update Ytsaurus cluster status")
 		return err
 	}
 
+	if oldState != clusterState {
+		c.apiProxy.RecordNormal("ClusterStateChanged",
+			fmt.Sprintf("Cluster state changed from %s to %s", oldState, clusterState))
+	}
 	return nil
 }
 
 func (c *Ytsaurus) SaveUpdateState(ctx context.Context, updateState ytv1.UpdateState) error {
 	logger := log.FromContext(ctx)
+	oldState := c.ytsaurus.Status.UpdateStatus.State
+	oldTransitionTime := c.ytsaurus.Status.UpdateStatus.StateTransitionTime
+	if oldState != updateState {
+		c.ytsaurus.Status.UpdateStatus.StateTransitionTime = ptr.To(metav1.Now())
+	}
 	c.ytsaurus.Status.UpdateStatus.State = updateState
 	if err := c.apiProxy.UpdateStatus(ctx); err != nil {
 		logger.Error(err, "unable to update Ytsaurus update state")
 		return err
 	}
+	if oldState != updateState && oldState != "" {
+		c.apiProxy.RecordNormal("UpdateStateChanged",
+			fmt.Sprintf("Update state changed from %s to %s", oldState, updateState))
+		if oldState != ytv1.UpdateStateNone && oldTransitionTime != nil {
+			metrics.ObserveUpdateStateDuration(c.ytsaurus.Namespace, c.ytsaurus.Name, oldState, time.Since(oldTransitionTime.Time))
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,64 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type YtsaurusAccount struct {
+	apiProxy        APIProxy
+	ytsaurusAccount *ytv1.YtsaurusAccount
+}
+
+func NewYtsaurusAccount(
+	ytsaurusAccount *ytv1.YtsaurusAccount,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *YtsaurusAccount {
+	return &YtsaurusAccount{
+		ytsaurusAccount: ytsaurusAccount,
+		apiProxy:        NewAPIProxy(ytsaurusAccount, client, recorder, scheme),
+	}
+}
+
+func (c *YtsaurusAccount) GetResource() *ytv1.YtsaurusAccount {
+	return c.ytsaurusAccount
+}
+
+func (c *YtsaurusAccount) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *YtsaurusAccount) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.ytsaurusAccount.Status.Conditions, condition)
+}
+
+func (c *YtsaurusAccount) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.ytsaurusAccount.Status.Conditions, conditionType)
+}
+
+func (c *YtsaurusAccount) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.ytsaurusAccount.Status.Conditions, conditionType)
+}
+
+// SetState changes the state of account reconciliation, it is persisted with the next status update.
+func (c *YtsaurusAccount) SetState(ctx context.Context, state ytv1.YtsaurusAccountState, message string) {
+	logger := log.FromContext(ctx)
+	if c.ytsaurusAccount.Status.State != state || c.ytsaurusAccount.Status.Message != message {
+		logger.Info("account state changed", "state", state, "message", message)
+		if state == ytv1.YtsaurusAccountStateFailed {
+			c.apiProxy.RecordWarning("YtsaurusAccount", message)
+		} else if c.ytsaurusAccount.Status.State != state {
+			c.apiProxy.RecordNormal("YtsaurusAccount", message)
+		}
+	}
+	c.ytsaurusAccount.Status.State = state
+	c.ytsaurusAccount.Status.Message = message
+}
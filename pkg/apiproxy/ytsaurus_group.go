@@ -0,0 +1,64 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type YtsaurusGroup struct {
+	apiProxy      APIProxy
+	ytsaurusGroup *ytv1.YtsaurusGroup
+}
+
+func NewYtsaurusGroup(
+	ytsaurusGroup *ytv1.YtsaurusGroup,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *YtsaurusGroup {
+	return &YtsaurusGroup{
+		ytsaurusGroup: ytsaurusGroup,
+		apiProxy:      NewAPIProxy(ytsaurusGroup, client, recorder, scheme),
+	}
+}
+
+func (c *YtsaurusGroup) GetResource() *ytv1.YtsaurusGroup {
+	return c.ytsaurusGroup
+}
+
+func (c *YtsaurusGroup) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *YtsaurusGroup) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.ytsaurusGroup.Status.Conditions, condition)
+}
+
+func (c *YtsaurusGroup) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.ytsaurusGroup.Status.Conditions, conditionType)
+}
+
+func (c *YtsaurusGroup) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.ytsaurusGroup.Status.Conditions, conditionType)
+}
+
+// SetState changes the state of group reconciliation, it is persisted with the next status update.
+func (c *YtsaurusGroup) SetState(ctx context.Context, state ytv1.YtsaurusSubjectState, message string) {
+	logger := log.FromContext(ctx)
+	if c.ytsaurusGroup.Status.State != state || c.ytsaurusGroup.Status.Message != message {
+		logger.Info("group state changed", "state", state, "message", message)
+		if state == ytv1.YtsaurusSubjectStateFailed {
+			c.apiProxy.RecordWarning("YtsaurusGroup", message)
+		} else if c.ytsaurusGroup.Status.State != state {
+			c.apiProxy.RecordNormal("YtsaurusGroup", message)
+		}
+	}
+	c.ytsaurusGroup.Status.State = state
+	c.ytsaurusGroup.Status.Message = message
+}
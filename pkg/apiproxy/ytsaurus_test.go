@@ -0,0 +1,135 @@
+package apiproxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+func newTestYtsaurus(t *testing.T) (*Ytsaurus, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ytv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	resource := &ytv1.Ytsaurus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: ytv1.YtsaurusSpec{
+			CommonSpec: ytv1.CommonSpec{CoreImage: "ytsaurus/ytsaurus:new"},
+		},
+		Status: ytv1.YtsaurusStatus{
+			State:        ytv1.ClusterStateRunning,
+			UpdateStatus: ytv1.UpdateStatus{State: ytv1.UpdateStateNone},
+		},
+	}
+	previousSpec, err := json.Marshal(ytv1.YtsaurusSpec{
+		CommonSpec: ytv1.CommonSpec{CoreImage: "ytsaurus/ytsaurus:old"},
+	})
+	require.NoError(t, err)
+	previousSpecConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-previous-spec", Namespace: "default"},
+		Data:       map[string]string{consts.PreviousSpecConfigMapKey: string(previousSpec)},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(resource, previousSpecConfigMap).
+		WithStatusSubresource(resource).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	ytsaurus := NewYtsaurus(resource, k8sClient, recorder, scheme)
+	require.NoError(t, ytsaurus.FetchPreviousSpec(context.Background()))
+	return ytsaurus, recorder
+}
+
+func runTestUpdate(t *testing.T, ytsaurus *Ytsaurus, result ytv1.UpdateResult) {
+	ctx := context.Background()
+	require.NoError(t, ytsaurus.SaveUpdatingClusterState(ctx, ytv1.UpdateFlowStateless, []string{"HttpProxy"}))
+	require.NoError(t, ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateWaitingForPodsRemoval))
+	require.NoError(t, ytsaurus.SaveClusterState(ctx, ytv1.ClusterStateUpdateFinishing))
+	ytsaurus.RecordUpdateHistory(result, "")
+	require.NoError(t, ytsaurus.SaveUpdateState(ctx, ytv1.UpdateStateNone))
+	require.NoError(t, ytsaurus.ClearUpdateStatus(ctx))
+	require.NoError(t, ytsaurus.SaveClusterState(ctx, ytv1.ClusterStateRunning))
+}
+
+func TestRecordUpdateHistory(t *testing.T) {
+	ytsaurus, recorder := newTestYtsaurus(t)
+
+	runTestUpdate(t, ytsaurus, ytv1.UpdateResultFinished)
+
+	history := ytsaurus.GetResource().Status.UpdateHistory
+	require.Len(t, history, 1)
+	require.Equal(t, ytv1.UpdateResultFinished, history[0].Result)
+	require.Equal(t, ytv1.UpdateFlowStateless, history[0].Flow)
+	require.Equal(t, []string{"HttpProxy"}, history[0].Components)
+	require.Equal(t, "ytsaurus/ytsaurus:old", history[0].ImageFrom)
+	require.Equal(t, "ytsaurus/ytsaurus:new", history[0].ImageTo)
+	require.NotNil(t, history[0].StartTime)
+	require.Nil(t, ytsaurus.GetResource().Status.UpdateStatus.StartTime)
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	require.Equal(t, []string{
+		`Normal UpdateStarted Update started, flow: "Stateless", components: [HttpProxy]`,
+		"Normal UpdateStateChanged Update state changed from None to WaitingForPodsRemoval",
+		"Normal ClusterStateChanged Cluster state changed from Updating to UpdateFinishing",
+		`Normal UpdateFinished Update finished, flow: "Stateless", components: [HttpProxy]`,
+		"Normal UpdateStateChanged Update state changed from WaitingForPodsRemoval to None",
+		"Normal ClusterStateChanged Cluster state changed from UpdateFinishing to Running",
+	}, events)
+
+	// Retry after the status is cleared does not duplicate the entry.
+	ytsaurus.RecordUpdateHistory(ytv1.UpdateResultFinished, "")
+	require.Len(t, ytsaurus.GetResource().Status.UpdateHistory, 1)
+}
+
+func TestRecordUpdateHistoryAfterRollback(t *testing.T) {
+	ctx := context.Background()
+	ytsaurus, _ := newTestYtsaurus(t)
+
+	require.NoError(t, ytsaurus.SaveUpdatingClusterState(ctx, ytv1.UpdateFlowFull, nil))
+	// Rolled back cluster is synced with the previous spec.
+	ytsaurus.GetResource().Spec = *ytsaurus.GetPreviousSpec()
+	ytsaurus.RecordUpdateHistory(ytv1.UpdateResultRolledBack, "deadline exceeded")
+
+	history := ytsaurus.GetResource().Status.UpdateHistory
+	require.Len(t, history, 1)
+	require.Equal(t, "ytsaurus/ytsaurus:old", history[0].ImageFrom)
+	require.Equal(t, "ytsaurus/ytsaurus:new", history[0].ImageTo)
+}
+
+func TestUpdateHistoryIsBounded(t *testing.T) {
+	ytsaurus, _ := newTestYtsaurus(t)
+
+	for i := 0; i < consts.MaxUpdateHistoryLength+2; i++ {
+		runTestUpdate(t, ytsaurus, ytv1.UpdateResultCanceled)
+		// Updates are identified by start time, which is stored with a second precision.
+		history := ytsaurus.GetResource().Status.UpdateHistory
+		history[len(history)-1].StartTime = &metav1.Time{Time: time.Now().Add(-time.Duration(i+1) * time.Hour)}
+	}
+	require.Len(t, ytsaurus.GetResource().Status.UpdateHistory, consts.MaxUpdateHistoryLength)
+}
+
+func TestSavePreviousSpec(t *testing.T) {
+	ctx := context.Background()
+	ytsaurus, _ := newTestYtsaurus(t)
+	require.Equal(t, "ytsaurus/ytsaurus:old", ytsaurus.GetPreviousSpec().CoreImage)
+
+	require.NoError(t, ytsaurus.SavePreviousSpec(ctx))
+	require.Equal(t, "ytsaurus/ytsaurus:new", ytsaurus.GetPreviousSpec().CoreImage)
+
+	require.NoError(t, ytsaurus.FetchPreviousSpec(ctx))
+	require.Equal(t, "ytsaurus/ytsaurus:new", ytsaurus.GetPreviousSpec().CoreImage)
+}
@@ -0,0 +1,64 @@
+package apiproxy
+
+import (
+	"context"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type YtsaurusUser struct {
+	apiProxy     APIProxy
+	ytsaurusUser *ytv1.YtsaurusUser
+}
+
+func NewYtsaurusUser(
+	ytsaurusUser *ytv1.YtsaurusUser,
+	client client.Client,
+	recorder record.EventRecorder,
+	scheme *runtime.Scheme) *YtsaurusUser {
+	return &YtsaurusUser{
+		ytsaurusUser: ytsaurusUser,
+		apiProxy:     NewAPIProxy(ytsaurusUser, client, recorder, scheme),
+	}
+}
+
+func (c *YtsaurusUser) GetResource() *ytv1.YtsaurusUser {
+	return c.ytsaurusUser
+}
+
+func (c *YtsaurusUser) APIProxy() APIProxy {
+	return c.apiProxy
+}
+
+func (c *YtsaurusUser) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&c.ytsaurusUser.Status.Conditions, condition)
+}
+
+func (c *YtsaurusUser) IsStatusConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.ytsaurusUser.Status.Conditions, conditionType)
+}
+
+func (c *YtsaurusUser) IsStatusConditionFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(c.ytsaurusUser.Status.Conditions, conditionType)
+}
+
+// SetState changes the state of user reconciliation, it is persisted with the next status update.
+func (c *YtsaurusUser) SetState(ctx context.Context, state ytv1.YtsaurusSubjectState, message string) {
+	logger := log.FromContext(ctx)
+	if c.ytsaurusUser.Status.State != state || c.ytsaurusUser.Status.Message != message {
+		logger.Info("user state changed", "state", state, "message", message)
+		if state == ytv1.YtsaurusSubjectStateFailed {
+			c.apiProxy.RecordWarning("YtsaurusUser", message)
+		} else if c.ytsaurusUser.Status.State != state {
+			c.apiProxy.RecordNormal("YtsaurusUser", message)
+		}
+	}
+	c.ytsaurusUser.Status.State = state
+	c.ytsaurusUser.Status.Message = message
+}
@@ -0,0 +1,496 @@
+package components
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var certManagerCertificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// managedCertificate is a certificate stored in kubernetes.io/tls secret,
+// it is either requested from cert-manager or signed by the operator CA.
+type managedCertificate struct {
+	name       string
+	dnsNames   []string
+	volumeName string
+
+	oldSecret      corev1.Secret
+	oldCertificate unstructured.Unstructured
+}
+
+// Certificates issues TLS certificates for native transport and HTTPS of HTTP proxies
+// when they are managed by the operator. Pods of components which mount certificate secrets
+// are restarted one by one when secrets are renewed.
+//
+// Renewed self-signed certificate authority is published next to the previous one first,
+// certificates are signed by it only when all pods trust both of them.
+//
+// Readiness of certificates is reported by CertificatesReady condition of the cluster.
+type Certificates struct {
+	labeller *labeller.Labeller
+	ytsaurus *apiproxy.Ytsaurus
+	spec     *ytv1.ManagedCertificatesSpec
+
+	caSecret     corev1.Secret
+	certificates []*managedCertificate
+
+	// certManagerMissing is set by Fetch when cert-manager CRDs are not installed.
+	certManagerMissing bool
+}
+
+func NewCertificates(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus) *Certificates {
+	resource := ytsaurus.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       ytsaurus.APIProxy(),
+		ComponentLabel: consts.YTComponentLabelCertificates,
+		ComponentName:  "Certificates",
+	}
+
+	var certificates []*managedCertificate
+	if ytconfig.IsNativeTransportCertificateManaged(&resource.Spec.CommonSpec) {
+		certificates = append(certificates, &managedCertificate{
+			name:       consts.NativeTransportCertificateSecretName,
+			dnsNames:   cfgen.GetNativeTransportCertificateDNSNames(),
+			volumeName: consts.BusSecretVolumeName,
+		})
+	}
+	if ytconfig.IsHTTPSCertificateManaged(&resource.Spec.CommonSpec) {
+		certificates = append(certificates, &managedCertificate{
+			name:       consts.HTTPSCertificateSecretName,
+			dnsNames:   cfgen.GetHTTPSCertificateDNSNames(),
+			volumeName: consts.HTTPSSecretVolumeName,
+		})
+	}
+
+	return &Certificates{
+		labeller:     &l,
+		ytsaurus:     ytsaurus,
+		spec:         resource.Spec.ManagedCertificates,
+		certificates: certificates,
+	}
+}
+
+func (c *Certificates) isSelfSigned() bool {
+	return c.spec.IssuerRef == nil
+}
+
+func (c *Certificates) getDuration() time.Duration {
+	if c.spec.Duration != nil {
+		return c.spec.Duration.Duration
+	}
+	return consts.DefaultCertificateDuration
+}
+
+func (c *Certificates) getRenewBefore() time.Duration {
+	if c.spec.RenewBefore != nil {
+		return c.spec.RenewBefore.Duration
+	}
+	return consts.DefaultCertificateRenewBefore
+}
+
+func (c *Certificates) Fetch(ctx context.Context) error {
+	proxy := c.ytsaurus.APIProxy()
+	if c.isSelfSigned() {
+		if err := proxy.FetchObject(ctx, consts.CertificateAuthoritySecretName, &c.caSecret); err != nil {
+			return err
+		}
+	}
+	for _, certificate := range c.certificates {
+		if err := proxy.FetchObject(ctx, certificate.name, &certificate.oldSecret); err != nil {
+			return err
+		}
+		if !c.isSelfSigned() {
+			certificate.oldCertificate.SetGroupVersionKind(certManagerCertificateGVK)
+			err := proxy.FetchObject(ctx, certificate.name, &certificate.oldCertificate)
+			if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				c.certManagerMissing = true
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to fetch cert-manager certificate %s: %w", certificate.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Sync issues missing certificates and renews expiring ones.
+// Returns true when all certificate secrets are ready to be mounted.
+func (c *Certificates) Sync(ctx context.Context) (bool, error) {
+	var ready bool
+	var err error
+	if c.isSelfSigned() {
+		ready, err = c.syncSelfSigned(ctx)
+	} else {
+		ready, err = c.syncCertManager(ctx)
+	}
+	if err != nil || c.certManagerMissing {
+		return ready, err
+	}
+
+	condition := metav1.Condition{
+		Type:    consts.ConditionCertificatesReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Issued",
+		Message: "Certificates are issued",
+	}
+	if !ready {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Issuing"
+		condition.Message = "Waiting for certificates to be issued"
+	}
+	c.ytsaurus.SetStatusCondition(condition)
+	return ready, nil
+}
+
+// isSecretsReady checks that secrets of all certificates are issued.
+func (c *Certificates) isSecretsReady() bool {
+	for _, certificate := range c.certificates {
+		if !isCertificateSecretReady(&certificate.oldSecret) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportCertManagerMissing reports that certificates can't be requested from cert-manager.
+// Certificates which are already issued are kept mounted, so running clusters are not blocked.
+func (c *Certificates) reportCertManagerMissing() bool {
+	message := fmt.Sprintf("cert-manager is not installed, certificates can't be requested from issuer %s",
+		c.spec.IssuerRef.Name)
+	condition := meta.FindStatusCondition(c.ytsaurus.GetResource().Status.Conditions, consts.ConditionCertificatesReady)
+	if condition == nil || condition.Reason != "CertManagerNotInstalled" {
+		c.ytsaurus.APIProxy().RecordWarning("CertManagerNotInstalled", message)
+	}
+	c.ytsaurus.SetStatusCondition(metav1.Condition{
+		Type:    consts.ConditionCertificatesReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  "CertManagerNotInstalled",
+		Message: message,
+	})
+	return c.isSecretsReady()
+}
+
+func (c *Certificates) syncCertManager(ctx context.Context) (bool, error) {
+	if c.certManagerMissing {
+		return c.reportCertManagerMissing(), nil
+	}
+	ready := true
+	for _, certificate := range c.certificates {
+		newCertificate := c.buildCertManagerCertificate(certificate)
+		if certificate.oldCertificate.GetResourceVersion() == "" ||
+			!reflect.DeepEqual(certificate.oldCertificate.Object["spec"], newCertificate.Object["spec"]) {
+			log.FromContext(ctx).Info("requesting certificate from cert-manager", "certificate", certificate.name)
+			if err := c.ytsaurus.APIProxy().SyncObject(ctx, &certificate.oldCertificate, newCertificate); err != nil {
+				return false, err
+			}
+		}
+		if !isCertificateSecretReady(&certificate.oldSecret) {
+			ready = false
+		}
+	}
+	return ready, nil
+}
+
+func (c *Certificates) buildCertManagerCertificate(certificate *managedCertificate) *unstructured.Unstructured {
+	issuerRef := c.spec.IssuerRef
+	issuerKind := issuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+	issuerGroup := issuerRef.Group
+	if issuerGroup == "" {
+		issuerGroup = certManagerCertificateGVK.Group
+	}
+
+	dnsNames := make([]interface{}, 0, len(certificate.dnsNames))
+	for _, dnsName := range certificate.dnsNames {
+		dnsNames = append(dnsNames, dnsName)
+	}
+
+	result := &unstructured.Unstructured{}
+	result.SetGroupVersionKind(certManagerCertificateGVK)
+	objectMeta := c.labeller.GetObjectMeta(certificate.name)
+	result.SetName(objectMeta.Name)
+	result.SetNamespace(objectMeta.Namespace)
+	result.SetLabels(objectMeta.Labels)
+	result.Object["spec"] = map[string]interface{}{
+		"secretName":  certificate.name,
+		"dnsNames":    dnsNames,
+		"duration":    c.getDuration().String(),
+		"renewBefore": c.getRenewBefore().String(),
+		"usages":      []interface{}{"server auth", "client auth"},
+		"privateKey": map[string]interface{}{
+			"rotationPolicy": "Always",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  issuerKind,
+			"group": issuerGroup,
+		},
+	}
+	return result
+}
+
+func (c *Certificates) syncSelfSigned(ctx context.Context) (bool, error) {
+	logger := log.FromContext(ctx)
+	now := time.Now()
+
+	ca, caKey, err := parseCertificateSecret(&c.caSecret)
+	if err != nil || !ca.IsCA || now.Add(c.getRenewBefore()).After(ca.NotAfter) {
+		logger.Info("issuing self-signed certificate authority", "reason", err)
+		ca, caKey, err = c.issueCertificateAuthority(ctx, now)
+		if err != nil {
+			return false, err
+		}
+	}
+	caBundle := c.caSecret.Data[consts.CABundleFileName]
+
+	for _, certificate := range c.certificates {
+		cert, _, err := parseCertificateSecret(&certificate.oldSecret)
+		isValid := err == nil &&
+			now.Add(c.getRenewBefore()).Before(cert.NotAfter) &&
+			slices.Equal(cert.DNSNames, certificate.dnsNames)
+		isBundleChanged := !bytes.Equal(certificate.oldSecret.Data[consts.CABundleFileName], caBundle)
+
+		if isValid && cert.CheckSignatureFrom(ca) == nil {
+			if isBundleChanged {
+				if err = c.syncCertificateBundle(ctx, certificate, caBundle); err != nil {
+					return false, err
+				}
+			}
+			continue
+		}
+
+		if isValid && isSignedByBundle(cert, caBundle) {
+			// Certificate signed by the renewed authority is not trusted by pods which trust only the previous one.
+			if isBundleChanged {
+				logger.Info("publishing renewed self-signed certificate authority", "certificate", certificate.name)
+				if err = c.syncCertificateBundle(ctx, certificate, caBundle); err != nil {
+					return false, err
+				}
+				continue
+			}
+			rolledOut, err := c.isRolledOut(ctx, certificate)
+			if err != nil {
+				return false, err
+			}
+			if !rolledOut {
+				logger.Info("waiting for pods to trust renewed self-signed certificate authority",
+					"certificate", certificate.name)
+				continue
+			}
+		}
+
+		logger.Info("issuing certificate signed by self-signed certificate authority",
+			"certificate", certificate.name, "reason", err)
+		if err = c.issueCertificate(ctx, certificate, ca, caKey, caBundle, now); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// isRolledOut checks that all pods which mount the certificate secret were restarted since its last change.
+func (c *Certificates) isRolledOut(ctx context.Context, certificate *managedCertificate) (bool, error) {
+	pods := &corev1.PodList{}
+	err := c.ytsaurus.APIProxy().ListObjects(ctx, pods,
+		client.InNamespace(c.labeller.ObjectMeta.Namespace),
+		client.MatchingLabels{"app.kubernetes.io/instance": c.labeller.GetClusterName()})
+	if err != nil {
+		return false, err
+	}
+	version := resources.GetTLSSecretVersion(&certificate.oldSecret)
+	annotation := resources.GetTLSSecretVersionAnnotation(certificate.volumeName)
+	for _, pod := range pods.Items {
+		if podVersion, ok := pod.Annotations[annotation]; ok && podVersion != version {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isSignedByBundle checks that the certificate is signed by one of certificate authorities from the bundle.
+func isSignedByBundle(cert *x509.Certificate, caBundle []byte) bool {
+	for block, rest := pem.Decode(caBundle); block != nil; block, rest = pem.Decode(rest) {
+		ca, err := x509.ParseCertificate(block.Bytes)
+		if err == nil && cert.CheckSignatureFrom(ca) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Certificates) issueCertificateAuthority(ctx context.Context, now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s ytsaurus ca", c.labeller.GetClusterName()),
+			Organization: []string{c.labeller.ObjectMeta.Namespace},
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(consts.CertificateAuthorityDuration),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certPEM, keyPEM, err := signCertificate(template, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to issue certificate authority: %w", err)
+	}
+
+	// Previous authority is trusted until certificates signed by it are replaced.
+	caBundle := slices.Clone(certPEM)
+	if previous, _, err := parseCertificateSecret(&c.caSecret); err == nil && previous.IsCA && now.Before(previous.NotAfter) {
+		caBundle = append(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: previous.Raw})...)
+	}
+
+	err = c.syncCertificateSecret(ctx, consts.CertificateAuthoritySecretName, &c.caSecret, certPEM, keyPEM, caBundle)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseCertificateSecret(&c.caSecret)
+}
+
+// syncCertificateBundle replaces the trusted certificate authorities and keeps the certificate.
+func (c *Certificates) syncCertificateBundle(ctx context.Context, certificate *managedCertificate, caBundle []byte) error {
+	secret := &certificate.oldSecret
+	return c.syncCertificateSecret(ctx, certificate.name, secret,
+		secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], caBundle)
+}
+
+func (c *Certificates) issueCertificate(
+	ctx context.Context,
+	certificate *managedCertificate,
+	ca *x509.Certificate,
+	caKey *ecdsa.PrivateKey,
+	caBundle []byte,
+	now time.Time,
+) error {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: c.labeller.GetClusterName(),
+		},
+		DNSNames:    certificate.dnsNames,
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(c.getDuration()),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certPEM, keyPEM, err := signCertificate(template, ca, caKey)
+	if err != nil {
+		return fmt.Errorf("failed to issue certificate %s: %w", certificate.name, err)
+	}
+
+	return c.syncCertificateSecret(ctx, certificate.name, &certificate.oldSecret, certPEM, keyPEM, caBundle)
+}
+
+func (c *Certificates) syncCertificateSecret(
+	ctx context.Context,
+	name string,
+	oldSecret *corev1.Secret,
+	certPEM, keyPEM, caPEM []byte,
+) error {
+	newSecret := &corev1.Secret{
+		ObjectMeta: c.labeller.GetObjectMeta(name),
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			consts.CABundleFileName: caPEM,
+		},
+	}
+	if err := c.ytsaurus.APIProxy().SyncObject(ctx, oldSecret, newSecret); err != nil {
+		return err
+	}
+	*oldSecret = *newSecret
+	return nil
+}
+
+func isCertificateSecretReady(secret *corev1.Secret) bool {
+	return len(secret.Data[corev1.TLSCertKey]) != 0 && len(secret.Data[corev1.TLSPrivateKeyKey]) != 0
+}
+
+// signCertificate generates a new key and signs the certificate, self-signed if parent is not set.
+func signCertificate(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// parseCertificateSecret parses certificate and key issued by the operator.
+func parseCertificateSecret(secret *corev1.Secret) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	if !isCertificateSecretReady(secret) {
+		return nil, nil, fmt.Errorf("certificate is missing")
+	}
+	certBlock, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if certBlock == nil {
+		return nil, nil, fmt.Errorf("certificate is malformed")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBlock, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+	if keyBlock == nil {
+		return nil, nil, fmt.Errorf("private key is malformed")
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, nil, fmt.Errorf("private key does not match certificate")
+	}
+	return cert, key, nil
+}
@@ -0,0 +1,275 @@
+package components
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Managed certificates test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+					ManagedCertificates: &ytv1.ManagedCertificatesSpec{
+						NativeTransport: true,
+						HTTPS:           true,
+						ExtraDNSNames:   []string{"yt.example.com"},
+					},
+				},
+				PrimaryMasters: ytv1.MastersSpec{
+					InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1},
+				},
+				HTTPProxies: []ytv1.HTTPProxiesSpec{
+					{
+						InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1},
+						Role:         consts.DefaultHTTPProxyRole,
+					},
+				},
+			},
+		}
+	})
+
+	syncCertificates := func(ctx context.Context, k8sClient client.Client) bool {
+		ytsaurus := &ytv1.Ytsaurus{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		proxy := apiproxy.NewYtsaurus(ytsaurus, k8sClient, record.NewFakeRecorder(100), scheme)
+		certificates := NewCertificates(ytconfig.NewGenerator(ytsaurus, "cluster.local"), proxy)
+		Expect(certificates.Fetch(ctx)).Should(Succeed())
+		ready, err := certificates.Sync(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		return ready
+	}
+
+	getCertificate := func(ctx context.Context, k8sClient client.Client, name string) (*corev1.Secret, *x509.Certificate) {
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, secret)).Should(Succeed())
+		Expect(secret.Type).Should(Equal(corev1.SecretTypeTLS))
+		block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+		Expect(block).ShouldNot(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ShouldNot(HaveOccurred())
+		return secret, cert
+	}
+
+	It("Self-signed certificates are issued and renewed when names change", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec).
+			Build()
+
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+
+		_, ca := getCertificate(ctx, k8sClient, consts.CertificateAuthoritySecretName)
+		Expect(ca.IsCA).Should(BeTrue())
+
+		nativeSecret, native := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		Expect(native.CheckSignatureFrom(ca)).Should(Succeed())
+		Expect(native.DNSNames).Should(ContainElements(
+			"masters.default.svc.cluster.local",
+			"*.masters.default.svc.cluster.local",
+			"*.http-proxies.default.svc.cluster.local",
+		))
+		Expect(native.VerifyHostname("ms-0.masters.default.svc.cluster.local")).Should(Succeed())
+		Expect(nativeSecret.Data[consts.CABundleFileName]).ShouldNot(BeEmpty())
+
+		_, https := getCertificate(ctx, k8sClient, consts.HTTPSCertificateSecretName)
+		Expect(https.VerifyHostname("http-proxies-lb.default.svc.cluster.local")).Should(Succeed())
+		Expect(https.VerifyHostname("http-proxies-lb")).Should(Succeed())
+		Expect(https.VerifyHostname("yt.example.com")).Should(Succeed())
+
+		// Nothing changes while certificates are valid.
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+		sameSecret, _ := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		Expect(sameSecret.Data).Should(Equal(nativeSecret.Data))
+
+		// New component requires new names in the certificate.
+		ytsaurus := &ytv1.Ytsaurus{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		ytsaurus.Spec.Schedulers = &ytv1.SchedulersSpec{InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1}}
+		Expect(k8sClient.Update(ctx, ytsaurus)).Should(Succeed())
+
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+		renewedSecret, renewed := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		Expect(renewedSecret.Data[corev1.TLSCertKey]).ShouldNot(Equal(nativeSecret.Data[corev1.TLSCertKey]))
+		Expect(renewed.VerifyHostname("sch-0.schedulers.default.svc.cluster.local")).Should(Succeed())
+		Expect(renewed.CheckSignatureFrom(ca)).Should(Succeed())
+	})
+
+	It("Renewed self-signed certificate authority is trusted before certificates are signed by it", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.ManagedCertificates.HTTPS = false
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec).
+			Build()
+
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+		_, native := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+
+		// Certificate authority with the same key expires soon.
+		caSecret, oldCA := getCertificate(ctx, k8sClient, consts.CertificateAuthoritySecretName)
+		_, caKey, err := parseCertificateSecret(caSecret)
+		Expect(err).ShouldNot(HaveOccurred())
+		oldCA.NotAfter = time.Now().Add(time.Hour)
+		caDER, err := x509.CreateCertificate(rand.Reader, oldCA, oldCA, &caKey.PublicKey, caKey)
+		Expect(err).ShouldNot(HaveOccurred())
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+		caSecret.Data[corev1.TLSCertKey] = caPEM
+		caSecret.Data[consts.CABundleFileName] = caPEM
+		Expect(k8sClient.Update(ctx, caSecret)).Should(Succeed())
+
+		nativeSecret, _ := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ms-0",
+				Namespace: "default",
+				Labels:    map[string]string{"app.kubernetes.io/instance": "ytsaurus"},
+				Annotations: map[string]string{
+					resources.GetTLSSecretVersionAnnotation(consts.BusSecretVolumeName): resources.GetTLSSecretVersion(nativeSecret),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).Should(Succeed())
+
+		// Both authorities are published, certificate is kept.
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+		_, newCA := getCertificate(ctx, k8sClient, consts.CertificateAuthoritySecretName)
+		Expect(newCA.Equal(oldCA)).Should(BeFalse())
+		publishedSecret, published := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		Expect(published.Equal(native)).Should(BeTrue())
+		Expect(isSignedByBundle(published, publishedSecret.Data[consts.CABundleFileName])).Should(BeTrue())
+		Expect(bytes.Count(publishedSecret.Data[consts.CABundleFileName], []byte("BEGIN CERTIFICATE"))).Should(Equal(2))
+
+		// Pod still trusts only the previous authority.
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+		_, waiting := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		Expect(waiting.Equal(native)).Should(BeTrue())
+
+		// Pod is restarted with both authorities.
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).Should(Succeed())
+		pod.Annotations[resources.GetTLSSecretVersionAnnotation(consts.BusSecretVolumeName)] = resources.GetTLSSecretVersion(publishedSecret)
+		Expect(k8sClient.Update(ctx, pod)).Should(Succeed())
+
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+		renewedSecret, renewed := getCertificate(ctx, k8sClient, consts.NativeTransportCertificateSecretName)
+		Expect(renewed.CheckSignatureFrom(newCA)).Should(Succeed())
+		Expect(renewedSecret.Data[consts.CABundleFileName]).Should(Equal(publishedSecret.Data[consts.CABundleFileName]))
+	})
+
+	It("Certificates are requested from cert-manager", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.ManagedCertificates.HTTPS = false
+		ytsaurusSpec.Spec.ManagedCertificates.IssuerRef = &ytv1.CertificateIssuerReference{
+			Name: "ca-issuer",
+			Kind: "ClusterIssuer",
+		}
+		scheme.AddKnownTypeWithName(certManagerCertificateGVK, &unstructured.Unstructured{})
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec).
+			Build()
+
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeFalse())
+
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certManagerCertificateGVK)
+		key := client.ObjectKey{Namespace: "default", Name: consts.NativeTransportCertificateSecretName}
+		Expect(k8sClient.Get(ctx, key, certificate)).Should(Succeed())
+		Expect(certificate.Object["spec"]).Should(HaveKeyWithValue("secretName", consts.NativeTransportCertificateSecretName))
+		Expect(certificate.Object["spec"]).Should(HaveKeyWithValue("issuerRef", map[string]interface{}{
+			"name":  "ca-issuer",
+			"kind":  "ClusterIssuer",
+			"group": "cert-manager.io",
+		}))
+		Expect(certificate.GetOwnerReferences()).Should(HaveLen(1))
+
+		// Secret is created by cert-manager.
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		})).Should(Succeed())
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+	})
+
+	It("Missing cert-manager is reported by the condition", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.ManagedCertificates.HTTPS = false
+		ytsaurusSpec.Spec.ManagedCertificates.IssuerRef = &ytv1.CertificateIssuerReference{Name: "ca-issuer"}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if obj.GetObjectKind().GroupVersionKind() == certManagerCertificateGVK {
+						return &meta.NoKindMatchError{GroupKind: certManagerCertificateGVK.GroupKind()}
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).
+			Build()
+
+		ytsaurus := &ytv1.Ytsaurus{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		proxy := apiproxy.NewYtsaurus(ytsaurus, k8sClient, record.NewFakeRecorder(100), scheme)
+		certificates := NewCertificates(ytconfig.NewGenerator(ytsaurus, "cluster.local"), proxy)
+		Expect(certificates.Fetch(ctx)).Should(Succeed())
+		ready, err := certificates.Sync(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ready).Should(BeFalse())
+		condition := meta.FindStatusCondition(ytsaurus.Status.Conditions, consts.ConditionCertificatesReady)
+		Expect(condition).ShouldNot(BeNil())
+		Expect(condition.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).Should(Equal("CertManagerNotInstalled"))
+
+		// Certificates issued before keep being mounted.
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: consts.NativeTransportCertificateSecretName, Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		})).Should(Succeed())
+		Expect(syncCertificates(ctx, k8sClient)).Should(BeTrue())
+	})
+})
@@ -5,9 +5,16 @@ import (
 	"fmt"
 	"strings"
 
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
@@ -26,7 +33,10 @@ type Chyt struct {
 
 	initUser        *InitJob
 	initEnvironment *InitJob
-	initChPublicJob *InitJob
+
+	chPublicClique *resources.ChytClique
+
+	ytClient yt.Client
 }
 
 func NewChyt(
@@ -64,15 +74,10 @@ func NewChyt(
 			consts.ClientConfigFileName,
 			chyt.GetResource().Spec.Image,
 			cfgen.GetNativeClientConfig),
-		initChPublicJob: NewInitJob(
+		chPublicClique: resources.NewChytClique(
+			fmt.Sprintf("%s-ch-public", chyt.GetResource().Name),
 			&l,
-			chyt.APIProxy(),
-			chyt,
-			ytsaurus.Spec.ImagePullSecrets,
-			"ch-public",
-			consts.ClientConfigFileName,
-			chyt.GetResource().Spec.Image,
-			cfgen.GetNativeClientConfig),
+			chyt.APIProxy()),
 		secret: resources.NewStringSecret(
 			l.GetSecretName(),
 			&l,
@@ -98,32 +103,108 @@ func (c *Chyt) createInitScript() string {
 		script += " --make-default"
 	}
 
-	return script
+	return createReleaseScript(script, chytReleaseDirs)
 }
 
-func (c *Chyt) createInitChPublicScript() string {
-	script := []string{
-		initJobPrologue,
-		fmt.Sprintf("export YT_PROXY=%v CHYT_CTL_ADDRESS=%v YT_LOG_LEVEL=debug", c.cfgen.GetHTTPProxiesAddress(consts.DefaultHTTPProxyRole), c.cfgen.GetStrawberryControllerServiceAddress()),
-		"yt create scheduler_pool --attributes '{name=chyt; pool_tree=default}' --ignore-existing",
-		"yt clickhouse ctl create ch_public || true",
-		"yt clickhouse ctl set-option --alias ch_public pool chyt",
-		"yt clickhouse ctl set-option --alias ch_public enable_geodata '%false'",
-		"yt clickhouse ctl set-option --alias ch_public instance_cpu 1",
-		"yt clickhouse ctl set-option --alias ch_public instance_memory '{reader=100000000;chunk_meta_cache=100000000;compressed_cache=100000000;clickhouse=100000000;clickhouse_watermark=10;footprint=500000000;log_tailer=100000000;watchdog_oom_watermark=0;watchdog_oom_window_watermark=0}'",
-		"yt clickhouse ctl set-option --alias ch_public instance_count 1",
-		"yt clickhouse ctl start ch_public",
+// chytReleaseDirs are Cypress directories which CHYT releases upload their files into.
+var chytReleaseDirs = []ypath.Path{
+	"//sys/bin/ytserver-clickhouse",
+	"//sys/bin/clickhouse-trampoline",
+}
+
+// initYtClient creates a client to the cluster with the token of the releaser.
+func (c *Chyt) initYtClient() error {
+	if c.ytClient != nil {
+		return nil
 	}
+	token, _ := c.secret.GetValue(consts.TokenSecretKey)
+	ytClient, err := newYtClient(c.cfgen, token)
+	if err != nil {
+		return err
+	}
+	c.ytClient = ytClient
+	return nil
+}
 
-	return strings.Join(script, "\n")
+// getOtherReleasePaths returns paths of the releases of other chyts of the cluster.
+func (c *Chyt) getOtherReleasePaths(ctx context.Context) ([]string, error) {
+	resource := c.chyt.GetResource()
+	var chytList ytv1.ChytList
+	if err := c.chyt.APIProxy().ListObjects(ctx, &chytList, client.InNamespace(resource.Namespace)); err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, chyt := range chytList.Items {
+		if chyt.Name == resource.Name || chyt.Spec.Ytsaurus == nil || chyt.Spec.Ytsaurus.Name != c.ytsaurus.Name {
+			continue
+		}
+		for _, releasePaths := range chyt.Status.ReleasePaths {
+			paths = append(paths, releasePaths...)
+		}
+	}
+	return paths, nil
+}
+
+// removeReleases removes files of the releases of the images from Cypress,
+// files used by kept releases of this and other chyts are not removed.
+func (c *Chyt) removeReleases(ctx context.Context, images []string) error {
+	status := &c.chyt.GetResource().Status
+	otherPaths, err := c.getOtherReleasePaths(ctx)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		if _, ok := status.ReleasePaths[image]; !ok {
+			c.chyt.APIProxy().RecordWarning(
+				"Release",
+				fmt.Sprintf("Files of CHYT release %s are unknown, they must be removed from Cypress manually", image))
+		}
+	}
+	for _, path := range getRemovedReleasePaths(status.ReleasePaths, images, otherPaths) {
+		exists, err := c.ytClient.NodeExists(ctx, ypath.Path(path), nil)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			c.chyt.APIProxy().RecordWarning(
+				"Release",
+				fmt.Sprintf("File %s of CHYT release is already removed from Cypress", path))
+			continue
+		}
+		if err := c.ytClient.RemoveNode(ctx, ypath.Path(path), &yt.RemoveNodeOptions{Recursive: true}); err != nil {
+			return err
+		}
+	}
+	for _, image := range images {
+		delete(status.ReleasePaths, image)
+	}
+	return nil
 }
 
-func (c *Chyt) prepareChPublicJob() {
-	c.initChPublicJob.SetInitScript(c.createInitChPublicScript())
+// getChPublicCliqueSpec returns the spec of the default clique, which is available to everyone.
+func (c *Chyt) getChPublicCliqueSpec() ytv1.ChytCliqueSpec {
+	return ytv1.ChytCliqueSpec{
+		// Cluster is set explicitly to remove the clique when the chyt is deleted.
+		Ytsaurus:       &corev1.LocalObjectReference{Name: c.ytsaurus.Name},
+		Chyt:           &corev1.LocalObjectReference{Name: c.chyt.GetResource().Name},
+		Alias:          "ch_public",
+		Pool:           "chyt",
+		InstanceCount:  1,
+		InstanceCPU:    1,
+		InstanceMemory: ptr.To(resource.MustParse("1G")),
+	}
+}
 
-	job := c.initChPublicJob.Build()
-	container := &job.Spec.Template.Spec.Containers[0]
-	container.EnvFrom = []corev1.EnvFromSource{c.secret.GetEnvSource()}
+// installRelease marks the image of the spec as installed along with the files its job has uploaded into Cypress.
+func (c *Chyt) installRelease(paths []string) {
+	resource := c.chyt.GetResource()
+	installRelease(
+		&resource.Status.InstalledImage,
+		&resource.Status.PreviousImages,
+		&resource.Status.ReleasePaths,
+		resource.Spec.Image,
+		paths)
+	c.chyt.APIProxy().RecordNormal("Release", fmt.Sprintf("CHYT release %s is installed", resource.Spec.Image))
 }
 
 func (c *Chyt) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
@@ -173,20 +254,62 @@ func (c *Chyt) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
 		}
 	}
 
+	resource := c.chyt.GetResource()
+	if needReleaseRestart(c.initEnvironment, resource.Status.InstalledImage) {
+		if !dry {
+			err = c.initEnvironment.prepareRestart(ctx, dry)
+		}
+		resource.Status.ReleaseStatus = ytv1.ChytReleaseStatusUploadingIntoCypress
+		return WaitingStatus(SyncStatusPending, "release restart"), err
+	}
+
 	status, err = c.initEnvironment.Sync(ctx, dry)
 	if err != nil || status.SyncStatus != SyncStatusReady {
-		c.chyt.GetResource().Status.ReleaseStatus = ytv1.ChytReleaseStatusUploadingIntoCypress
+		resource.Status.ReleaseStatus = ytv1.ChytReleaseStatusUploadingIntoCypress
 		return status, err
 	}
 
-	if c.ytsaurus.Spec.StrawberryController != nil && c.chyt.GetResource().Spec.MakeDefault {
+	if resource.Status.InstalledImage != resource.Spec.Image {
+		resource.Status.ReleaseStatus = ytv1.ChytReleaseStatusUploadingIntoCypress
+		// Uploaded files are reported by the job, so the upload is repeated
+		// if the job has been already removed by its TTL.
+		paths, found, err := getReleaseJobPaths(ctx, c.initEnvironment)
+		if err != nil {
+			return WaitingStatus(SyncStatusPending, "release paths"), err
+		}
+		if !found {
+			err = c.initEnvironment.prepareRestart(ctx, dry)
+			return WaitingStatus(SyncStatusPending, "release restart"), err
+		}
 		if !dry {
-			c.prepareChPublicJob()
+			c.installRelease(paths)
 		}
-		status, err = c.initChPublicJob.Sync(ctx, dry)
-		if err != nil || status.SyncStatus != SyncStatusReady {
+		return WaitingStatus(SyncStatusPending, "installed image"), err
+	}
+
+	if keep := resource.Spec.KeepPreviousReleases; len(resource.Status.PreviousImages) > int(keep) {
+		resource.Status.ReleaseStatus = ytv1.ChytReleaseStatusCleaningUp
+		if !dry {
+			err = c.initYtClient()
+			if err == nil {
+				err = c.removeReleases(ctx, resource.Status.PreviousImages[keep:])
+			}
+			if err == nil {
+				resource.Status.PreviousImages = resource.Status.PreviousImages[:keep]
+			}
+		}
+		return WaitingStatus(SyncStatusPending, "cleanup of previous releases"), err
+	}
+
+	if c.ytsaurus.Spec.StrawberryController != nil && c.chyt.GetResource().Spec.MakeDefault {
+		spec := c.getChPublicCliqueSpec()
+		if c.chPublicClique.NeedSync(spec) {
+			if !dry {
+				c.chPublicClique.Build().Spec = spec
+				err = c.chPublicClique.Sync(ctx)
+			}
 			c.chyt.GetResource().Status.ReleaseStatus = ytv1.ChytReleaseStatusCreatingChPublicClique
-			return status, err
+			return WaitingStatus(SyncStatusPending, c.chPublicClique.Name()), err
 		}
 	}
 
@@ -199,7 +322,7 @@ func (c *Chyt) Fetch(ctx context.Context) error {
 	return resources.Fetch(ctx,
 		c.initUser,
 		c.initEnvironment,
-		c.initChPublicJob,
+		c.chPublicClique,
 		c.secret,
 	)
 }
@@ -217,3 +340,62 @@ func (c *Chyt) Sync(ctx context.Context) error {
 	_, err := c.doSync(ctx, false)
 	return err
 }
+
+// getCliques returns cliques of the namespace which run the chyt release.
+func (c *Chyt) getCliques(ctx context.Context) ([]ytv1.ChytClique, error) {
+	resource := c.chyt.GetResource()
+	var cliqueList ytv1.ChytCliqueList
+	if err := c.chyt.APIProxy().ListObjects(ctx, &cliqueList, client.InNamespace(resource.Namespace)); err != nil {
+		return nil, err
+	}
+	var cliques []ytv1.ChytClique
+	for _, clique := range cliqueList.Items {
+		if clique.Spec.Chyt != nil && clique.Spec.Chyt.Name == resource.Name {
+			cliques = append(cliques, clique)
+		}
+	}
+	return cliques, nil
+}
+
+// Remove removes all kept releases from Cypress, it returns true once they are removed.
+// Releases are kept while cliques run them, the ch_public clique of the chyt is deleted here.
+func (c *Chyt) Remove(ctx context.Context) (bool, error) {
+	resource := c.chyt.GetResource()
+	images := resource.Status.PreviousImages
+	if resource.Status.InstalledImage != "" {
+		images = append([]string{resource.Status.InstalledImage}, images...)
+	}
+	if len(images) == 0 {
+		return true, nil
+	}
+
+	cliques, err := c.getCliques(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(cliques) != 0 {
+		resource.Status.ReleaseStatus = ytv1.ChytReleaseStatusWaitingForCliques
+		for i := range cliques {
+			clique := &cliques[i]
+			if clique.Name == c.chPublicClique.Name() && clique.DeletionTimestamp.IsZero() {
+				if err := c.chyt.APIProxy().DeleteObject(ctx, clique); err != nil {
+					return false, err
+				}
+			}
+		}
+		return false, nil
+	}
+
+	if c.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		return false, nil
+	}
+
+	resource.Status.ReleaseStatus = ytv1.ChytReleaseStatusCleaningUp
+	if err := c.initYtClient(); err != nil {
+		return false, err
+	}
+	if err := c.removeReleases(ctx, images); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,289 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yson"
+	"go.ytsaurus.tech/yt/go/yt"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+const chytFamily = "chyt"
+
+// ChytClique keeps a CHYT clique in line with its spec via the strawberry controller:
+// the clique is created if missing, its speclet options and ACL are set and the clique is started.
+type ChytClique struct {
+	clique   *apiproxy.ChytClique
+	cfgen    *ytconfig.Generator
+	ytsaurus *ytv1.Ytsaurus
+	chyt     *ytv1.Chyt
+
+	clientSecret *resources.StringSecret
+
+	ytClient   yt.Client
+	strawberry strawberryAPI
+}
+
+// NewChytClique creates the component, chyt is nil if the clique references the cluster directly.
+func NewChytClique(
+	cfgen *ytconfig.Generator,
+	clique *apiproxy.ChytClique,
+	ytsaurus *ytv1.Ytsaurus,
+	chyt *ytv1.Chyt) *ChytClique {
+	resource := clique.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       clique.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-chyt-clique-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("ChytClique-%s", resource.Name),
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	return &ChytClique{
+		clique:   clique,
+		cfgen:    cfgen,
+		ytsaurus: ytsaurus,
+		chyt:     chyt,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			clique.APIProxy()),
+	}
+}
+
+func (c *ChytClique) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, c.clientSecret)
+}
+
+// initClients creates clients to the cluster and the strawberry controller,
+// returns false if they are not accessible yet.
+func (c *ChytClique) initClients(ctx context.Context, state ytv1.ChytCliqueState) bool {
+	if c.ytsaurus.Spec.StrawberryController == nil {
+		c.clique.SetState(ctx, ytv1.ChytCliqueStateFailed, "Strawberry controller is not enabled in ytsaurus")
+		return false
+	}
+
+	if c.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		c.clique.SetState(ctx, state, "Waiting for ytsaurus to be running")
+		return false
+	}
+
+	// Removal of the clique doesn't need the release, which is kept until its cliques are removed.
+	if c.chyt != nil && state != ytv1.ChytCliqueStateDeleting && c.chyt.Status.ReleaseStatus != ytv1.ChytReleaseStatusFinished {
+		c.clique.SetState(ctx, state, "Waiting for chyt release to be finished")
+		return false
+	}
+
+	if !resources.Exists(c.clientSecret) {
+		c.clique.SetState(ctx, state, "Waiting for ytsaurus client secret")
+		return false
+	}
+
+	token, _ := c.clientSecret.GetValue(consts.TokenSecretKey)
+	if c.ytClient == nil {
+		ytClient, err := newYtClient(c.cfgen, token)
+		if err != nil {
+			c.clique.SetState(ctx, ytv1.ChytCliqueStateFailed, err.Error())
+			return false
+		}
+		c.ytClient = ytClient
+	}
+	if c.strawberry == nil {
+		c.strawberry = newHTTPStrawberryAPI(c.cfgen, c.ytsaurus.Name, chytFamily, token)
+	}
+	return true
+}
+
+// getSpecletOptions returns the options of the clique speclet declared by the spec,
+// values are brought to the form they have in get_speclet result.
+func (c *ChytClique) getSpecletOptions() (map[string]any, error) {
+	spec := c.clique.GetResource().Spec
+	options := map[string]any{
+		"pool":           spec.Pool,
+		"instance_count": spec.InstanceCount,
+		"instance_cpu":   spec.InstanceCPU,
+		"enable_geodata": spec.EnableGeodata,
+	}
+	if spec.InstanceMemory != nil {
+		options["instance_total_memory"] = spec.InstanceMemory.Value()
+	}
+	if spec.ClickHouseConfig != "" {
+		var config map[string]any
+		if err := yson.Unmarshal([]byte(spec.ClickHouseConfig), &config); err != nil {
+			return nil, fmt.Errorf("invalid clickhouse config: %w", err)
+		}
+		options["clickhouse_config"] = config
+	}
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]any{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func (c *ChytClique) syncPool(ctx context.Context) error {
+	var defaultTree string
+	if err := c.ytClient.GetNode(ctx, ypath.Path("//sys/pool_trees/@default_tree"), &defaultTree, nil); err != nil {
+		return err
+	}
+	pool := c.clique.GetResource().Spec.Pool
+	_, err := c.ytClient.CreateObject(ctx, yt.NodeSchedulerPool, &yt.CreateObjectOptions{
+		IgnoreExisting: true,
+		Attributes: map[string]any{
+			"name":      pool,
+			"pool_tree": defaultTree,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create pool %s: %w", pool, err)
+	}
+	return nil
+}
+
+func (c *ChytClique) syncACL(ctx context.Context, alias string) error {
+	acl := c.clique.GetResource().Spec.ACL
+	if acl == nil {
+		return nil
+	}
+	path := ypath.Path("//sys/access_control_object_namespaces").Child(chytFamily).Child(alias).Child("principal").Attr("acl")
+	var current []yt.ACE
+	if err := c.ytClient.GetNode(ctx, path, &current, nil); err != nil {
+		return err
+	}
+	desired := toYtACL(acl)
+	if aclEqual(current, desired) {
+		return nil
+	}
+	if err := c.ytClient.SetNode(ctx, path, desired, nil); err != nil {
+		return fmt.Errorf("failed to set acl of clique %s: %w", alias, err)
+	}
+	return nil
+}
+
+func (c *ChytClique) syncClique(ctx context.Context, options map[string]any) error {
+	resource := c.clique.GetResource()
+	alias := resource.GetAlias()
+	params := map[string]any{"alias": alias}
+
+	if err := c.syncPool(ctx); err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := c.strawberry.Execute(ctx, "exists", params, &exists); err != nil {
+		return err
+	}
+	if !exists {
+		if err := c.strawberry.Execute(ctx, "create", params, nil); err != nil {
+			return err
+		}
+		c.clique.APIProxy().RecordNormal("ChytClique", fmt.Sprintf("Clique %s is created", alias))
+	}
+
+	speclet := map[string]any{}
+	if err := c.strawberry.Execute(ctx, "get_speclet", params, &speclet); err != nil {
+		return err
+	}
+	changed := map[string]any{}
+	for key, value := range options {
+		if !reflect.DeepEqual(speclet[key], value) {
+			changed[key] = value
+		}
+	}
+	if len(changed) != 0 {
+		err := c.strawberry.Execute(ctx, "set_options", map[string]any{"alias": alias, "options": changed}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if speclet["active"] != true {
+		if err := c.strawberry.Execute(ctx, "start", params, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := c.syncACL(ctx, alias); err != nil {
+		return err
+	}
+
+	var info strawberryOpletBriefInfo
+	if err := c.strawberry.Execute(ctx, "get_brief_info", params, &info); err != nil {
+		return err
+	}
+	resource.Status.Health = info.Health
+	resource.Status.HealthReason = info.HealthReason
+	resource.Status.OperationID = info.YTOperation.ID
+	resource.Status.OperationState = info.YTOperation.State
+	return nil
+}
+
+func (c *ChytClique) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if !c.initClients(ctx, ytv1.ChytCliqueStatePending) {
+		return nil
+	}
+
+	options, err := c.getSpecletOptions()
+	if err != nil {
+		c.clique.SetState(ctx, ytv1.ChytCliqueStateFailed, err.Error())
+		return nil
+	}
+
+	// Errors of YTsaurus and strawberry API are reported in the status, the clique is reconciled again later.
+	if err = c.syncClique(ctx, options); err != nil {
+		logger.Error(err, "failed to sync chyt clique")
+		c.clique.SetState(ctx, ytv1.ChytCliqueStateFailed, err.Error())
+		return nil
+	}
+
+	c.clique.SetState(ctx, ytv1.ChytCliqueStateSynced, "Clique is in sync with the spec")
+	return nil
+}
+
+// Remove stops and removes the clique, it returns true once the clique is removed.
+func (c *ChytClique) Remove(ctx context.Context) bool {
+	logger := log.FromContext(ctx)
+
+	// Cliques can't be created without the strawberry controller.
+	if c.ytsaurus.Spec.StrawberryController == nil {
+		return true
+	}
+
+	if !c.initClients(ctx, ytv1.ChytCliqueStateDeleting) {
+		return false
+	}
+
+	params := map[string]any{"alias": c.clique.GetResource().GetAlias()}
+	err := func() error {
+		var exists bool
+		if err := c.strawberry.Execute(ctx, "exists", params, &exists); err != nil || !exists {
+			return err
+		}
+		return c.strawberry.Execute(ctx, "remove", params, nil)
+	}()
+	if err != nil {
+		logger.Error(err, "failed to remove chyt clique")
+		c.clique.SetState(ctx, ytv1.ChytCliqueStateFailed, err.Error())
+		return false
+	}
+
+	c.clique.SetState(ctx, ytv1.ChytCliqueStateDeleting, "Clique is removed")
+	return true
+}
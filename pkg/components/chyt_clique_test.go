@@ -0,0 +1,208 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+type strawberryCall struct {
+	command string
+	params  map[string]any
+}
+
+// fakeStrawberryAPI records executed commands and replies with canned results.
+type fakeStrawberryAPI struct {
+	results map[string]any
+	calls   []strawberryCall
+}
+
+func (a *fakeStrawberryAPI) Execute(_ context.Context, command string, params map[string]any, result any) error {
+	a.calls = append(a.calls, strawberryCall{command: command, params: params})
+	if result == nil {
+		return nil
+	}
+	data, err := json.Marshal(a.results[command])
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (a *fakeStrawberryAPI) commands() []string {
+	var commands []string
+	for _, call := range a.calls {
+		commands = append(commands, call.command)
+	}
+	return commands
+}
+
+var _ = Describe("Chyt clique test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var cliqueSpec *ytv1.ChytClique
+	var clientSecret *corev1.Secret
+	var mockYtClient *mock_yt.MockClient
+	var strawberry *fakeStrawberryAPI
+	var scheme *runtime.Scheme
+
+	aclPath := ypath.Path("//sys/access_control_object_namespaces/chyt/analytics/principal/@acl")
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+		strawberry = &fakeStrawberryAPI{results: map[string]any{}}
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+				StrawberryController: &ytv1.StrawberryControllerSpec{},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		cliqueSpec = &ytv1.ChytClique{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "analytics",
+				Namespace: "default",
+			},
+			Spec: ytv1.ChytCliqueSpec{
+				Ytsaurus:       &corev1.LocalObjectReference{Name: "ytsaurus"},
+				Pool:           "chyt",
+				InstanceCount:  2,
+				InstanceCPU:    4,
+				InstanceMemory: ptr.To(resource.MustParse("16Gi")),
+				ACL: []ytv1.AccessControlEntry{
+					{Action: "allow", Subjects: []string{"analysts"}, Permissions: []string{"use"}},
+				},
+			},
+		}
+
+		clientSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "yt-client-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				consts.TokenSecretKey: []byte("token"),
+			},
+		}
+	})
+
+	newComponent := func(ctx context.Context, k8sClient client.Client) (*ChytClique, *ytv1.ChytClique, *apiproxy.ChytClique) {
+		ytsaurus := &ytv1.Ytsaurus{}
+		clique := &ytv1.ChytClique{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cliqueSpec), clique)).Should(Succeed())
+		proxy := apiproxy.NewChytClique(clique, k8sClient, record.NewFakeRecorder(100), scheme)
+		component := NewChytClique(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus, nil)
+		component.ytClient = mockYtClient
+		component.strawberry = strawberry
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		return component, clique, proxy
+	}
+
+	It("ChytClique Sync; clique is created, configured and started", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, cliqueSpec, clientSecret).
+			WithStatusSubresource(cliqueSpec).
+			Build()
+
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/pool_trees/@default_tree")), gomock.Any(), gomock.Nil()).
+			SetArg(2, "default").
+			Return(nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeSchedulerPool), gomock.Eq(&yt.CreateObjectOptions{
+				IgnoreExisting: true,
+				Attributes:     map[string]any{"name": "chyt", "pool_tree": "default"},
+			})).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(aclPath), gomock.Any(), gomock.Nil()).
+			SetArg(2, []yt.ACE{}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(aclPath), gomock.Eq([]yt.ACE{
+				{Action: yt.ActionAllow, Subjects: []string{"analysts"}, Permissions: []yt.Permission{yt.PermissionUse}},
+			}), gomock.Nil()).
+			Return(nil)
+
+		strawberry.results["exists"] = false
+		strawberry.results["get_speclet"] = map[string]any{"pool": "chyt", "instance_count": 1}
+		strawberry.results["get_brief_info"] = map[string]any{
+			"state":        "active",
+			"health":       "good",
+			"yt_operation": map[string]any{"id": "1-2-3-4", "state": "running"},
+		}
+
+		component, clique, proxy := newComponent(ctx, k8sClient)
+		Expect(component.Sync(ctx)).Should(Succeed())
+		Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+
+		Expect(strawberry.commands()).Should(Equal([]string{
+			"exists", "create", "get_speclet", "set_options", "start", "get_brief_info",
+		}))
+		Expect(strawberry.calls[3].params).Should(Equal(map[string]any{
+			"alias": "analytics",
+			"options": map[string]any{
+				"instance_count":        2.0,
+				"instance_cpu":          4.0,
+				"instance_total_memory": float64(16 << 30),
+				"enable_geodata":        false,
+			},
+		}))
+
+		Expect(clique.Status.State).Should(Equal(ytv1.ChytCliqueStateSynced))
+		Expect(clique.Status.Health).Should(Equal("good"))
+		Expect(clique.Status.OperationID).Should(Equal("1-2-3-4"))
+		Expect(clique.Status.OperationState).Should(Equal("running"))
+	})
+
+	It("ChytClique Remove; existing clique is removed", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, cliqueSpec, clientSecret).
+			WithStatusSubresource(cliqueSpec).
+			Build()
+
+		strawberry.results["exists"] = true
+
+		component, _, _ := newComponent(ctx, k8sClient)
+		Expect(component.Remove(ctx)).Should(BeTrue())
+		Expect(strawberry.commands()).Should(Equal([]string{"exists", "remove"}))
+	})
+})
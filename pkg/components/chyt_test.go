@@ -0,0 +1,234 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Chyt release test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var chytSpec *ytv1.Chyt
+	var releaserSecret *corev1.Secret
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		chytSpec = &ytv1.Chyt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "chyt",
+				Namespace: "default",
+			},
+			Spec: ytv1.ChytSpec{
+				Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				Image:    "ytsaurus/chyt:2.14.0",
+			},
+			Status: ytv1.ChytStatus{
+				InstalledImage: "ytsaurus/chyt:2.13.0",
+				ReleasePaths: map[string][]string{
+					"ytsaurus/chyt:2.13.0": {
+						"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.13.0",
+						"//sys/bin/clickhouse-trampoline/clickhouse-trampoline",
+					},
+				},
+			},
+		}
+
+		releaserSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus-chyt-chyt-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				consts.TokenSecretKey: []byte("token"),
+			},
+		}
+	})
+
+	newComponent := func(ctx context.Context, k8sClient client.Client) (*Chyt, *ytv1.Chyt, *apiproxy.Chyt) {
+		chyt := &ytv1.Chyt{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(chytSpec), chyt)).Should(Succeed())
+		proxy := apiproxy.NewChyt(chyt, k8sClient, record.NewFakeRecorder(100), scheme)
+		component := NewChyt(ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain"), proxy, ytsaurusSpec)
+		component.ytClient = mockYtClient
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		return component, chyt, proxy
+	}
+
+	// syncChyt runs sync until the release is ready, all jobs are completed once they are created.
+	// Pods of completed jobs report the release paths, which are given by job names.
+	syncChyt := func(ctx context.Context, k8sClient client.Client, reports map[string]string) *ytv1.Chyt {
+		for i := 0; i < 30; i++ {
+			component, chyt, proxy := newComponent(ctx, k8sClient)
+			if component.Status(ctx).SyncStatus == SyncStatusReady {
+				return chyt
+			}
+			Expect(component.Sync(ctx)).Should(Succeed())
+			Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+
+			jobs := &batchv1.JobList{}
+			Expect(k8sClient.List(ctx, jobs)).Should(Succeed())
+			for _, job := range jobs.Items {
+				if job.Status.Succeeded != 0 {
+					continue
+				}
+				job.Status.Succeeded = 1
+				Expect(k8sClient.Status().Update(ctx, &job)).Should(Succeed())
+				createReleaseJobPod(ctx, k8sClient, job.Name, reports[job.Name])
+			}
+		}
+		Fail("chyt release is not ready")
+		return nil
+	}
+
+	It("Chyt image upgrades; uploaded files are reported by the job and files of the outdated release are removed", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(chytSpec, releaserSecret).
+			WithStatusSubresource(chytSpec, &batchv1.Job{}).
+			Build()
+
+		// The unversioned trampoline is rewritten by every release, so it is never removed.
+		outdatedPath := ypath.Path("//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.13.0")
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(outdatedPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(outdatedPath), gomock.Eq(&yt.RemoveNodeOptions{Recursive: true})).
+			Return(nil)
+
+		chyt := syncChyt(ctx, k8sClient, map[string]string{
+			"ytsaurus-chyt-chyt-init-job-release": "//sys/bin/clickhouse-trampoline/clickhouse-trampoline\n" +
+				"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0\n",
+		})
+		Expect(chyt.Status.ReleaseStatus).Should(Equal(ytv1.ChytReleaseStatusFinished))
+		Expect(chyt.Status.InstalledImage).Should(Equal("ytsaurus/chyt:2.14.0"))
+		Expect(chyt.Status.PreviousImages).Should(BeEmpty())
+		Expect(chyt.Status.ReleasePaths).Should(Equal(map[string][]string{
+			"ytsaurus/chyt:2.14.0": {
+				"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0",
+			},
+		}))
+
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: "default", Name: "release-ytsaurus-chyt-chyt-init-job-config"}
+		Expect(k8sClient.Get(ctx, key, cm)).Should(Succeed())
+		script := cm.Data[consts.InitClusterScriptFileName]
+		Expect(script).Should(ContainSubstring("for dir in '//sys/bin/ytserver-clickhouse' '//sys/bin/clickhouse-trampoline'; do"))
+		Expect(script).Should(ContainSubstring("/setup_cluster_for_chyt.sh\n"))
+		Expect(script).Should(ContainSubstring("> /dev/termination-log"))
+	})
+
+	It("Chyt remove; releases are kept until cliques running them are removed", func() {
+		ctx := context.Background()
+		chytSpec.Spec.Image = "ytsaurus/chyt:2.14.0"
+		chytSpec.Status = ytv1.ChytStatus{
+			ReleaseStatus:  ytv1.ChytReleaseStatusFinished,
+			InstalledImage: "ytsaurus/chyt:2.14.0",
+			PreviousImages: []string{"ytsaurus/chyt:2.13.0"},
+			ReleasePaths: map[string][]string{
+				"ytsaurus/chyt:2.14.0": {"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0"},
+				"ytsaurus/chyt:2.13.0": {
+					"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.13.0",
+					"//sys/bin/clickhouse-trampoline/clickhouse-trampoline",
+				},
+			},
+		}
+		newClique := func(name, chytName string) *ytv1.ChytClique {
+			return &ytv1.ChytClique{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: ytv1.ChytCliqueSpec{
+					Chyt: &corev1.LocalObjectReference{Name: chytName},
+					Pool: "chyt",
+				},
+			}
+		}
+		newOtherChyt := func(name, ytsaurusName, path string) *ytv1.Chyt {
+			return &ytv1.Chyt{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: ytv1.ChytSpec{
+					Ytsaurus: &corev1.LocalObjectReference{Name: ytsaurusName},
+					Image:    "ytsaurus/chyt:2.13.0",
+				},
+				Status: ytv1.ChytStatus{
+					InstalledImage: "ytsaurus/chyt:2.13.0",
+					ReleasePaths:   map[string][]string{"ytsaurus/chyt:2.13.0": {path}},
+				},
+			}
+		}
+		analytics := newClique("analytics", "chyt")
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				chytSpec,
+				releaserSecret,
+				newClique("chyt-ch-public", "chyt"),
+				analytics,
+				newClique("other", "other-chyt"),
+				newOtherChyt("other-chyt", "ytsaurus", "//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.13.0"),
+				newOtherChyt("remote-chyt", "remote", "//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0")).
+			WithStatusSubresource(chytSpec).
+			Build()
+
+		component, chyt, _ := newComponent(ctx, k8sClient)
+		Expect(component.Remove(ctx)).Should(BeFalse())
+		Expect(chyt.Status.ReleaseStatus).Should(Equal(ytv1.ChytReleaseStatusWaitingForCliques))
+
+		// The ch_public clique is owned by the chyt, other cliques are removed by their owners.
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "chyt-ch-public"}, &ytv1.ChytClique{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(analytics), &ytv1.ChytClique{})).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, analytics)).Should(Succeed())
+
+		// The file of 2.13.0 is used by another chyt of the cluster and the trampoline by all releases,
+		// so only the file of 2.14.0 is removed, a chyt of another cluster doesn't keep it.
+		// Missing files are reported rather than failing the removal.
+		mockYtClient.EXPECT().
+			NodeExists(gomock.Any(), gomock.Eq(ypath.Path("//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0")), gomock.Nil()).
+			Return(false, nil)
+
+		component, chyt, _ = newComponent(ctx, k8sClient)
+		Expect(component.Remove(ctx)).Should(BeTrue())
+		Expect(chyt.Status.ReleasePaths).Should(BeEmpty())
+	})
+})
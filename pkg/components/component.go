@@ -128,5 +128,6 @@ func (c *localServerComponent) NeedSync() bool {
 
 func LocalServerNeedSync(srv server, ytsaurus *apiproxy.Ytsaurus) bool {
 	return (srv.configNeedsReload() && ytsaurus.IsUpdating()) ||
+		(srv.isRollingUpdate() && srv.needUpdate() && ytsaurus.IsUpdating()) ||
 		srv.needBuild()
 }
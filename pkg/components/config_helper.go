@@ -3,9 +3,12 @@ package components
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/BurntSushi/toml"
 
@@ -31,6 +34,8 @@ type ConfigHelper struct {
 
 	configOverrides *corev1.LocalObjectReference
 	overridesMap    corev1.ConfigMap
+	// overridesName is the config map name which prefixes keys of overrides.
+	overridesName string
 
 	configMap *resources.ConfigMap
 }
@@ -46,10 +51,19 @@ func NewConfigHelper(
 		apiProxy:        apiProxy,
 		generators:      generators,
 		configOverrides: configOverrides,
+		overridesName:   name,
 		configMap:       resources.NewConfigMap(name, labeller, apiProxy),
 	}
 }
 
+// NewCanaryConfigHelper returns a helper of a separate config map with the same configs and overrides,
+// so canary pods get new configs while the rest of pods keep reading the current config map.
+func (h *ConfigHelper) NewCanaryConfigHelper() *ConfigHelper {
+	canary := NewConfigHelper(h.labeller, h.apiProxy, h.GetConfigMapName()+"-canary", h.configOverrides, h.generators)
+	canary.overridesName = h.overridesName
+	return canary
+}
+
 func mergeMapsRecursively(dst, src map[string]interface{}) map[string]interface{} {
 	for key, srcVal := range src {
 		if dstVal, ok := dst[key]; ok {
@@ -122,7 +136,7 @@ func (h *ConfigHelper) getConfig(fileName string) ([]byte, error) {
 	if h.overridesMap.GetResourceVersion() != "" {
 		overrideNames := []string{
 			fileName,
-			fmt.Sprintf("%s--%s", h.GetConfigMapName(), fileName),
+			fmt.Sprintf("%s--%s", h.overridesName, fileName),
 		}
 		for _, overrideName := range overrideNames {
 			if value, ok := h.overridesMap.Data[overrideName]; ok {
@@ -183,6 +197,22 @@ func (h *ConfigHelper) getCurrentConfigValue(fileName string) []byte {
 	return []byte(data)
 }
 
+// GetConfigVersion returns hash of desired configs, empty string if configs cannot be built.
+func (h *ConfigHelper) GetConfigVersion() string {
+	fileNames := h.GetFileNames()
+	sort.Strings(fileNames)
+	hash := sha256.New()
+	for _, fileName := range fileNames {
+		data, err := h.getConfig(fileName)
+		if err != nil {
+			return ""
+		}
+		hash.Write([]byte(fileName))
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
 func (h *ConfigHelper) NeedReload() (bool, error) {
 	for fileName := range h.generators {
 		newConfig, err := h.getConfig(fileName)
@@ -200,6 +230,22 @@ func (h *ConfigHelper) NeedReload() (bool, error) {
 	return false, nil
 }
 
+// GetConfigDiff returns diffs between current and desired configs by file name, unchanged configs are omitted.
+func (h *ConfigHelper) GetConfigDiff() (map[string]string, error) {
+	diff := make(map[string]string)
+	for fileName := range h.generators {
+		newConfig, err := h.getConfig(fileName)
+		if err != nil {
+			return nil, err
+		}
+		curConfig := h.getCurrentConfigValue(fileName)
+		if !cmp.Equal(curConfig, newConfig) {
+			diff[fileName] = cmp.Diff(string(curConfig), string(newConfig))
+		}
+	}
+	return diff, nil
+}
+
 func (h *ConfigHelper) NeedInit() bool {
 	return !resources.Exists(h.configMap)
 }
@@ -40,7 +40,7 @@ func NewControllerAgent(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus,
 		"/usr/bin/ytserver-controller-agent",
 		"ytserver-controller-agent.yson",
 		"ca",
-		"controller-agents",
+		cfgen.GetControllerAgentsServiceName(),
 		func() ([]byte, error) { return cfgen.GetControllerAgentConfig(resource.Spec.ControllerAgents) },
 		WithContainerPorts(corev1.ContainerPort{
 			Name:          consts.YTRPCPortName,
@@ -2,9 +2,16 @@ package components
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
+	"go.ytsaurus.tech/yt/go/ypath"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
@@ -16,15 +23,44 @@ import (
 
 type DataNode struct {
 	localServerComponent
-	cfgen  *ytconfig.NodeGenerator
-	master Component
+	cfgen          *ytconfig.NodeGenerator
+	master         Component
+	ytsaurusClient internalYtsaurusClient
+	spec           ytv1.DataNodesSpec
+
+	// removed is set for a group which was removed from the spec,
+	// its nodes are decommissioned and then its statefulset is deleted.
+	removed bool
 }
 
 func NewDataNode(
 	cfgen *ytconfig.NodeGenerator,
 	ytsaurus *apiproxy.Ytsaurus,
 	master Component,
+	ytsaurusClient internalYtsaurusClient,
 	spec ytv1.DataNodesSpec,
+) *DataNode {
+	return newDataNode(cfgen, ytsaurus, master, ytsaurusClient, spec, false)
+}
+
+// NewRemovedDataNode creates a component for the data nodes group which was removed from the spec.
+func NewRemovedDataNode(
+	cfgen *ytconfig.NodeGenerator,
+	ytsaurus *apiproxy.Ytsaurus,
+	master Component,
+	ytsaurusClient internalYtsaurusClient,
+	name string,
+) *DataNode {
+	return newDataNode(cfgen, ytsaurus, master, ytsaurusClient, ytv1.DataNodesSpec{Name: name}, true)
+}
+
+func newDataNode(
+	cfgen *ytconfig.NodeGenerator,
+	ytsaurus *apiproxy.Ytsaurus,
+	master Component,
+	ytsaurusClient internalYtsaurusClient,
+	spec ytv1.DataNodesSpec,
+	removed bool,
 ) *DataNode {
 	resource := ytsaurus.GetResource()
 	l := labeller.Labeller{
@@ -60,11 +96,48 @@ func NewDataNode(
 		localServerComponent: newLocalServerComponent(&l, ytsaurus, srv),
 		cfgen:                cfgen,
 		master:               master,
+		ytsaurusClient:       ytsaurusClient,
+		spec:                 spec,
+		removed:              removed,
 	}
 }
 
+// GetRemovedDataNodeGroups returns names of data node groups which are not in the spec anymore,
+// but their statefulsets still exist.
+func GetRemovedDataNodeGroups(ctx context.Context, ytsaurus *apiproxy.Ytsaurus) ([]string, error) {
+	resource := ytsaurus.GetResource()
+	var statefulSets appsv1.StatefulSetList
+	err := ytsaurus.APIProxy().ListObjects(ctx, &statefulSets,
+		client.InNamespace(resource.Namespace),
+		client.MatchingLabels{"app.kubernetes.io/instance": resource.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, statefulSet := range statefulSets.Items {
+		name, found := strings.CutPrefix(statefulSet.Labels["app.kubernetes.io/component"], consts.YTComponentLabelDataNode)
+		if !found {
+			continue
+		}
+		if name == "" {
+			name = consts.DefaultName
+		} else if name, found = strings.CutPrefix(name, "-"); !found {
+			continue
+		}
+		if !slices.ContainsFunc(resource.Spec.DataNodes, func(spec ytv1.DataNodesSpec) bool {
+			return spec.Name == name
+		}) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
 func (n *DataNode) IsUpdatable() bool {
-	return true
+	// Removed group is not updated, it is deleted after the update.
+	return !n.removed
 }
 
 func (n *DataNode) GetType() consts.ComponentType { return consts.DataNodeType }
@@ -76,6 +149,18 @@ func (n *DataNode) Fetch(ctx context.Context) error {
 func (n *DataNode) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
 	var err error
 
+	if n.removed {
+		return n.doSyncRemoved(ctx, dry)
+	}
+
+	// Nodes are decommissioned before the statefulset is shrunk in any cluster state.
+	// Component is not running until that, so an update is not started in the middle of the decommission.
+	if n.server.currentReplicas() > n.spec.InstanceCount {
+		if status, err := n.handleScaleDown(ctx, dry); status != nil {
+			return *status, err
+		}
+	}
+
 	if ytv1.IsReadyToUpdateClusterState(n.ytsaurus.GetClusterState()) && n.server.needUpdate() {
 		return SimpleStatus(SyncStatusNeedLocalUpdate), err
 	}
@@ -95,6 +180,12 @@ func (n *DataNode) doSync(ctx context.Context, dry bool) (ComponentStatus, error
 	}
 
 	if n.NeedSync() {
+		// Pods are recreated by the update with the same instance count, so there is nothing to recommission.
+		if n.ytsaurus.GetClusterState() != ytv1.ClusterStateUpdating {
+			if status, err := n.handleScaleUp(ctx, dry); status != nil {
+				return *status, err
+			}
+		}
 		if !dry {
 			err = n.server.Sync(ctx)
 		}
@@ -108,6 +199,26 @@ func (n *DataNode) doSync(ctx context.Context, dry bool) (ComponentStatus, error
 	return SimpleStatus(SyncStatusReady), err
 }
 
+// doSyncRemoved decommissions all nodes of the removed group and deletes its resources.
+func (n *DataNode) doSyncRemoved(ctx context.Context, dry bool) (ComponentStatus, error) {
+	var err error
+
+	if n.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
+		return SimpleStatus(SyncStatusReady), err
+	}
+
+	if n.server.currentReplicas() > 0 {
+		if status, err := n.handleScaleDown(ctx, dry); status != nil {
+			return *status, err
+		}
+	}
+
+	if !dry {
+		err = n.server.removeResources(ctx)
+	}
+	return WaitingStatus(SyncStatusPending, "removal"), err
+}
+
 func (n *DataNode) Status(ctx context.Context) (ComponentStatus, error) {
 	return n.doSync(ctx, true)
 }
@@ -116,3 +227,130 @@ func (n *DataNode) Sync(ctx context.Context) error {
 	_, err := n.doSync(ctx, false)
 	return err
 }
+
+func (n *DataNode) getNodePath(index int32) ypath.Path {
+	podName := fmt.Sprintf("%s-%d", n.cfgen.GetDataNodesStatefulSetName(n.spec.Name), index)
+	return ypath.Path("//sys/cluster_nodes").Child(n.cfgen.GetDataNodePodAddress(n.spec.Name, podName))
+}
+
+// handleScaleDown decommissions nodes which are going to be removed and waits for their chunks
+// to be replicated to other nodes. Masters could be read-only during the update, so nodes are only
+// checked there. Nil status means that the statefulset can be shrunk.
+func (n *DataNode) handleScaleDown(ctx context.Context, dry bool) (*ComponentStatus, error) {
+	currentReplicas := n.server.currentReplicas()
+	isUpdating := n.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating
+
+	ytClientStatus, err := n.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return &ytClientStatus, err
+	}
+	if (!isUpdating && ytClientStatus.SyncStatus != SyncStatusReady) || n.ytsaurusClient.GetYtClient() == nil {
+		return ptr.To(WaitingStatus(SyncStatusBlocked, n.ytsaurusClient.GetName())), err
+	}
+
+	if !isUpdating && !dry {
+		err = n.setDecommissioned(ctx, n.spec.InstanceCount, currentReplicas, true)
+		if err != nil {
+			return ptr.To(WaitingStatus(SyncStatusPending, "nodes decommission")), err
+		}
+	}
+
+	decommissioned, err := n.areNodesDecommissioned(ctx, n.spec.InstanceCount, currentReplicas)
+	if err != nil {
+		return ptr.To(WaitingStatus(SyncStatusPending, "nodes decommission")), err
+	}
+	if !decommissioned {
+		if isUpdating {
+			return ptr.To(WaitingStatus(SyncStatusBlocked, "nodes decommission after update")), err
+		}
+		return ptr.To(WaitingStatus(SyncStatusPending, "nodes decommission")), err
+	}
+	return nil, nil
+}
+
+// handleScaleUp recommissions nodes which were decommissioned by the previous scale down.
+// Nil status means that the statefulset can be resized.
+func (n *DataNode) handleScaleUp(ctx context.Context, dry bool) (*ComponentStatus, error) {
+	currentReplicas := n.server.currentReplicas()
+	if currentReplicas >= n.spec.InstanceCount {
+		return nil, nil
+	}
+
+	ytClientStatus, err := n.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return &ytClientStatus, err
+	}
+	if ytClientStatus.SyncStatus != SyncStatusReady {
+		// Cluster is not initialized yet, so there are no decommissioned nodes.
+		return nil, nil
+	}
+
+	if !dry {
+		err = n.setDecommissioned(ctx, currentReplicas, n.spec.InstanceCount, false)
+	}
+	return nil, err
+}
+
+func (n *DataNode) setDecommissioned(ctx context.Context, from, to int32, decommissioned bool) error {
+	ytClient := n.ytsaurusClient.GetYtClient()
+	// Nodes with the highest ordinals are removed first.
+	for index := to - 1; index >= from; index-- {
+		nodePath := n.getNodePath(index)
+		exists, err := ytClient.NodeExists(ctx, nodePath, nil)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err = ytClient.SetNode(ctx, nodePath.Attr("decommissioned"), decommissioned, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *DataNode) areNodesDecommissioned(ctx context.Context, from, to int32) (bool, error) {
+	logger := log.FromContext(ctx)
+	ytClient := n.ytsaurusClient.GetYtClient()
+
+	for index := to - 1; index >= from; index-- {
+		nodePath := n.getNodePath(index)
+		exists, err := ytClient.NodeExists(ctx, nodePath, nil)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			continue
+		}
+		var decommissioned bool
+		err = ytClient.GetNode(ctx, nodePath.Attr("decommissioned"), &decommissioned, nil)
+		if err != nil {
+			return false, err
+		}
+		if !decommissioned {
+			logger.Info("node is not decommissioned", "node", nodePath)
+			return false, nil
+		}
+		var chunkCount int64
+		err = ytClient.GetNode(ctx, nodePath.Attr("statistics").Child("total_stored_chunk_count"), &chunkCount, nil)
+		if err != nil {
+			return false, err
+		}
+		if chunkCount != 0 {
+			logger.Info("waiting for chunks to be moved from decommissioned node", "node", nodePath, "chunkCount", chunkCount)
+			return false, nil
+		}
+	}
+
+	var lostVitalChunkCount int64
+	err := ytClient.GetNode(ctx, ypath.Path("//sys/lost_vital_chunks").Attr("count"), &lostVitalChunkCount, nil)
+	if err != nil {
+		return false, err
+	}
+	if lostVitalChunkCount != 0 {
+		logger.Info("waiting for lost vital chunks to be replicated", "lostVitalChunkCount", lostVitalChunkCount)
+		return false, nil
+	}
+	return true, nil
+}
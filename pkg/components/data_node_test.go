@@ -0,0 +1,179 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Data node test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	lostVitalChunksPath := ypath.Path("//sys/lost_vital_chunks").Attr("count")
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage: "ytsaurus/ytsaurus:latest",
+				},
+				DataNodes: []ytv1.DataNodesSpec{
+					{
+						Name: "default",
+						InstanceSpec: ytv1.InstanceSpec{
+							InstanceCount: 2,
+						},
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+	})
+
+	newDataNode := func(replicas int32, removed bool) (*DataNode, *FakeServer) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, client, record.NewFakeRecorder(100), scheme)
+		cfgen := ytconfig.NewLocalNodeGenerator(ytsaurusSpec, "cluster_domain")
+
+		var dataNode *DataNode
+		if removed {
+			dataNode = NewRemovedDataNode(cfgen, ytsaurus, NewFakeComponent("master", "Master"), NewFakeYtsaurusClient(mockYtClient), "removed")
+		} else {
+			dataNode = NewDataNode(cfgen, ytsaurus, NewFakeComponent("master", "Master"), NewFakeYtsaurusClient(mockYtClient), ytsaurusSpec.Spec.DataNodes[0])
+		}
+		server := NewFakeServer()
+		server.replicas = replicas
+		server.needBuilt = true
+		dataNode.server = server
+		return dataNode, server
+	}
+
+	expectNodeState := func(nodePath ypath.Path, decommissioned bool, chunkCount int64) {
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("decommissioned")), gomock.Any(), gomock.Nil()).
+			SetArg(2, decommissioned).
+			Return(nil)
+		if decommissioned {
+			mockYtClient.EXPECT().
+				GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("statistics").Child("total_stored_chunk_count")), gomock.Any(), gomock.Nil()).
+				SetArg(2, chunkCount).
+				Return(nil)
+		}
+	}
+
+	expectLostVitalChunks := func(count int64) {
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(lostVitalChunksPath), gomock.Any(), gomock.Nil()).
+			SetArg(2, count).
+			Return(nil)
+	}
+
+	It("Data node Sync; scale down waits for decommission", func() {
+		ctx := context.Background()
+		dataNode, server := newDataNode(3, false)
+		nodePath := dataNode.getNodePath(2)
+		Expect(nodePath).Should(Equal(ypath.Path("//sys/cluster_nodes/dnd-ytsaurus-2.data-nodes-ytsaurus.default.svc.cluster_domain:9012")))
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(nodePath), gomock.Nil()).Return(true, nil).AnyTimes()
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("decommissioned")), gomock.Eq(true), gomock.Nil()).
+			Return(nil).
+			Times(2)
+
+		expectNodeState(nodePath, true, 5)
+		Expect(dataNode.Sync(ctx)).Should(Succeed())
+		Expect(server.synced).Should(BeFalse())
+
+		expectNodeState(nodePath, true, 0)
+		expectLostVitalChunks(3)
+		status, err := dataNode.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusPending))
+		Expect(status.Message).Should(ContainSubstring("nodes decommission"))
+
+		expectNodeState(nodePath, true, 0)
+		expectLostVitalChunks(0)
+		Expect(dataNode.Sync(ctx)).Should(Succeed())
+		Expect(server.synced).Should(BeTrue())
+	})
+
+	It("Data node Status; scale down is blocked during the update", func() {
+		ytsaurusSpec.Status.State = ytv1.ClusterStateUpdating
+		dataNode, server := newDataNode(3, false)
+		nodePath := dataNode.getNodePath(2)
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(nodePath), gomock.Nil()).Return(true, nil)
+		expectNodeState(nodePath, false, 0)
+		Expect(dataNode.Sync(context.Background())).Should(Succeed())
+		Expect(server.synced).Should(BeFalse())
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(nodePath), gomock.Nil()).Return(true, nil)
+		expectNodeState(nodePath, false, 0)
+		status, err := dataNode.Status(context.Background())
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusBlocked))
+	})
+
+	It("Data node Sync; scale up recommissions nodes", func() {
+		dataNode, server := newDataNode(1, false)
+		nodePath := dataNode.getNodePath(1)
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(nodePath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("decommissioned")), gomock.Eq(false), gomock.Nil()).
+			Return(nil)
+		Expect(dataNode.Sync(context.Background())).Should(Succeed())
+		Expect(server.synced).Should(BeTrue())
+	})
+
+	It("Data node Sync; removed group is decommissioned and deleted", func() {
+		ctx := context.Background()
+		dataNode, server := newDataNode(1, true)
+		Expect(dataNode.IsUpdatable()).Should(BeFalse())
+		nodePath := dataNode.getNodePath(0)
+		Expect(nodePath).Should(Equal(ypath.Path("//sys/cluster_nodes/dnd-removed-ytsaurus-0.data-nodes-removed-ytsaurus.default.svc.cluster_domain:9012")))
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(nodePath), gomock.Nil()).Return(true, nil).AnyTimes()
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("decommissioned")), gomock.Eq(true), gomock.Nil()).
+			Return(nil).
+			Times(2)
+
+		expectNodeState(nodePath, true, 1)
+		Expect(dataNode.Sync(ctx)).Should(Succeed())
+		Expect(server.removed).Should(BeFalse())
+
+		expectNodeState(nodePath, true, 0)
+		expectLostVitalChunks(0)
+		Expect(dataNode.Sync(ctx)).Should(Succeed())
+		Expect(server.removed).Should(BeTrue())
+		Expect(server.synced).Should(BeFalse())
+	})
+})
@@ -2,6 +2,12 @@ package components
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
@@ -16,13 +22,15 @@ import (
 type ExecNode struct {
 	baseExecNode
 	localComponent
-	master Component
+	master         Component
+	ytsaurusClient internalYtsaurusClient
 }
 
 func NewExecNode(
 	cfgen *ytconfig.NodeGenerator,
 	ytsaurus *apiproxy.Ytsaurus,
 	master Component,
+	ytsaurusClient internalYtsaurusClient,
 	spec ytv1.ExecNodesSpec,
 ) *ExecNode {
 	resource := ytsaurus.GetResource()
@@ -80,7 +88,8 @@ func NewExecNode(
 			spec:          &spec,
 			sidecarConfig: sidecarConfig,
 		},
-		master: master,
+		master:         master,
+		ytsaurusClient: ytsaurusClient,
 	}
 }
 
@@ -97,6 +106,15 @@ func (n *ExecNode) doSync(ctx context.Context, dry bool) (ComponentStatus, error
 		return SimpleStatus(SyncStatusNeedLocalUpdate), err
 	}
 
+	if n.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating && n.IsDrainEnabled() && IsUpdatingComponent(n.ytsaurus, n) {
+		switch n.ytsaurus.GetUpdateState() {
+		case ytv1.UpdateStateWaitingForExecNodesDrain:
+			return n.handleDrain(ctx, dry)
+		case ytv1.UpdateStateWaitingForExecNodesUndrain:
+			return n.handleUndrain(ctx, dry)
+		}
+	}
+
 	if n.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
 		if status, err := handleUpdatingClusterState(ctx, n.ytsaurus, n, &n.localComponent, n.server, dry); status != nil {
 			return *status, err
@@ -130,3 +148,135 @@ func (n *ExecNode) Sync(ctx context.Context) error {
 	_, err := n.doSync(ctx, false)
 	return err
 }
+
+// IsDrainEnabled checks if running jobs should be finished before removal of pods during update.
+func (n *ExecNode) IsDrainEnabled() bool {
+	return n.spec.DrainTimeout != nil
+}
+
+func (n *ExecNode) getNodePaths() []ypath.Path {
+	statefulSetName := n.cfgen.GetExecNodesStatefulSetName(n.spec.Name)
+	paths := make([]ypath.Path, 0, n.spec.InstanceCount)
+	for i := 0; i < int(n.spec.InstanceCount); i++ {
+		podName := fmt.Sprintf("%s-%d", statefulSetName, i)
+		paths = append(paths, ypath.Path("//sys/cluster_nodes").Child(n.cfgen.GetExecNodePodAddress(n.spec.Name, podName)))
+	}
+	return paths
+}
+
+func (n *ExecNode) setDisableSchedulerJobs(ctx context.Context, disable bool) error {
+	ytClient := n.ytsaurusClient.GetYtClient()
+	for _, nodePath := range n.getNodePaths() {
+		exists, err := ytClient.NodeExists(ctx, nodePath, nil)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// Node has never been registered.
+			continue
+		}
+		if err = ytClient.SetNode(ctx, nodePath.Attr("disable_scheduler_jobs"), disable, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *ExecNode) getRunningJobCount(ctx context.Context) (int, error) {
+	ytClient := n.ytsaurusClient.GetYtClient()
+	jobCount := 0
+	for _, nodePath := range n.getNodePaths() {
+		exists, err := ytClient.NodeExists(ctx, nodePath, nil)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			continue
+		}
+		var userSlots int
+		if err = ytClient.GetNode(ctx, nodePath.Attr("resource_usage").Child("user_slots"), &userSlots, nil); err != nil {
+			return 0, err
+		}
+		jobCount += userSlots
+	}
+	return jobCount, nil
+}
+
+func (n *ExecNode) handleDrain(ctx context.Context, dry bool) (ComponentStatus, error) {
+	logger := log.FromContext(ctx)
+
+	if n.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesDrainedCondition(n.GetName())) {
+		return NewComponentStatus(SyncStatusReady, "Nothing to do now"), nil
+	}
+
+	ytClientStatus, err := n.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return ytClientStatus, err
+	}
+	if ytClientStatus.SyncStatus != SyncStatusReady && ytClientStatus.SyncStatus != SyncStatusUpdating {
+		return WaitingStatus(SyncStatusBlocked, n.ytsaurusClient.GetName()), err
+	}
+
+	started := n.ytsaurus.GetUpdateStatusCondition(n.labeller.GetNodesDrainStartedCondition())
+	if started == nil || started.Status != metav1.ConditionTrue {
+		if !dry {
+			err = n.setDisableSchedulerJobs(ctx, true)
+			if err == nil {
+				n.setCondition(ctx, n.labeller.GetNodesDrainStartedCondition(), "Scheduler jobs were disabled on nodes")
+			}
+		}
+		return WaitingStatus(SyncStatusUpdating, "nodes drain start"), err
+	}
+
+	if time.Since(started.LastTransitionTime.Time) > n.spec.DrainTimeout.Duration {
+		if !dry {
+			logger.Info("nodes drain timeout expired, running jobs will be aborted", "component", n.GetName())
+			n.setCondition(ctx, labeller.GetNodesDrainedCondition(n.GetName()), "Nodes drain timeout expired")
+		}
+		return WaitingStatus(SyncStatusUpdating, "nodes drain"), err
+	}
+
+	jobCount, err := n.getRunningJobCount(ctx)
+	if err != nil {
+		return WaitingStatus(SyncStatusUpdating, "nodes drain"), err
+	}
+	if jobCount > 0 {
+		return WaitingStatus(SyncStatusUpdating, fmt.Sprintf("%d running jobs", jobCount)), err
+	}
+
+	if !dry {
+		n.setCondition(ctx, labeller.GetNodesDrainedCondition(n.GetName()), "Nodes were drained")
+	}
+	return WaitingStatus(SyncStatusUpdating, "nodes drain"), err
+}
+
+func (n *ExecNode) handleUndrain(ctx context.Context, dry bool) (ComponentStatus, error) {
+	if n.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesUndrainedCondition(n.GetName())) {
+		return NewComponentStatus(SyncStatusReady, "Nothing to do now"), nil
+	}
+
+	ytClientStatus, err := n.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return ytClientStatus, err
+	}
+	if ytClientStatus.SyncStatus != SyncStatusReady && ytClientStatus.SyncStatus != SyncStatusUpdating {
+		return WaitingStatus(SyncStatusBlocked, n.ytsaurusClient.GetName()), err
+	}
+
+	if !dry {
+		err = n.setDisableSchedulerJobs(ctx, false)
+		if err == nil {
+			n.setCondition(ctx, labeller.GetNodesUndrainedCondition(n.GetName()), "Scheduler jobs were enabled on nodes")
+		}
+	}
+	return WaitingStatus(SyncStatusUpdating, "nodes undrain"), err
+}
+
+func (n *ExecNode) setCondition(ctx context.Context, conditionType, message string) {
+	n.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Update",
+		Message: message,
+	})
+}
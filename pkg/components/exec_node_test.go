@@ -0,0 +1,126 @@
+package components
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Exec node test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	nodePath := ypath.Path("//sys/cluster_nodes/end-ytsaurus-0.exec-nodes-ytsaurus.default.svc.cluster_domain:9029")
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage: "ytsaurus/ytsaurus:latest",
+				},
+				ExecNodes: []ytv1.ExecNodesSpec{
+					{
+						Name: "default",
+						InstanceSpec: ytv1.InstanceSpec{
+							InstanceCount: 1,
+						},
+						DrainTimeout: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateUpdating,
+				UpdateStatus: ytv1.UpdateStatus{
+					Flow:  ytv1.UpdateFlowStateless,
+					State: ytv1.UpdateStateWaitingForExecNodesDrain,
+				},
+			},
+		}
+	})
+
+	It("Exec node Sync; drain and undrain", func() {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, client, record.NewFakeRecorder(100), scheme)
+		ytsaurusClient := NewFakeYtsaurusClient(mockYtClient)
+
+		cfgen := ytconfig.NewLocalNodeGenerator(ytsaurusSpec, "cluster_domain")
+		execNode := NewExecNode(cfgen, ytsaurus, NewFakeComponent("master", "Master"), ytsaurusClient, ytsaurusSpec.Spec.ExecNodes[0])
+		execNode.server = NewFakeServer()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(nodePath), gomock.Nil()).Return(true, nil).AnyTimes()
+
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("disable_scheduler_jobs")), gomock.Eq(true), gomock.Nil()).
+			Return(nil)
+		Expect(execNode.Sync(context.Background())).Should(Succeed())
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(execNode.labeller.GetNodesDrainStartedCondition())).Should(BeTrue())
+
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("resource_usage").Child("user_slots")), gomock.Any(), gomock.Nil()).
+			SetArg(2, 3).
+			Return(nil)
+		status, err := execNode.Status(context.Background())
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusUpdating))
+		Expect(status.Message).Should(ContainSubstring("3 running jobs"))
+
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("resource_usage").Child("user_slots")), gomock.Any(), gomock.Nil()).
+			SetArg(2, 0).
+			Return(nil)
+		Expect(execNode.Sync(context.Background())).Should(Succeed())
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesDrainedCondition(execNode.GetName()))).Should(BeTrue())
+
+		ytsaurusSpec.Status.UpdateStatus.State = ytv1.UpdateStateWaitingForExecNodesUndrain
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("disable_scheduler_jobs")), gomock.Eq(false), gomock.Nil()).
+			Return(nil)
+		Expect(execNode.Sync(context.Background())).Should(Succeed())
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesUndrainedCondition(execNode.GetName()))).Should(BeTrue())
+	})
+
+	It("Exec node Sync; drain timeout", func() {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, client, record.NewFakeRecorder(100), scheme)
+		ytsaurusClient := NewFakeYtsaurusClient(mockYtClient)
+
+		cfgen := ytconfig.NewLocalNodeGenerator(ytsaurusSpec, "cluster_domain")
+		execNode := NewExecNode(cfgen, ytsaurus, NewFakeComponent("master", "Master"), ytsaurusClient, ytsaurusSpec.Spec.ExecNodes[0])
+		execNode.server = NewFakeServer()
+
+		ytsaurus.SetUpdateStatusCondition(context.Background(), metav1.Condition{
+			Type:               execNode.labeller.GetNodesDrainStartedCondition(),
+			Status:             metav1.ConditionTrue,
+			Reason:             "Update",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		})
+		Expect(execNode.Sync(context.Background())).Should(Succeed())
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodesDrainedCondition(execNode.GetName()))).Should(BeTrue())
+	})
+})
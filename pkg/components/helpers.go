@@ -3,6 +3,7 @@ package components
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -51,14 +52,18 @@ func CreateTabletCells(ctx context.Context, ytClient yt.Client, bundle string, t
 	return nil
 }
 
-func GetNotGoodTabletCellBundles(ctx context.Context, ytClient yt.Client) ([]string, error) {
+func GetTabletCellBundlesHealth(ctx context.Context, ytClient yt.Client) ([]TabletCellBundleHealth, error) {
 	var tabletCellBundles []TabletCellBundleHealth
 	err := ytClient.ListNode(
 		ctx,
 		ypath.Path("//sys/tablet_cell_bundles"),
 		&tabletCellBundles,
 		&yt.ListNodeOptions{Attributes: []string{"health"}})
+	return tabletCellBundles, err
+}
 
+func GetNotGoodTabletCellBundles(ctx context.Context, ytClient yt.Client) ([]string, error) {
+	tabletCellBundles, err := GetTabletCellBundlesHealth(ctx, ytClient)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +78,75 @@ func GetNotGoodTabletCellBundles(ctx context.Context, ytClient yt.Client) ([]str
 	return notGoodBundles, err
 }
 
+type tabletCellInfo struct {
+	ID               string `yson:",value"`
+	TabletCellBundle string `yson:"tablet_cell_bundle,attr"`
+	CreationTime     string `yson:"creation_time,attr"`
+}
+
+// RemoveTabletCells removes tablet cells of the bundle above tabletCellCount, the most recently created go first.
+// Cells are ordered by their creation time, since @tablet_cell_ids of the bundle is not ordered.
+func RemoveTabletCells(ctx context.Context, ytClient yt.Client, bundle string, tabletCellCount int) error {
+	var tabletCells []tabletCellInfo
+	if err := ytClient.ListNode(
+		ctx,
+		ypath.Path("//sys/tablet_cells"),
+		&tabletCells,
+		&yt.ListNodeOptions{Attributes: []string{"tablet_cell_bundle", "creation_time"}}); err != nil {
+		return err
+	}
+
+	var bundleCells []tabletCellInfo
+	for _, cell := range tabletCells {
+		if cell.TabletCellBundle == bundle {
+			bundleCells = append(bundleCells, cell)
+		}
+	}
+	// Creation time is in ISO 8601 format, so it is ordered as a string.
+	sort.Slice(bundleCells, func(i, j int) bool {
+		if bundleCells[i].CreationTime != bundleCells[j].CreationTime {
+			return bundleCells[i].CreationTime < bundleCells[j].CreationTime
+		}
+		return bundleCells[i].ID < bundleCells[j].ID
+	})
+
+	for i := len(bundleCells) - 1; i >= tabletCellCount; i -= 1 {
+		if err := ytClient.RemoveNode(ctx, ypath.Path("#"+bundleCells[i].ID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getBundleReplicationOptions returns replication options of tablet cell bundles for clusters
+// which have not enough data nodes for the default replication factor.
+func getBundleReplicationOptions(maxReplicationFactor int32) map[string]any {
+	if maxReplicationFactor >= 3 {
+		return nil
+	}
+	return map[string]any{
+		"changelog_replication_factor": 1,
+		"changelog_read_quorum":        1,
+		"changelog_write_quorum":       1,
+		"snapshot_replication_factor":  1,
+	}
+}
+
+func toYtACL(acl []ytv1.AccessControlEntry) []yt.ACE {
+	result := make([]yt.ACE, 0, len(acl))
+	for _, entry := range acl {
+		ace := yt.ACE{
+			Action:   yt.SecurityAction(entry.Action),
+			Subjects: entry.Subjects,
+		}
+		for _, permission := range entry.Permissions {
+			ace.Permissions = append(ace.Permissions, yt.Permission(permission))
+		}
+		result = append(result, ace)
+	}
+	return result
+}
+
 func CreateUser(ctx context.Context, ytClient yt.Client, userName, token string, isSuperuser bool) error {
 	var err error
 	_, err = ytClient.CreateObject(ctx, yt.NodeUser, &yt.CreateObjectOptions{
@@ -113,6 +187,25 @@ func CreateUser(ctx context.Context, ytClient yt.Client, userName, token string,
 	return err
 }
 
+// fetchRemoteClusters returns referenced RemoteYtsaurus objects, missing ones are skipped.
+func fetchRemoteClusters(ctx context.Context, apiProxy apiproxy.APIProxy, refs []corev1.LocalObjectReference) ([]ytv1.RemoteYtsaurus, error) {
+	logger := log.FromContext(ctx)
+
+	var remoteClusters []ytv1.RemoteYtsaurus
+	for _, ref := range refs {
+		var remote ytv1.RemoteYtsaurus
+		if err := apiProxy.FetchObject(ctx, ref.Name, &remote); err != nil {
+			return nil, err
+		}
+		if remote.Name == "" {
+			logger.Info("Remote ytsaurus is not found", "name", ref.Name)
+			continue
+		}
+		remoteClusters = append(remoteClusters, remote)
+	}
+	return remoteClusters, nil
+}
+
 func IsUpdatingComponent(ytsaurus *apiproxy.Ytsaurus, component Component) bool {
 	componentNames := ytsaurus.GetLocalUpdatingComponents()
 	return (componentNames == nil && component.IsUpdatable()) || slices.Contains(componentNames, component.GetName())
@@ -130,10 +223,7 @@ func handleUpdatingClusterState(
 
 	if IsUpdatingComponent(ytsaurus, cmp) {
 		if ytsaurus.GetUpdateState() == ytv1.UpdateStateWaitingForPodsRemoval {
-			if !dry {
-				err = removePods(ctx, server, cmpBase)
-			}
-			return ptr.To(WaitingStatus(SyncStatusUpdating, "pods removal")), err
+			return handlePodsRemoval(ctx, ytsaurus, cmpBase, server, dry)
 		}
 
 		if ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsCreation {
@@ -145,6 +235,34 @@ func handleUpdatingClusterState(
 	return nil, err
 }
 
+// handlePodsRemoval removes pods of the updating component or replaces them batch by batch
+// if rolling update is configured. Nil status means that the component should apply its new spec first.
+func handlePodsRemoval(
+	ctx context.Context,
+	ytsaurus *apiproxy.Ytsaurus,
+	cmpBase *localComponent,
+	server server,
+	dry bool,
+) (*ComponentStatus, error) {
+	var err error
+
+	if server.isRollingUpdate() && ytsaurus.GetUpdateFlow() == ytv1.UpdateFlowStateless {
+		if server.needUpdate() {
+			// With OnDelete strategy statefulset update doesn't restart pods.
+			return nil, err
+		}
+		if !dry {
+			err = rollPods(ctx, server, cmpBase)
+		}
+		return ptr.To(WaitingStatus(SyncStatusUpdating, "pods rolling update")), err
+	}
+
+	if !dry {
+		err = removePods(ctx, server, cmpBase)
+	}
+	return ptr.To(WaitingStatus(SyncStatusUpdating, "pods removal")), err
+}
+
 func SetPathAcl(path string, acl []yt.ACE) string {
 	formattedAcl, err := yson.MarshalFormat(acl, yson.FormatText)
 	if err != nil {
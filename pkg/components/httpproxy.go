@@ -23,8 +23,7 @@ type HttpProxy struct {
 	master           Component
 	balancingService *resources.HTTPService
 
-	role        string
-	httpsSecret *resources.TLSSecret
+	role string
 }
 
 func NewHTTPProxy(
@@ -44,6 +43,16 @@ func NewHTTPProxy(
 		spec.InstanceSpec.MonitoringPort = ptr.To(int32(consts.HTTPProxyMonitoringPort))
 	}
 
+	transport := ytconfig.GetHTTPTransport(&resource.Spec.CommonSpec, spec.Transport)
+	var httpsSecret *resources.TLSSecret
+	if transport.HTTPSSecret != nil {
+		httpsSecret = resources.NewTLSSecret(
+			transport.HTTPSSecret.Name,
+			consts.HTTPSSecretVolumeName,
+			consts.HTTPSSecretMountPoint,
+			ytsaurus.APIProxy())
+	}
+
 	srv := newServer(
 		&l,
 		ytsaurus,
@@ -74,19 +83,12 @@ func NewHTTPProxy(
 		),
 		WithCustomReadinessProbeEndpointPort(consts.HTTPProxyHTTPPort),
 		WithCustomReadinessProbeEndpointPath("/ping"),
+		WithTLSSecret(httpsSecret),
 	)
 
-	var httpsSecret *resources.TLSSecret
-	if spec.Transport.HTTPSSecret != nil {
-		httpsSecret = resources.NewTLSSecret(
-			spec.Transport.HTTPSSecret.Name,
-			consts.HTTPSSecretVolumeName,
-			consts.HTTPSSecretMountPoint)
-	}
-
 	balancingService := resources.NewHTTPService(
 		cfgen.GetHTTPProxiesServiceName(spec.Role),
-		&spec.Transport,
+		&transport,
 		&l,
 		ytsaurus.APIProxy())
 
@@ -99,7 +101,6 @@ func NewHTTPProxy(
 		master:               masterReconciler,
 		serviceType:          spec.ServiceType,
 		role:                 spec.Role,
-		httpsSecret:          httpsSecret,
 		balancingService:     balancingService,
 	}
 }
@@ -140,11 +141,6 @@ func (hp *HttpProxy) doSync(ctx context.Context, dry bool) (ComponentStatus, err
 
 	if hp.NeedSync() {
 		if !dry {
-			statefulSet := hp.server.buildStatefulSet()
-			if hp.httpsSecret != nil {
-				hp.httpsSecret.AddVolume(&statefulSet.Spec.Template.Spec)
-				hp.httpsSecret.AddVolumeMount(&statefulSet.Spec.Template.Spec.Containers[0])
-			}
 			err = hp.server.Sync(ctx)
 		}
 		return WaitingStatus(SyncStatusPending, "components"), err
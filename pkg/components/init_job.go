@@ -16,6 +16,7 @@ import (
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/metrics"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 )
@@ -169,6 +170,14 @@ func (j *InitJob) Sync(ctx context.Context, dry bool) (ComponentStatus, error) {
 			Reason:  "InitJobCompleted",
 			Message: "Init job successfully completed",
 		})
+		if duration, ok := j.initJob.Duration(); ok {
+			metrics.ObserveInitJobDuration(
+				j.labeller.ObjectMeta.Namespace,
+				j.labeller.GetClusterName(),
+				j.labeller.ComponentName,
+				j.initJob.Name(),
+				duration)
+		}
 	}
 
 	return WaitingStatus(SyncStatusPending, fmt.Sprintf("setting %s condition", j.initCompletedCondition)), err
@@ -28,9 +28,10 @@ type Master struct {
 	localServerComponent
 	cfgen *ytconfig.Generator
 
-	initJob          *InitJob
-	exitReadOnlyJob  *InitJob
-	adminCredentials corev1.Secret
+	initJob             *InitJob
+	exitReadOnlyJob     *InitJob
+	snapshotsExportJobs []*snapshotsExportJob
+	adminCredentials    corev1.Secret
 }
 
 func NewMaster(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus) *Master {
@@ -84,11 +85,52 @@ func NewMaster(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus) *Master {
 		cfgen.GetNativeClientConfig,
 	)
 
+	var snapshotsExportJobs []*snapshotsExportJob
+	if export := resource.Spec.PrimaryMasters.SnapshotsExport; export != nil {
+		image := resource.Spec.CoreImage
+		if export.Image != nil {
+			image = *export.Image
+		}
+		newSnapshotsExportJob := func(name string, spec *ytv1.MastersSpec, podName string) *snapshotsExportJob {
+			return &snapshotsExportJob{
+				InitJob: NewInitJob(
+					&l,
+					ytsaurus.APIProxy(),
+					ytsaurus,
+					resource.Spec.ImagePullSecrets,
+					name,
+					consts.ClientConfigFileName,
+					image,
+					cfgen.GetNativeClientConfig,
+				),
+				spec:    spec,
+				podName: podName,
+			}
+		}
+		for index, podName := range cfgen.GetMasterPodNames() {
+			snapshotsExportJobs = append(snapshotsExportJobs, newSnapshotsExportJob(
+				fmt.Sprintf("snapshots-export-%d", index),
+				&resource.Spec.PrimaryMasters,
+				podName))
+		}
+		// Snapshots of secondary cells are exported along with the primary ones.
+		for i := range resource.Spec.SecondaryMasters {
+			spec := &resource.Spec.SecondaryMasters[i]
+			for index, podName := range cfgen.GetSecondaryMasterPodNames(spec) {
+				snapshotsExportJobs = append(snapshotsExportJobs, newSnapshotsExportJob(
+					fmt.Sprintf("snapshots-export-%d-%d", spec.CellTag, index),
+					spec,
+					podName))
+			}
+		}
+	}
+
 	return &Master{
 		localServerComponent: newLocalServerComponent(&l, ytsaurus, srv),
 		cfgen:                cfgen,
 		initJob:              initJob,
 		exitReadOnlyJob:      exitReadOnlyJob,
+		snapshotsExportJobs:  snapshotsExportJobs,
 	}
 }
 
@@ -109,11 +151,19 @@ func (m *Master) Fetch(ctx context.Context) error {
 		}
 	}
 
-	return resources.Fetch(ctx,
+	if err := resources.Fetch(ctx,
 		m.server,
 		m.initJob,
 		m.exitReadOnlyJob,
-	)
+	); err != nil {
+		return err
+	}
+	for _, job := range m.snapshotsExportJobs {
+		if err := job.Fetch(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (m *Master) initAdminUser() string {
@@ -145,9 +195,14 @@ type Medium struct {
 }
 
 func (m *Master) getExtraMedia() []Medium {
+	return getExtraMedia(&m.ytsaurus.GetResource().Spec)
+}
+
+// getExtraMedia returns media of data node locations which are created in addition to the default one.
+func getExtraMedia(spec *ytv1.YtsaurusSpec) []Medium {
 	mediaMap := make(map[string]Medium)
 
-	for _, d := range m.ytsaurus.GetResource().Spec.DataNodes {
+	for _, d := range spec.DataNodes {
 		for _, l := range d.Locations {
 			if l.Medium == consts.DefaultMedium {
 				continue
@@ -275,7 +330,7 @@ func (m *Master) createInitScript() string {
 	return strings.Join(script, "\n")
 }
 
-func (m *Master) createExitReadOnlyScript() string {
+func createExitReadOnlyScript() string {
 	script := []string{
 		initJobWithNativeDriverPrologue(),
 		"export YT_LOG_LEVEL=DEBUG",
@@ -299,6 +354,10 @@ func (m *Master) doSync(ctx context.Context, dry bool) (ComponentStatus, error)
 			st, err := m.exitReadOnly(ctx, dry)
 			return *st, err
 		}
+		if m.ytsaurus.GetUpdateState() == ytv1.UpdateStateWaitingForMasterSnapshotsExport {
+			st, err := m.exportSnapshots(ctx, dry)
+			return *st, err
+		}
 		if status, err := handleUpdatingClusterState(ctx, m.ytsaurus, m, &m.localComponent, m.server, dry); status != nil {
 			return *status, err
 		}
@@ -370,7 +429,7 @@ func (m *Master) exitReadOnly(ctx context.Context, dry bool) (*ComponentStatus,
 
 	if !m.exitReadOnlyJob.IsCompleted() {
 		if !dry {
-			m.exitReadOnlyJob.SetInitScript(m.createExitReadOnlyScript())
+			m.exitReadOnlyJob.SetInitScript(createExitReadOnlyScript())
 		}
 		status, err := m.exitReadOnlyJob.Sync(ctx, dry)
 		return &status, err
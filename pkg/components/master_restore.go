@@ -0,0 +1,372 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"go.ytsaurus.tech/yt/go/yt"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// MasterRestore replaces the state of primary and secondary masters with snapshots from the backup.
+// All master cells are stopped, restored and started together, so they are consistent with each other.
+// Ytsaurus is not managed by the operator while masters are being restored.
+type MasterRestore struct {
+	labeller      *labeller.Labeller
+	masterRestore *apiproxy.MasterRestore
+	cfgen         *ytconfig.Generator
+	ytsaurus      *ytv1.Ytsaurus
+
+	cells        []*masterRestoreCell
+	clientSecret *resources.StringSecret
+
+	exitReadOnlyJob *InitJob
+
+	ytClient yt.Client
+}
+
+// masterRestoreCell is the primary or a secondary master cell being restored.
+type masterRestoreCell struct {
+	spec            *ytv1.MastersSpec
+	statefulSetName string
+	// cellPath is the path where peers of the cell are registered.
+	cellPath    string
+	podNames    []string
+	statefulSet appsv1.StatefulSet
+	restoreJobs []*InitJob
+}
+
+func NewMasterRestore(
+	cfgen *ytconfig.Generator,
+	masterRestore *apiproxy.MasterRestore,
+	ytsaurus *ytv1.Ytsaurus) *MasterRestore {
+	resource := masterRestore.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       masterRestore.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-master-restore-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("MasterRestore-%s", resource.Name),
+		Annotations:    ytsaurus.Spec.ExtraPodAnnotations,
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	image := ytsaurus.Spec.CoreImage
+	if resource.Spec.Image != nil {
+		image = *resource.Spec.Image
+	}
+
+	newRestoreJob := func(name string) *InitJob {
+		return NewInitJob(
+			&l,
+			masterRestore.APIProxy(),
+			masterRestore,
+			ytsaurus.Spec.ImagePullSecrets,
+			name,
+			consts.ClientConfigFileName,
+			image,
+			cfgen.GetNativeClientConfig,
+		)
+	}
+
+	primaryCell := &masterRestoreCell{
+		spec:            &ytsaurus.Spec.PrimaryMasters,
+		statefulSetName: cfgen.GetMastersStatefulSetName(),
+		cellPath:        "//sys/primary_masters",
+		podNames:        cfgen.GetMasterPodNames(),
+	}
+	for index := range primaryCell.podNames {
+		primaryCell.restoreJobs = append(primaryCell.restoreJobs, newRestoreJob(fmt.Sprintf("restore-%d", index)))
+	}
+	cells := []*masterRestoreCell{primaryCell}
+
+	// Snapshots of secondary cells are exported along with the primary ones, so they are restored together.
+	for i := range ytsaurus.Spec.SecondaryMasters {
+		spec := &ytsaurus.Spec.SecondaryMasters[i]
+		cell := &masterRestoreCell{
+			spec:            spec,
+			statefulSetName: cfgen.GetSecondaryMastersStatefulSetName(spec.CellTag),
+			cellPath:        fmt.Sprintf("//sys/secondary_masters/%d", spec.CellTag),
+			podNames:        cfgen.GetSecondaryMasterPodNames(spec),
+		}
+		for index := range cell.podNames {
+			cell.restoreJobs = append(cell.restoreJobs, newRestoreJob(fmt.Sprintf("restore-%d-%d", spec.CellTag, index)))
+		}
+		cells = append(cells, cell)
+	}
+
+	return &MasterRestore{
+		labeller:      &l,
+		masterRestore: masterRestore,
+		cfgen:         cfgen,
+		ytsaurus:      ytsaurus,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			masterRestore.APIProxy()),
+		cells: cells,
+		exitReadOnlyJob: NewInitJob(
+			&l,
+			masterRestore.APIProxy(),
+			masterRestore,
+			ytsaurus.Spec.ImagePullSecrets,
+			"exit-read-only",
+			consts.ClientConfigFileName,
+			ytsaurus.Spec.CoreImage,
+			cfgen.GetNativeClientConfig,
+		),
+	}
+}
+
+func (r *MasterRestore) Fetch(ctx context.Context) error {
+	for _, cell := range r.cells {
+		if err := r.masterRestore.APIProxy().FetchObject(ctx, cell.statefulSetName, &cell.statefulSet); err != nil {
+			return err
+		}
+		for _, job := range cell.restoreJobs {
+			if err := job.Fetch(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resources.Fetch(ctx,
+		r.clientSecret,
+		r.exitReadOnlyJob,
+	)
+}
+
+func (r *MasterRestore) createRestoreScript(masters *ytv1.MastersSpec, podName string) string {
+	source := r.masterRestore.GetResource().Spec.Source
+	snapshotsPath := ytv1.FindFirstLocation(masters.Locations, ytv1.LocationTypeMasterSnapshots).Path
+	changelogsPath := ytv1.FindFirstLocation(masters.Locations, ytv1.LocationTypeMasterChangelogs).Path
+
+	script := []string{
+		initJobPrologue,
+		// Newer snapshots and changelogs would be applied on top of the restored snapshot.
+		fmt.Sprintf("rm -rf %s/* %s/*", changelogsPath, snapshotsPath),
+	}
+
+	if source.S3 != nil {
+		sourcePath := path.Join("backup", source.S3.Bucket, source.Path, podName, "snapshots")
+		script = append(script,
+			"set +x",
+			`mc alias set backup "$S3_ENDPOINT" "$S3_ACCESS_KEY_ID" "$S3_SECRET_ACCESS_KEY"`,
+			"set -x",
+			fmt.Sprintf(`snapshot="$(mc ls %s/ | awk '{print $NF}' | grep '\.snapshot$' | sort | tail -n 1)"`, sourcePath),
+			fmt.Sprintf(`mc cp %s/"$snapshot" %s/`, sourcePath, snapshotsPath),
+		)
+	} else {
+		sourcePath := path.Join(consts.SnapshotsExportMountPoint, source.Path, podName, "snapshots")
+		script = append(script,
+			fmt.Sprintf(`snapshot="$(ls -1 %s/*.snapshot | sort | tail -n 1)"`, sourcePath),
+			fmt.Sprintf(`cp "$snapshot" %s/`, snapshotsPath),
+		)
+	}
+
+	return strings.Join(script, "\n")
+}
+
+// setMastersReplicas sets replicas of statefulsets of all master cells,
+// it returns true once statefulsets of all cells have the desired number of ready replicas.
+func (r *MasterRestore) setMastersReplicas(ctx context.Context, start bool) (bool, error) {
+	done := true
+	for _, cell := range r.cells {
+		replicas := int32(0)
+		if start {
+			replicas = cell.spec.InstanceCount
+		}
+		if ptr.Deref(cell.statefulSet.Spec.Replicas, 1) != replicas {
+			cell.statefulSet.Spec.Replicas = ptr.To(replicas)
+			if err := r.masterRestore.APIProxy().Client().Update(ctx, &cell.statefulSet); err != nil {
+				return false, err
+			}
+		}
+		if start {
+			done = done && cell.statefulSet.Status.ReadyReplicas == replicas
+		} else {
+			done = done && cell.statefulSet.Status.Replicas == 0
+		}
+	}
+	return done, nil
+}
+
+// pauseYtsaurusManagement prevents the operator from recreating master pods while snapshots are restored.
+func (r *MasterRestore) pauseYtsaurusManagement(ctx context.Context) error {
+	if !r.ytsaurus.Spec.IsManaged {
+		return nil
+	}
+
+	// Condition is saved first, so management is resumed even if the restore is interrupted here.
+	r.masterRestore.SetStatusCondition(metav1.Condition{
+		Type:    consts.ConditionYtsaurusManagementPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  "MasterRestore",
+		Message: "Ytsaurus is not managed by the operator during master restore",
+	})
+	if err := r.masterRestore.APIProxy().UpdateStatus(ctx); err != nil {
+		return err
+	}
+
+	r.ytsaurus.Spec.IsManaged = false
+	return r.masterRestore.APIProxy().Client().Update(ctx, r.ytsaurus)
+}
+
+func (r *MasterRestore) resumeYtsaurusManagement(ctx context.Context) error {
+	if !r.masterRestore.IsStatusConditionTrue(consts.ConditionYtsaurusManagementPaused) {
+		return nil
+	}
+
+	if !r.ytsaurus.Spec.IsManaged {
+		r.ytsaurus.Spec.IsManaged = true
+		if err := r.masterRestore.APIProxy().Client().Update(ctx, r.ytsaurus); err != nil {
+			return err
+		}
+	}
+	r.masterRestore.SetStatusCondition(metav1.Condition{
+		Type:    consts.ConditionYtsaurusManagementPaused,
+		Status:  metav1.ConditionFalse,
+		Reason:  "MasterRestore",
+		Message: "Ytsaurus is managed by the operator again",
+	})
+	return nil
+}
+
+func (r *MasterRestore) restoreSnapshots(ctx context.Context) error {
+	source := r.masterRestore.GetResource().Spec.Source
+
+	for _, cell := range r.cells {
+		for index, job := range cell.restoreJobs {
+			if job.IsCompleted() {
+				continue
+			}
+			if job.initJob.Failed() {
+				r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateFailed,
+					fmt.Sprintf("Restore job %s failed, Ytsaurus is left unmanaged", job.initJob.Name()))
+				return nil
+			}
+			if !resources.Exists(job.initJob) {
+				podName := cell.podNames[index]
+				job.SetInitScript(r.createRestoreScript(cell.spec, podName))
+				podSpec := &job.Build().Spec.Template.Spec
+				if err := addMasterLocationsToPodSpec(podSpec, cell.spec, podName, false); err != nil {
+					return err
+				}
+				addSnapshotsStorageToPodSpec(podSpec, source.PVC, source.S3)
+				podSpec.Tolerations = cell.spec.Tolerations
+				podSpec.NodeSelector = cell.spec.NodeSelector
+			}
+			_, err := job.Sync(ctx, false)
+			return err
+		}
+	}
+
+	r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateStartingMasters, "Waiting for master pods to be ready")
+	return nil
+}
+
+func (r *MasterRestore) waitForLeader(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if r.ytClient == nil {
+		token, _ := r.clientSecret.GetValue(consts.TokenSecretKey)
+		ytClient, err := newYtClient(r.cfgen, token)
+		if err != nil {
+			return err
+		}
+		r.ytClient = ytClient
+	}
+
+	readOnly := false
+	for _, cell := range r.cells {
+		ok, msg, cellReadOnly, err := checkMasterCellHydra(ctx, r.ytClient, cell.cellPath)
+		if err != nil {
+			// Masters and proxies may be unavailable for some time after restart.
+			logger.Info("failed to get masters hydra state", "cell", cell.cellPath, "error", err)
+			r.masterRestore.GetResource().Status.Message = "Waiting for masters to be available"
+			return nil
+		}
+		if !ok {
+			r.masterRestore.GetResource().Status.Message = fmt.Sprintf("%s: %s", cell.cellPath, msg)
+			return nil
+		}
+		readOnly = readOnly || cellReadOnly
+	}
+
+	if readOnly {
+		r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateExitingReadOnly, "Waiting for masters to exit read-only mode")
+		return nil
+	}
+	return r.complete(ctx)
+}
+
+func (r *MasterRestore) exitReadOnly(ctx context.Context) error {
+	if !r.exitReadOnlyJob.IsCompleted() {
+		if r.exitReadOnlyJob.initJob.Failed() {
+			r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateFailed,
+				fmt.Sprintf("Job %s failed, Ytsaurus is left unmanaged", r.exitReadOnlyJob.initJob.Name()))
+			return nil
+		}
+		r.exitReadOnlyJob.SetInitScript(createExitReadOnlyScript())
+		_, err := r.exitReadOnlyJob.Sync(ctx, false)
+		return err
+	}
+	return r.complete(ctx)
+}
+
+func (r *MasterRestore) complete(ctx context.Context) error {
+	if err := r.resumeYtsaurusManagement(ctx); err != nil {
+		return err
+	}
+	r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateCompleted, "Masters were restored")
+	return nil
+}
+
+func (r *MasterRestore) Sync(ctx context.Context) error {
+	switch r.masterRestore.GetState() {
+	case "", ytv1.MasterRestoreStatePending:
+		if err := r.pauseYtsaurusManagement(ctx); err != nil {
+			return err
+		}
+		r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateStoppingMasters, "Waiting for master pods removal")
+
+	case ytv1.MasterRestoreStateStoppingMasters:
+		stopped, err := r.setMastersReplicas(ctx, false)
+		if err != nil || !stopped {
+			return err
+		}
+		r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateRestoringSnapshots, "Waiting for snapshots to be restored")
+
+	case ytv1.MasterRestoreStateRestoringSnapshots:
+		return r.restoreSnapshots(ctx)
+
+	case ytv1.MasterRestoreStateStartingMasters:
+		started, err := r.setMastersReplicas(ctx, true)
+		if err != nil || !started {
+			return err
+		}
+		r.masterRestore.SetState(ctx, ytv1.MasterRestoreStateWaitingForLeader, "Waiting for masters to elect a leader")
+
+	case ytv1.MasterRestoreStateWaitingForLeader:
+		return r.waitForLeader(ctx)
+
+	case ytv1.MasterRestoreStateExitingReadOnly:
+		return r.exitReadOnly(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,299 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Master restore test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var masterRestoreSpec *ytv1.MasterRestore
+	var mastersStatefulSet *appsv1.StatefulSet
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+				IsManaged: true,
+				PrimaryMasters: ytv1.MastersSpec{
+					InstanceSpec: ytv1.InstanceSpec{
+						InstanceCount: 1,
+						Locations: []ytv1.LocationSpec{
+							{
+								LocationType: ytv1.LocationTypeMasterChangelogs,
+								Path:         "/yt/master-data/master-changelogs",
+							},
+							{
+								LocationType: ytv1.LocationTypeMasterSnapshots,
+								Path:         "/yt/master-data/master-snapshots",
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "master-data",
+								MountPath: "/yt/master-data",
+							},
+						},
+						VolumeClaimTemplates: []ytv1.EmbeddedPersistentVolumeClaim{
+							{
+								EmbeddedObjectMetadata: ytv1.EmbeddedObjectMetadata{
+									Name: "master-data",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		masterRestoreSpec = &ytv1.MasterRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "restore",
+				Namespace: "default",
+			},
+			Spec: ytv1.MasterRestoreSpec{
+				Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				Source: ytv1.MasterRestoreSourceSpec{
+					PVC: &ytv1.MasterSnapshotsExportPVCSpec{
+						ClaimName: "master-backups",
+					},
+					Path: "ytsaurus/20240102T030405Z",
+				},
+			},
+		}
+
+		mastersStatefulSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ms",
+				Namespace: "default",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(1)),
+			},
+			Status: appsv1.StatefulSetStatus{
+				Replicas:      1,
+				ReadyReplicas: 1,
+			},
+		}
+	})
+
+	It("MasterRestore Sync; restore from pvc", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, masterRestoreSpec, mastersStatefulSet).
+			WithStatusSubresource(masterRestoreSpec, mastersStatefulSet).
+			Build()
+
+		ytsaurus := &ytv1.Ytsaurus{}
+		masterRestore := &ytv1.MasterRestore{}
+		syncMasterRestore := func() {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(masterRestoreSpec), masterRestore)).Should(Succeed())
+			proxy := apiproxy.NewMasterRestore(masterRestore, k8sClient, record.NewFakeRecorder(100), scheme)
+			component := NewMasterRestore(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus)
+			component.ytClient = mockYtClient
+			Expect(component.Fetch(ctx)).Should(Succeed())
+			Expect(component.Sync(ctx)).Should(Succeed())
+			Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+		}
+		updateStatefulSetStatus := func(replicas int32) {
+			statefulSet := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(mastersStatefulSet), statefulSet)).Should(Succeed())
+			statefulSet.Status.Replicas = replicas
+			statefulSet.Status.ReadyReplicas = replicas
+			Expect(k8sClient.Status().Update(ctx, statefulSet)).Should(Succeed())
+		}
+
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateStoppingMasters))
+		Expect(ytsaurus.Spec.IsManaged).Should(BeFalse())
+
+		syncMasterRestore()
+		statefulSet := &appsv1.StatefulSet{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(mastersStatefulSet), statefulSet)).Should(Succeed())
+		Expect(*statefulSet.Spec.Replicas).Should(Equal(int32(0)))
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateStoppingMasters))
+
+		updateStatefulSetStatus(0)
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateRestoringSnapshots))
+
+		syncMasterRestore()
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "ytsaurus-master-restore-restore-init-job-restore-0"}, job)).Should(Succeed())
+		var claimNames []string
+		for _, volume := range job.Spec.Template.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+		Expect(claimNames).Should(ConsistOf("master-data-ms-0", "master-backups"))
+
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "restore-0-ytsaurus-master-restore-restore-init-job-config"}, configMap)).Should(Succeed())
+		script := configMap.Data[consts.InitClusterScriptFileName]
+		Expect(script).Should(ContainSubstring("rm -rf /yt/master-data/master-changelogs/* /yt/master-data/master-snapshots/*"))
+		Expect(script).Should(ContainSubstring("ls -1 /snapshots_export/ytsaurus/20240102T030405Z/ms-0/snapshots/*.snapshot"))
+
+		job.Status.Succeeded = 1
+		Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+		syncMasterRestore()
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateStartingMasters))
+
+		syncMasterRestore()
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(mastersStatefulSet), statefulSet)).Should(Succeed())
+		Expect(*statefulSet.Spec.Replicas).Should(Equal(int32(1)))
+
+		updateStatefulSetStatus(1)
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateWaitingForLeader))
+
+		masterAddress := "ms-0.masters.default.svc.cluster_domain:9010"
+		mockYtClient.EXPECT().
+			ListNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/primary_masters")), gomock.Any(), gomock.Nil()).
+			SetArg(2, []string{masterAddress}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/primary_masters/"+masterAddress+"/orchid/monitoring/hydra")), gomock.Any(), gomock.Nil()).
+			SetArg(2, MasterHydra{Active: true, State: MasterStateLeading}).
+			Return(nil)
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateCompleted))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		Expect(ytsaurus.Spec.IsManaged).Should(BeTrue())
+	})
+
+	It("MasterRestore Sync; secondary cells are restored along with the primary one", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.SecondaryMasters = []ytv1.MastersSpec{
+			{
+				InstanceSpec:         *ytsaurusSpec.Spec.PrimaryMasters.InstanceSpec.DeepCopy(),
+				MasterConnectionSpec: ytv1.MasterConnectionSpec{CellTag: 2},
+			},
+		}
+		secondaryStatefulSet := mastersStatefulSet.DeepCopy()
+		secondaryStatefulSet.Name = "sms-2"
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, masterRestoreSpec, mastersStatefulSet, secondaryStatefulSet).
+			WithStatusSubresource(masterRestoreSpec, mastersStatefulSet, secondaryStatefulSet).
+			Build()
+
+		ytsaurus := &ytv1.Ytsaurus{}
+		masterRestore := &ytv1.MasterRestore{}
+		syncMasterRestore := func() {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(masterRestoreSpec), masterRestore)).Should(Succeed())
+			proxy := apiproxy.NewMasterRestore(masterRestore, k8sClient, record.NewFakeRecorder(100), scheme)
+			component := NewMasterRestore(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus)
+			component.ytClient = mockYtClient
+			Expect(component.Fetch(ctx)).Should(Succeed())
+			Expect(component.Sync(ctx)).Should(Succeed())
+			Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+		}
+		getReplicas := func(name string) int32 {
+			statefulSet := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, statefulSet)).Should(Succeed())
+			return *statefulSet.Spec.Replicas
+		}
+		updateStatefulSetStatus := func(name string, replicas int32) {
+			statefulSet := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, statefulSet)).Should(Succeed())
+			statefulSet.Status.Replicas = replicas
+			statefulSet.Status.ReadyReplicas = replicas
+			Expect(k8sClient.Status().Update(ctx, statefulSet)).Should(Succeed())
+		}
+
+		syncMasterRestore()
+		syncMasterRestore()
+		Expect(getReplicas("ms")).Should(Equal(int32(0)))
+		Expect(getReplicas("sms-2")).Should(Equal(int32(0)))
+
+		By("Snapshots are restored once masters of all cells are stopped")
+		updateStatefulSetStatus("ms", 0)
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateStoppingMasters))
+		updateStatefulSetStatus("sms-2", 0)
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateRestoringSnapshots))
+
+		for _, name := range []string{"restore-0", "restore-2-0"} {
+			// Jobs are run one by one, the next one is created once the previous one is completed.
+			syncMasterRestore()
+			syncMasterRestore()
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "ytsaurus-master-restore-restore-init-job-" + name}, job)).Should(Succeed())
+			job.Status.Succeeded = 1
+			Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+		}
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "restore-2-0-ytsaurus-master-restore-restore-init-job-config"}, configMap)).Should(Succeed())
+		Expect(configMap.Data[consts.InitClusterScriptFileName]).Should(ContainSubstring("/snapshots_export/ytsaurus/20240102T030405Z/sms-2-0/snapshots/*.snapshot"))
+
+		syncMasterRestore()
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateStartingMasters))
+		syncMasterRestore()
+		Expect(getReplicas("ms")).Should(Equal(int32(1)))
+		Expect(getReplicas("sms-2")).Should(Equal(int32(1)))
+		updateStatefulSetStatus("ms", 1)
+		updateStatefulSetStatus("sms-2", 1)
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateWaitingForLeader))
+
+		By("Leaders of all cells are awaited")
+		for cellPath, masterAddress := range map[string]string{
+			"//sys/primary_masters":     "ms-0.masters.default.svc.cluster_domain:9010",
+			"//sys/secondary_masters/2": "sms-2-0.secondary-masters-2.default.svc.cluster_domain:9010",
+		} {
+			mockYtClient.EXPECT().
+				ListNode(gomock.Any(), gomock.Eq(ypath.Path(cellPath)), gomock.Any(), gomock.Nil()).
+				SetArg(2, []string{masterAddress}).
+				Return(nil)
+			mockYtClient.EXPECT().
+				GetNode(gomock.Any(), gomock.Eq(ypath.Path(cellPath+"/"+masterAddress+"/orchid/monitoring/hydra")), gomock.Any(), gomock.Nil()).
+				SetArg(2, MasterHydra{Active: true, State: MasterStateLeading}).
+				Return(nil)
+		}
+		syncMasterRestore()
+		Expect(masterRestore.Status.State).Should(Equal(ytv1.MasterRestoreStateCompleted))
+	})
+})
@@ -0,0 +1,292 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+)
+
+const snapshotsExportBackupIDFormat = "20060102T150405Z"
+
+// snapshotsExportJob exports snapshots of a single master pod of the primary or a secondary cell.
+type snapshotsExportJob struct {
+	*InitJob
+	spec    *ytv1.MastersSpec
+	podName string
+}
+
+// getSnapshotsExportBackupID returns the name of the backup directory,
+// it is the same for all masters and is derived from the time when snapshots were built.
+func (m *Master) getSnapshotsExportBackupID() string {
+	condition := m.ytsaurus.GetUpdateStatusCondition(consts.ConditionSnaphotsSaved)
+	if condition == nil {
+		return ""
+	}
+	return condition.LastTransitionTime.UTC().Format(snapshotsExportBackupIDFormat)
+}
+
+func (m *Master) createSnapshotsExportScript(spec *ytv1.MastersSpec, podName string) string {
+	resource := m.ytsaurus.GetResource()
+	export := resource.Spec.PrimaryMasters.SnapshotsExport
+	snapshotsPath := ytv1.FindFirstLocation(spec.Locations, ytv1.LocationTypeMasterSnapshots).Path
+	changelogsPath := ytv1.FindFirstLocation(spec.Locations, ytv1.LocationTypeMasterChangelogs).Path
+
+	backupsPath := export.Path
+	if backupsPath == "" {
+		backupsPath = resource.Name
+	}
+	maxBackupCount := export.MaxBackupCount
+	if maxBackupCount < 1 {
+		maxBackupCount = 1
+	}
+
+	script := []string{
+		initJobPrologue,
+		fmt.Sprintf(`snapshot="$(ls -1 %s/*.snapshot | sort | tail -n 1)"`, snapshotsPath),
+	}
+
+	if export.S3 != nil {
+		root := path.Join("backup", export.S3.Bucket, backupsPath)
+		target := path.Join(root, m.getSnapshotsExportBackupID(), podName)
+		script = append(script,
+			"set +x",
+			`mc alias set backup "$S3_ENDPOINT" "$S3_ACCESS_KEY_ID" "$S3_SECRET_ACCESS_KEY"`,
+			"set -x",
+			fmt.Sprintf(`mc cp "$snapshot" %s/snapshots/`, target),
+			fmt.Sprintf("mc cp --recursive %s/ %s/changelogs/", changelogsPath, target),
+			// Backups are named after their creation time, so the oldest ones go first.
+			fmt.Sprintf("mc ls %s/ | awk '{print $NF}' | sort | head -n -%d | xargs -r -I{} mc rm --recursive --force %s/{}",
+				root, maxBackupCount, root),
+		)
+	} else {
+		root := path.Join(consts.SnapshotsExportMountPoint, backupsPath)
+		target := path.Join(root, m.getSnapshotsExportBackupID(), podName)
+		script = append(script,
+			fmt.Sprintf("mkdir -p %s/snapshots %s/changelogs", target, target),
+			fmt.Sprintf(`cp "$snapshot" %s/snapshots/`, target),
+			fmt.Sprintf("cp -r %s/. %s/changelogs/", changelogsPath, target),
+			fmt.Sprintf("ls -1 %s | sort | head -n -%d | xargs -r -I{} rm -rf %s/{}", root, maxBackupCount, root),
+		)
+	}
+
+	return strings.Join(script, "\n")
+}
+
+// isPathUnderMount reports whether the path is the mount path itself or lies inside of it.
+func isPathUnderMount(locationPath, mountPath string) bool {
+	locationPath = path.Clean(locationPath)
+	mountPath = path.Clean(mountPath)
+	return locationPath == mountPath || strings.HasPrefix(locationPath, strings.TrimSuffix(mountPath, "/")+"/")
+}
+
+// getLocationVolume returns the volume of the master pod which holds the location.
+// The deepest mount containing the location is used.
+func getLocationVolume(location *ytv1.LocationSpec, spec *ytv1.InstanceSpec, podName string, readOnly bool) (*corev1.Volume, *corev1.VolumeMount, error) {
+	var locationMount *corev1.VolumeMount
+	for i := range spec.VolumeMounts {
+		mount := &spec.VolumeMounts[i]
+		if !isPathUnderMount(location.Path, mount.MountPath) {
+			continue
+		}
+		if locationMount == nil || len(path.Clean(mount.MountPath)) > len(path.Clean(locationMount.MountPath)) {
+			locationMount = mount
+		}
+	}
+	if locationMount == nil {
+		return nil, nil, fmt.Errorf("no volume found for location %s", location.Path)
+	}
+
+	mount := *locationMount
+	mount.ReadOnly = readOnly
+	for _, claim := range spec.VolumeClaimTemplates {
+		if claim.Name == mount.Name {
+			return &corev1.Volume{
+				Name: mount.Name,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: fmt.Sprintf("%s-%s", claim.Name, podName),
+						ReadOnly:  readOnly,
+					},
+				},
+			}, &mount, nil
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.Name == mount.Name {
+			return volume.DeepCopy(), &mount, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no volume %s found for location %s", mount.Name, location.Path)
+}
+
+// addMasterLocationsToPodSpec mounts snapshots and changelogs locations of the master pod.
+func addMasterLocationsToPodSpec(podSpec *corev1.PodSpec, spec *ytv1.MastersSpec, podName string, readOnly bool) error {
+	container := &podSpec.Containers[0]
+	for _, locationType := range []ytv1.LocationType{ytv1.LocationTypeMasterSnapshots, ytv1.LocationTypeMasterChangelogs} {
+		location := ytv1.FindFirstLocation(spec.Locations, locationType)
+		volume, mount, err := getLocationVolume(location, &spec.InstanceSpec, podName, readOnly)
+		if err != nil {
+			return err
+		}
+		if !hasVolume(podSpec.Volumes, volume.Name) {
+			podSpec.Volumes = append(podSpec.Volumes, *volume)
+			container.VolumeMounts = append(container.VolumeMounts, *mount)
+		}
+	}
+	return nil
+}
+
+// addSnapshotsStorageToPodSpec makes the storage of exported snapshots available to the job:
+// persistent volume claim is mounted, S3 endpoint and credentials are passed via environment.
+func addSnapshotsStorageToPodSpec(podSpec *corev1.PodSpec, pvc *ytv1.MasterSnapshotsExportPVCSpec, s3 *ytv1.MasterSnapshotsExportS3Spec) {
+	container := &podSpec.Containers[0]
+
+	if pvc != nil {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: consts.SnapshotsExportVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.ClaimName,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      consts.SnapshotsExportVolumeName,
+			MountPath: consts.SnapshotsExportMountPoint,
+		})
+	}
+
+	if s3 != nil {
+		secretKeyRef := func(key string) *corev1.EnvVarSource {
+			return &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: s3.CredentialsSecret,
+					Key:                  key,
+				},
+			}
+		}
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "S3_ENDPOINT", Value: s3.Endpoint},
+			corev1.EnvVar{Name: "S3_ACCESS_KEY_ID", ValueFrom: secretKeyRef(consts.S3AccessKeyIDSecret)},
+			corev1.EnvVar{Name: "S3_SECRET_ACCESS_KEY", ValueFrom: secretKeyRef(consts.S3SecretAccessKeySecret)},
+		)
+	}
+}
+
+// patchSnapshotsExportJob mounts master locations and backup target into the export job
+// and places it on the node of the master pod, so volumes with ReadWriteOnce access mode can be shared.
+func (m *Master) patchSnapshotsExportJob(job *snapshotsExportJob, nodeName string) error {
+	export := m.ytsaurus.GetResource().Spec.PrimaryMasters.SnapshotsExport
+	podSpec := &job.Build().Spec.Template.Spec
+
+	if err := addMasterLocationsToPodSpec(podSpec, job.spec, job.podName, true); err != nil {
+		return err
+	}
+	addSnapshotsStorageToPodSpec(podSpec, export.PVC, export.S3)
+
+	podSpec.Tolerations = job.spec.Tolerations
+	podSpec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "metadata.name",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{nodeName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// exportSnapshots runs a job per master which copies the latest snapshot and changelogs to the backup target.
+func (m *Master) exportSnapshots(ctx context.Context, dry bool) (*ComponentStatus, error) {
+	if m.ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExported) {
+		return ptr.To(SimpleStatus(SyncStatusUpdating)), nil
+	}
+
+	if !m.ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExportPrepared) {
+		for _, job := range m.snapshotsExportJobs {
+			if !job.isRestartPrepared() {
+				if err := job.prepareRestart(ctx, dry); err != nil {
+					return ptr.To(SimpleStatus(SyncStatusUpdating)), err
+				}
+			}
+		}
+
+		if !dry {
+			m.setMasterSnapshotsExportPrepared(ctx, metav1.ConditionTrue)
+		}
+		return ptr.To(SimpleStatus(SyncStatusUpdating)), nil
+	}
+
+	for _, job := range m.snapshotsExportJobs {
+		if job.IsCompleted() {
+			continue
+		}
+		if !dry && !resources.Exists(job.initJob) {
+			podName := job.podName
+			pod := &corev1.Pod{}
+			if err := m.ytsaurus.APIProxy().FetchObject(ctx, podName, pod); err != nil {
+				return ptr.To(SimpleStatus(SyncStatusUpdating)), err
+			}
+			if pod.ResourceVersion == "" {
+				// Masters are being resized and the peer has not been created yet, so there is nothing to export.
+				continue
+			}
+			if pod.Spec.NodeName == "" {
+				return ptr.To(WaitingStatus(SyncStatusUpdating, fmt.Sprintf("pod %s scheduling", podName))), nil
+			}
+			job.SetInitScript(m.createSnapshotsExportScript(job.spec, podName))
+			if err := m.patchSnapshotsExportJob(job, pod.Spec.NodeName); err != nil {
+				return ptr.To(SimpleStatus(SyncStatusUpdating)), err
+			}
+		}
+		status, err := job.Sync(ctx, dry)
+		return &status, err
+	}
+
+	if !dry {
+		m.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+			Type:    consts.ConditionMasterSnapshotsExported,
+			Status:  metav1.ConditionTrue,
+			Reason:  "MasterSnapshotsExported",
+			Message: "Master snapshots were exported",
+		})
+		m.setMasterSnapshotsExportPrepared(ctx, metav1.ConditionFalse)
+	}
+	return ptr.To(SimpleStatus(SyncStatusUpdating)), nil
+}
+
+func (m *Master) setMasterSnapshotsExportPrepared(ctx context.Context, status metav1.ConditionStatus) {
+	m.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+		Type:    consts.ConditionMasterSnapshotsExportPrepared,
+		Status:  status,
+		Reason:  "MasterSnapshotsExportPrepared",
+		Message: "Master snapshots export jobs are prepared",
+	})
+}
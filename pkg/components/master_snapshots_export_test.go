@@ -0,0 +1,43 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+)
+
+func TestGetLocationVolume(t *testing.T) {
+	spec := &ytv1.InstanceSpec{
+		Volumes: []corev1.Volume{
+			{Name: "master-data-old"},
+			{Name: "master-data"},
+			{Name: "master-data-changelogs"},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "master-data-old", MountPath: "/yt/master-data-old"},
+			{Name: "master-data", MountPath: "/yt/master-data"},
+			{Name: "master-data-changelogs", MountPath: "/yt/master-data/changelogs"},
+		},
+	}
+
+	for path, volumeName := range map[string]string{
+		"/yt/master-data":                     "master-data",
+		"/yt/master-data/snapshots":           "master-data",
+		"/yt/master-data/changelogs":          "master-data-changelogs",
+		"/yt/master-data/changelogs/":         "master-data-changelogs",
+		"/yt/master-data/changelogs/archived": "master-data-changelogs",
+		"/yt/master-data-old/snapshots":       "master-data-old",
+	} {
+		volume, mount, err := getLocationVolume(&ytv1.LocationSpec{Path: path}, spec, "ms-0", true)
+		require.NoError(t, err, path)
+		require.Equal(t, volumeName, volume.Name, path)
+		require.Equal(t, volumeName, mount.Name, path)
+		require.True(t, mount.ReadOnly, path)
+	}
+
+	_, _, err := getLocationVolume(&ytv1.LocationSpec{Path: "/yt/master"}, spec, "ms-0", true)
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package components
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Master test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+				PrimaryMasters: ytv1.MastersSpec{
+					InstanceSpec: ytv1.InstanceSpec{
+						InstanceCount: 1,
+						Locations: []ytv1.LocationSpec{
+							{
+								LocationType: ytv1.LocationTypeMasterChangelogs,
+								Path:         "/yt/master-data/master-changelogs",
+							},
+							{
+								LocationType: ytv1.LocationTypeMasterSnapshots,
+								Path:         "/yt/master-data/master-snapshots",
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "master-data",
+								MountPath: "/yt/master-data",
+							},
+						},
+						VolumeClaimTemplates: []ytv1.EmbeddedPersistentVolumeClaim{
+							{
+								EmbeddedObjectMetadata: ytv1.EmbeddedObjectMetadata{
+									Name: "master-data",
+								},
+							},
+						},
+					},
+					SnapshotsExport: &ytv1.MasterSnapshotsExportSpec{
+						PVC: &ytv1.MasterSnapshotsExportPVCSpec{
+							ClaimName: "master-backups",
+						},
+						MaxBackupCount: 2,
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateUpdating,
+				UpdateStatus: ytv1.UpdateStatus{
+					Flow:  ytv1.UpdateFlowMaster,
+					State: ytv1.UpdateStateWaitingForMasterSnapshotsExport,
+					Conditions: []metav1.Condition{
+						{
+							Type:               consts.ConditionSnaphotsSaved,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("Master Sync; snapshots export", func() {
+		ctx := context.Background()
+		masterPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ms-0",
+				Namespace: "default",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec, masterPod).Build()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		cfgen := ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain")
+		master := NewMaster(cfgen, ytsaurus)
+
+		syncMaster := func() {
+			Expect(master.Fetch(ctx)).Should(Succeed())
+			Expect(master.Sync(ctx)).Should(Succeed())
+		}
+
+		syncMaster()
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExportPrepared)).Should(BeTrue())
+
+		syncMaster()
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "yt-master-init-job-snapshots-export-0"}, job)).Should(Succeed())
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).
+			Should(Equal([]string{"node-1"}))
+		var claimNames []string
+		for _, volume := range podSpec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+		Expect(claimNames).Should(ConsistOf("master-data-ms-0", "master-backups"))
+
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "snapshots-export-0-yt-master-init-job-config"}, configMap)).Should(Succeed())
+		script := configMap.Data[consts.InitClusterScriptFileName]
+		Expect(script).Should(ContainSubstring("cp \"$snapshot\" /snapshots_export/ytsaurus/20240102T030405Z/ms-0/snapshots/"))
+		Expect(script).Should(ContainSubstring("head -n -2"))
+
+		job.Status.Succeeded = 1
+		Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+		syncMaster()
+		syncMaster()
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExported)).Should(BeTrue())
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExportPrepared)).Should(BeFalse())
+	})
+
+	It("Master Sync; snapshots export of secondary masters", func() {
+		ctx := context.Background()
+		secondaryMasters := ytsaurusSpec.Spec.PrimaryMasters.DeepCopy()
+		secondaryMasters.CellTag = 1
+		secondaryMasters.SnapshotsExport = nil
+		ytsaurusSpec.Spec.SecondaryMasters = []ytv1.MastersSpec{*secondaryMasters}
+		newPod := func(name string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node-1",
+				},
+			}
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec, newPod("ms-0"), newPod("sms-1-0")).Build()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		cfgen := ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain")
+		master := NewMaster(cfgen, ytsaurus)
+
+		syncMaster := func() {
+			Expect(master.Fetch(ctx)).Should(Succeed())
+			Expect(master.Sync(ctx)).Should(Succeed())
+		}
+		completeJob := func(name string) {
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, job)).Should(Succeed())
+			job.Status.Succeeded = 1
+			Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+		}
+
+		syncMaster()
+		syncMaster()
+		completeJob("yt-master-init-job-snapshots-export-0")
+		syncMaster()
+		syncMaster()
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "yt-master-init-job-snapshots-export-1-0"}, job)).Should(Succeed())
+		var claimNames []string
+		for _, volume := range job.Spec.Template.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+		Expect(claimNames).Should(ConsistOf("master-data-sms-1-0", "master-backups"))
+
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "snapshots-export-1-0-yt-master-init-job-config"}, configMap)).Should(Succeed())
+		Expect(configMap.Data[consts.InitClusterScriptFileName]).
+			Should(ContainSubstring("cp \"$snapshot\" /snapshots_export/ytsaurus/20240102T030405Z/sms-1-0/snapshots/"))
+
+		completeJob("yt-master-init-job-snapshots-export-1-0")
+		syncMaster()
+		syncMaster()
+		Expect(ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterSnapshotsExported)).Should(BeTrue())
+	})
+})
@@ -2,6 +2,7 @@ package components
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/utils/ptr"
 
@@ -189,6 +190,15 @@ func (m *microserviceImpl) removePods(ctx context.Context) error {
 	return m.Sync(ctx)
 }
 
+// Microservices are updated by removal of all pods.
+func (m *microserviceImpl) isRollingUpdate() bool {
+	return false
+}
+
+func (m *microserviceImpl) rollPods(ctx context.Context, updateStartedAt time.Time) (bool, error) {
+	return m.arePodsReady(ctx), nil
+}
+
 func (m *microserviceImpl) getImage() string {
 	return m.image
 }
@@ -0,0 +1,100 @@
+package components
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+// ComponentPlan describes changes of the component which would be rolled out by the update.
+type ComponentPlan struct {
+	Name string               `json:"name"`
+	Type consts.ComponentType `json:"type"`
+	// ImageChange is set if the image of the component would be changed.
+	ImageChange string `json:"imageChange,omitempty"`
+	// ConfigDiff holds diffs of changed configs by file name.
+	ConfigDiff map[string]string `json:"configDiff,omitempty"`
+}
+
+// changesDescriber is implemented by components and their servers which could describe pending changes.
+type changesDescriber interface {
+	describeChanges(plan *ComponentPlan) error
+}
+
+// PlanComponent describes pending changes of the component, which must be fetched beforehand.
+func PlanComponent(c Component) (ComponentPlan, error) {
+	plan := ComponentPlan{
+		Name: c.GetName(),
+		Type: c.GetType(),
+	}
+	if describer, ok := c.(changesDescriber); ok {
+		if err := describer.describeChanges(&plan); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
+
+func describeImageChange(currentImage, image string) string {
+	if currentImage == image {
+		return ""
+	}
+	return fmt.Sprintf("%s -> %s", currentImage, image)
+}
+
+func (c *localServerComponent) describeChanges(plan *ComponentPlan) error {
+	if describer, ok := c.server.(changesDescriber); ok {
+		return describer.describeChanges(plan)
+	}
+	return nil
+}
+
+func (s *serverImpl) describeChanges(plan *ComponentPlan) error {
+	if !s.exists() {
+		return nil
+	}
+	currentImage := s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template.Spec.Containers[0].Image
+	plan.ImageChange = describeImageChange(currentImage, s.image)
+
+	diff, err := s.configHelper.GetConfigDiff()
+	if err != nil {
+		return err
+	}
+	if len(diff) != 0 {
+		plan.ConfigDiff = diff
+	}
+	return nil
+}
+
+func (m *microserviceImpl) describeChanges(plan *ComponentPlan) error {
+	if !m.exists() {
+		return nil
+	}
+	currentImage := m.deployment.OldObject().(*appsv1.Deployment).Spec.Template.Spec.Containers[0].Image
+	plan.ImageChange = describeImageChange(currentImage, m.image)
+
+	diff, err := m.configHelper.GetConfigDiff()
+	if err != nil {
+		return err
+	}
+	if len(diff) != 0 {
+		plan.ConfigDiff = diff
+	}
+	return nil
+}
+
+func (u *UI) describeChanges(plan *ComponentPlan) error {
+	if describer, ok := u.microservice.(changesDescriber); ok {
+		return describer.describeChanges(plan)
+	}
+	return nil
+}
+
+func (c *StrawberryController) describeChanges(plan *ComponentPlan) error {
+	if describer, ok := c.microservice.(changesDescriber); ok {
+		return describer.describeChanges(plan)
+	}
+	return nil
+}
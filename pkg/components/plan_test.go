@@ -0,0 +1,75 @@
+package components
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Component plan test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:23.2",
+					UseShortNames: true,
+				},
+				Discovery: ytv1.DiscoverySpec{
+					InstanceSpec: ytv1.InstanceSpec{
+						InstanceCount: 1,
+					},
+				},
+			},
+		}
+	})
+
+	It("PlanComponent; image and config changes", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		newDiscovery := func(resource *ytv1.Ytsaurus) *Discovery {
+			ytsaurus := apiproxy.NewYtsaurus(resource, k8sClient, record.NewFakeRecorder(100), scheme)
+			discovery := NewDiscovery(ytconfig.NewGenerator(resource, "cluster_domain"), ytsaurus)
+			Expect(discovery.Fetch(ctx)).Should(Succeed())
+			return discovery
+		}
+
+		Expect(newDiscovery(ytsaurusSpec.DeepCopy()).Sync(ctx)).Should(Succeed())
+
+		plan, err := PlanComponent(newDiscovery(ytsaurusSpec.DeepCopy()))
+		Expect(err).Should(Succeed())
+		Expect(plan).Should(Equal(ComponentPlan{Name: "Discovery", Type: consts.DiscoveryType}))
+
+		resource := ytsaurusSpec.DeepCopy()
+		resource.Spec.CoreImage = "ytsaurus/ytsaurus:24.1"
+		resource.Spec.Discovery.InstanceCount = 3
+		plan, err = PlanComponent(newDiscovery(resource))
+		Expect(err).Should(Succeed())
+		Expect(plan.ImageChange).Should(Equal("ytsaurus/ytsaurus:23.2 -> ytsaurus/ytsaurus:24.1"))
+		Expect(plan.ConfigDiff).Should(HaveKey("ytserver-discovery.yson"))
+		Expect(plan.ConfigDiff["ytserver-discovery.yson"]).Should(ContainSubstring("ds-2.discovery.default.svc.cluster_domain"))
+	})
+})
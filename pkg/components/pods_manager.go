@@ -2,6 +2,7 @@ package components
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -14,6 +15,8 @@ type podsManager interface {
 	arePodsRemoved(ctx context.Context) bool
 	arePodsReady(ctx context.Context) bool
 	podsImageCorrespondsToSpec() bool
+	isRollingUpdate() bool
+	rollPods(ctx context.Context, updateStartedAt time.Time) (bool, error)
 }
 
 func removePods(ctx context.Context, manager podsManager, c *localComponent) error {
@@ -34,6 +37,22 @@ func removePods(ctx context.Context, manager podsManager, c *localComponent) err
 	return nil
 }
 
+func rollPods(ctx context.Context, manager podsManager, c *localComponent) error {
+	started := c.ytsaurus.GetUpdateStatusCondition(c.labeller.GetPodsUpdatingStartedCondition())
+	if started == nil || started.Status != metav1.ConditionTrue {
+		setPodsUpdatingStartedCondition(ctx, c)
+		return nil
+	}
+
+	done, err := manager.rollPods(ctx, started.LastTransitionTime.Time)
+	if err != nil || !done {
+		return err
+	}
+
+	setPodsUpdatedCondition(ctx, c)
+	return nil
+}
+
 func isPodsRemovingStarted(c *localComponent) bool {
 	return c.ytsaurus.IsUpdateStatusConditionTrue(c.labeller.GetPodsRemovingStartedCondition())
 }
@@ -55,3 +74,21 @@ func setPodsRemovedCondition(ctx context.Context, c *localComponent) {
 		Message: "Pods removed",
 	})
 }
+
+func setPodsUpdatingStartedCondition(ctx context.Context, c *localComponent) {
+	c.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+		Type:    c.labeller.GetPodsUpdatingStartedCondition(),
+		Status:  metav1.ConditionTrue,
+		Reason:  "Update",
+		Message: "Pods rolling update was started",
+	})
+}
+
+func setPodsUpdatedCondition(ctx context.Context, c *localComponent) {
+	c.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+		Type:    labeller.GetPodsUpdatedCondition(c.GetName()),
+		Status:  metav1.ConditionTrue,
+		Reason:  "Update",
+		Message: "Pods updated",
+	})
+}
@@ -3,6 +3,7 @@ package components
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"k8s.io/utils/ptr"
@@ -25,11 +26,15 @@ type QueryTracker struct {
 	localServerComponent
 	cfgen *ytconfig.Generator
 
+	stage          string
 	ytsaurusClient internalYtsaurusClient
 	tabletNodes    []Component
 	initCondition  string
 	initQTState    *InitJob
 	secret         *resources.StringSecret
+
+	// removed is set for a stage which was removed from the spec, its resources are deleted.
+	removed bool
 }
 
 func NewQueryTracker(
@@ -47,19 +52,111 @@ func NewQueryTracker(
 		Annotations:    resource.Spec.ExtraPodAnnotations,
 	}
 
-	if resource.Spec.QueryTrackers.InstanceSpec.MonitoringPort == nil {
-		resource.Spec.QueryTrackers.InstanceSpec.MonitoringPort = ptr.To(int32(consts.QueryTrackerMonitoringPort))
+	return newQueryTracker(
+		cfgen,
+		ytsaurus,
+		yc,
+		tabletNodes,
+		&l,
+		consts.DefaultQueryTrackerStage,
+		&resource.Spec.QueryTrackers.InstanceSpec,
+		"queryTrackerInitCompleted",
+		func() ([]byte, error) { return cfgen.GetQueryTrackerConfig(resource.Spec.QueryTrackers) })
+}
+
+// NewQueryTrackerStage creates query tracker of an additional stage,
+// its state is initialized in a separate root and it is not a part of query tracker state update.
+func NewQueryTrackerStage(
+	cfgen *ytconfig.Generator,
+	ytsaurus *apiproxy.Ytsaurus,
+	yc internalYtsaurusClient,
+	tabletNodes []Component,
+	spec *ytv1.QueryTrackerStageSpec,
+) *QueryTracker {
+	return newQueryTrackerStage(cfgen, ytsaurus, yc, tabletNodes, spec, false)
+}
+
+// NewRemovedQueryTrackerStage creates a component for the query tracker stage which was removed from the spec.
+func NewRemovedQueryTrackerStage(
+	cfgen *ytconfig.Generator,
+	ytsaurus *apiproxy.Ytsaurus,
+	yc internalYtsaurusClient,
+	name string,
+) *QueryTracker {
+	return newQueryTrackerStage(cfgen, ytsaurus, yc, nil, &ytv1.QueryTrackerStageSpec{Name: name}, true)
+}
+
+// GetRemovedQueryTrackerStages returns names of query tracker stages which are deployed, but not in the spec anymore.
+func GetRemovedQueryTrackerStages(ytsaurus *apiproxy.Ytsaurus) []string {
+	resource := ytsaurus.GetResource()
+	var names []string
+	for _, stage := range resource.Status.QueryTrackerStages {
+		if resource.Spec.QueryTrackers == nil || !slices.ContainsFunc(resource.Spec.QueryTrackers.Stages, func(spec ytv1.QueryTrackerStageSpec) bool {
+			return spec.Name == stage.Name
+		}) {
+			names = append(names, stage.Name)
+		}
 	}
+	return names
+}
 
-	srv := newServer(
+func newQueryTrackerStage(
+	cfgen *ytconfig.Generator,
+	ytsaurus *apiproxy.Ytsaurus,
+	yc internalYtsaurusClient,
+	tabletNodes []Component,
+	spec *ytv1.QueryTrackerStageSpec,
+	removed bool,
+) *QueryTracker {
+	resource := ytsaurus.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       ytsaurus.APIProxy(),
+		ComponentLabel: fmt.Sprintf("yt-query-tracker-%s", spec.Name),
+		ComponentName:  fmt.Sprintf("%s-%s", consts.QueryTrackerType, spec.Name),
+		Annotations:    resource.Spec.ExtraPodAnnotations,
+	}
+
+	qt := newQueryTracker(
+		cfgen,
+		ytsaurus,
+		yc,
+		tabletNodes,
 		&l,
+		spec.Name,
+		&spec.InstanceSpec,
+		fmt.Sprintf("%sInitCompleted", l.ComponentName),
+		func() ([]byte, error) { return cfgen.GetQueryTrackerStageConfig(spec) })
+	qt.removed = removed
+	return qt
+}
+
+func newQueryTracker(
+	cfgen *ytconfig.Generator,
+	ytsaurus *apiproxy.Ytsaurus,
+	yc internalYtsaurusClient,
+	tabletNodes []Component,
+	l *labeller.Labeller,
+	stage string,
+	instanceSpec *ytv1.InstanceSpec,
+	initCondition string,
+	generator ytconfig.YsonGeneratorFunc,
+) *QueryTracker {
+	resource := ytsaurus.GetResource()
+
+	if instanceSpec.MonitoringPort == nil {
+		instanceSpec.MonitoringPort = ptr.To(int32(consts.QueryTrackerMonitoringPort))
+	}
+
+	srv := newServer(
+		l,
 		ytsaurus,
-		&resource.Spec.QueryTrackers.InstanceSpec,
+		instanceSpec,
 		"/usr/bin/ytserver-query-tracker",
 		"ytserver-query-tracker.yson",
-		cfgen.GetQueryTrackerStatefulSetName(),
-		cfgen.GetQueryTrackerServiceName(),
-		func() ([]byte, error) { return cfgen.GetQueryTrackerConfig(resource.Spec.QueryTrackers) },
+		cfgen.GetQueryTrackerStatefulSetName(stage),
+		cfgen.GetQueryTrackerServiceName(stage),
+		generator,
 		WithContainerPorts(corev1.ContainerPort{
 			Name:          consts.YTRPCPortName,
 			ContainerPort: consts.QueryTrackerRPCPort,
@@ -67,19 +164,20 @@ func NewQueryTracker(
 		}),
 	)
 
-	image := ytsaurus.GetResource().Spec.CoreImage
-	if resource.Spec.QueryTrackers.InstanceSpec.Image != nil {
-		image = *resource.Spec.QueryTrackers.InstanceSpec.Image
+	image := resource.Spec.CoreImage
+	if instanceSpec.Image != nil {
+		image = *instanceSpec.Image
 	}
 
 	return &QueryTracker{
-		localServerComponent: newLocalServerComponent(&l, ytsaurus, srv),
+		localServerComponent: newLocalServerComponent(l, ytsaurus, srv),
 		cfgen:                cfgen,
+		stage:                stage,
 		tabletNodes:          tabletNodes,
-		initCondition:        "queryTrackerInitCompleted",
+		initCondition:        initCondition,
 		ytsaurusClient:       yc,
 		initQTState: NewInitJob(
-			&l,
+			l,
 			ytsaurus.APIProxy(),
 			ytsaurus,
 			resource.Spec.ImagePullSecrets,
@@ -89,17 +187,22 @@ func NewQueryTracker(
 			cfgen.GetNativeClientConfig),
 		secret: resources.NewStringSecret(
 			l.GetSecretName(),
-			&l,
+			l,
 			ytsaurus.APIProxy()),
 	}
 }
 
 func (qt *QueryTracker) IsUpdatable() bool {
-	return true
+	// Removed stage is not updated, it is deleted after the update.
+	return !qt.removed
 }
 
 func (qt *QueryTracker) GetType() consts.ComponentType { return consts.QueryTrackerType }
 
+func (qt *QueryTracker) isProductionStage() bool {
+	return qt.stage == consts.DefaultQueryTrackerStage
+}
+
 func (qt *QueryTracker) Fetch(ctx context.Context) error {
 	return resources.Fetch(ctx,
 		qt.server,
@@ -108,26 +211,93 @@ func (qt *QueryTracker) Fetch(ctx context.Context) error {
 	)
 }
 
+// getStageStatus returns the status of the additional stage, nil if it is not deployed yet.
+func (qt *QueryTracker) getStageStatus() *ytv1.QueryTrackerStageStatus {
+	stages := qt.ytsaurus.GetResource().Status.QueryTrackerStages
+	for i := range stages {
+		if stages[i].Name == qt.stage {
+			return &stages[i]
+		}
+	}
+	return nil
+}
+
+// recordStage adds the additional stage to the status before its resources are created.
+func (qt *QueryTracker) recordStage() *ytv1.QueryTrackerStageStatus {
+	if status := qt.getStageStatus(); status != nil {
+		return status
+	}
+	resource := qt.ytsaurus.GetResource()
+	resource.Status.QueryTrackerStages = append(resource.Status.QueryTrackerStages, ytv1.QueryTrackerStageStatus{Name: qt.stage})
+	return qt.getStageStatus()
+}
+
+// doSyncRemoved deletes resources of the removed stage and then removes it from the status.
+func (qt *QueryTracker) doSyncRemoved(ctx context.Context, dry bool) (ComponentStatus, error) {
+	var err error
+
+	if qt.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
+		return SimpleStatus(SyncStatusReady), err
+	}
+
+	if !dry {
+		err = qt.removeResources(ctx)
+		if err == nil {
+			resource := qt.ytsaurus.GetResource()
+			resource.Status.QueryTrackerStages = slices.DeleteFunc(resource.Status.QueryTrackerStages, func(status ytv1.QueryTrackerStageStatus) bool {
+				return status.Name == qt.stage
+			})
+			qt.ytsaurus.APIProxy().RecordNormal("QueryTrackerStage", fmt.Sprintf("Query tracker stage %s is removed", qt.stage))
+		}
+	}
+	return WaitingStatus(SyncStatusPending, "removal"), err
+}
+
+// removeResources deletes the statefulset, services, configs, secret and state job of the stage.
+func (qt *QueryTracker) removeResources(ctx context.Context) error {
+	if err := qt.server.removeResources(ctx); err != nil {
+		return err
+	}
+	if err := qt.initQTState.removeIfExists(ctx); err != nil {
+		return err
+	}
+	if err := qt.initQTState.configHelper.RemoveIfExists(ctx); err != nil {
+		return err
+	}
+	if resources.Exists(qt.secret) {
+		return qt.ytsaurus.APIProxy().DeleteObject(ctx, qt.secret.OldObject())
+	}
+	return nil
+}
+
 func (qt *QueryTracker) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
 	var err error
 
+	if qt.removed {
+		return qt.doSyncRemoved(ctx, dry)
+	}
+
 	if ytv1.IsReadyToUpdateClusterState(qt.ytsaurus.GetClusterState()) && qt.server.needUpdate() {
 		return SimpleStatus(SyncStatusNeedLocalUpdate), err
 	}
 
-	if qt.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
+	if qt.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating && !qt.isProductionStage() {
+		if status, err := handleUpdatingClusterState(ctx, qt.ytsaurus, qt, &qt.localComponent, qt.server, dry); status != nil {
+			return *status, err
+		}
+	} else if qt.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
 		if IsUpdatingComponent(qt.ytsaurus, qt) {
-			if qt.ytsaurus.GetUpdateState() == ytv1.UpdateStateWaitingForPodsRemoval && IsUpdatingComponent(qt.ytsaurus, qt) {
-				if !dry {
-					err = removePods(ctx, qt.server, &qt.localComponent)
+			if qt.ytsaurus.GetUpdateState() == ytv1.UpdateStateWaitingForPodsRemoval {
+				if status, err := handlePodsRemoval(ctx, qt.ytsaurus, &qt.localComponent, qt.server, dry); status != nil {
+					return *status, err
 				}
-				return WaitingStatus(SyncStatusUpdating, "pods removal"), err
 			}
 
 			if status, err := qt.updateQTState(ctx, dry); status != nil {
 				return *status, err
 			}
-			if qt.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsCreation &&
+			if qt.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsRemoval &&
+				qt.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsCreation &&
 				qt.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForQTStateUpdate {
 				return NewComponentStatus(SyncStatusReady, "Nothing to do now"), err
 			}
@@ -136,6 +306,10 @@ func (qt *QueryTracker) doSync(ctx context.Context, dry bool) (ComponentStatus,
 		}
 	}
 
+	if !dry && !qt.isProductionStage() {
+		qt.recordStage()
+	}
+
 	if qt.secret.NeedSync(consts.TokenSecretKey, "") {
 		if !dry {
 			secretSpec := qt.secret.Build()
@@ -192,7 +366,10 @@ func (qt *QueryTracker) doSync(ctx context.Context, dry bool) (ComponentStatus,
 	}
 
 	if !dry {
-		err = qt.init(ctx, ytClient)
+		err = qt.createConfig(ctx, ytClient)
+		if err == nil && qt.isProductionStage() {
+			err = qt.init(ctx, ytClient)
+		}
 		if err != nil {
 			return WaitingStatus(SyncStatusPending, fmt.Sprintf("%s initialization", qt.GetName())), err
 		}
@@ -205,6 +382,16 @@ func (qt *QueryTracker) doSync(ctx context.Context, dry bool) (ComponentStatus,
 		})
 	}
 
+	// The state of an additional stage is initialized again by the new image of the stage,
+	// the state of production stage is updated within the cluster update.
+	stageStatus := qt.getStageStatus()
+	if stageStatus != nil && stageStatus.StateImage != "" && stageStatus.StateImage != qt.initQTState.image && qt.initQTState.IsCompleted() {
+		if !dry {
+			err = qt.initQTState.prepareRestart(ctx, dry)
+		}
+		return WaitingStatus(SyncStatusPending, fmt.Sprintf("%s restart", qt.initQTState.initJob.Name())), err
+	}
+
 	if !dry {
 		qt.prepareInitQueryTrackerState()
 	}
@@ -212,6 +399,9 @@ func (qt *QueryTracker) doSync(ctx context.Context, dry bool) (ComponentStatus,
 	if err != nil || status.SyncStatus != SyncStatusReady {
 		return status, err
 	}
+	if stageStatus != nil && !dry {
+		stageStatus.StateImage = qt.initQTState.image
+	}
 
 	if qt.ytsaurus.IsStatusConditionTrue(qt.initCondition) {
 		return SimpleStatus(SyncStatusReady), err
@@ -231,12 +421,14 @@ func (qt *QueryTracker) createUser(ctx context.Context, ytClient yt.Client) (err
 	return
 }
 
-func (qt *QueryTracker) init(ctx context.Context, ytClient yt.Client) (err error) {
+// createConfig creates dynamic config of the query tracker stage with the local cluster as default one for all engines.
+func (qt *QueryTracker) createConfig(ctx context.Context, ytClient yt.Client) (err error) {
 	logger := log.FromContext(ctx)
 
+	path := ypath.Path(qt.cfgen.GetQueryTrackerStageRoot(qt.stage)).Child("config")
 	_, err = ytClient.CreateNode(
 		ctx,
-		ypath.Path("//sys/query_tracker/config"),
+		path,
 		yt.NodeDocument,
 		&yt.CreateNodeOptions{
 			Attributes: map[string]interface{}{
@@ -259,21 +451,36 @@ func (qt *QueryTracker) init(ctx context.Context, ytClient yt.Client) (err error
 		},
 	)
 	if err != nil {
-		logger.Error(err, "Creating document '//sys/query_tracker/config' failed")
-		return
+		logger.Error(err, fmt.Sprintf("Creating document '%s' failed", path))
+	}
+	return
+}
+
+func (qt *QueryTracker) getStages() map[string]interface{} {
+	stageNames := []string{consts.DefaultQueryTrackerStage}
+	for _, stage := range qt.ytsaurus.GetResource().Spec.QueryTrackers.Stages {
+		stageNames = append(stageNames, stage.Name)
 	}
 
+	stages := make(map[string]interface{})
+	for _, stage := range stageNames {
+		stages[stage] = map[string]interface{}{
+			"root":    qt.cfgen.GetQueryTrackerStageRoot(stage),
+			"user":    "query_tracker",
+			"channel": map[string]interface{}{"addresses": qt.cfgen.GetQueryTrackerAddresses(stage)},
+		}
+	}
+	return stages
+}
+
+func (qt *QueryTracker) init(ctx context.Context, ytClient yt.Client) (err error) {
+	logger := log.FromContext(ctx)
+
 	err = ytClient.SetNode(
 		ctx,
 		ypath.Path("//sys/@cluster_connection/query_tracker"),
 		map[string]interface{}{
-			"stages": map[string]interface{}{
-				"production": map[string]interface{}{
-					"root":    "//sys/query_tracker",
-					"user":    "query_tracker",
-					"channel": map[string]interface{}{"addresses": qt.cfgen.GetQueryTrackerAddresses()},
-				},
-			},
+			"stages": qt.getStages(),
 		},
 		nil,
 	)
@@ -422,10 +629,14 @@ func (qt *QueryTracker) Sync(ctx context.Context) error {
 func (qt *QueryTracker) prepareInitQueryTrackerState() {
 	path := "/usr/bin/init_query_tracker_state"
 
+	args := fmt.Sprintf("--force --latest --proxy %s", qt.cfgen.GetHTTPProxiesServiceAddress(consts.DefaultHTTPProxyRole))
+	if !qt.isProductionStage() {
+		args += fmt.Sprintf(" --root %s", qt.cfgen.GetQueryTrackerStageRoot(qt.stage))
+	}
+
 	script := []string{
 		initJobWithNativeDriverPrologue(),
-		fmt.Sprintf("if [[ -f \"%s\" ]]; then %s --force --latest --proxy %s; fi",
-			path, path, qt.cfgen.GetHTTPProxiesServiceAddress(consts.DefaultHTTPProxyRole)),
+		fmt.Sprintf("if [[ -f \"%s\" ]]; then %s %s; fi", path, path, args),
 	}
 
 	qt.initQTState.SetInitScript(strings.Join(script, "\n"))
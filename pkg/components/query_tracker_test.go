@@ -0,0 +1,133 @@
+package components
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Query tracker stage test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage: "ytsaurus/ytsaurus:24.2",
+				},
+				QueryTrackers: &ytv1.QueryTrackerSpec{
+					InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+				QueryTrackerStages: []ytv1.QueryTrackerStageStatus{
+					{Name: "dev", StateImage: "ytsaurus/ytsaurus:24.1"},
+				},
+			},
+		}
+	})
+
+	newStageClient := func() client.Client {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "yt-query-tracker-dev-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{consts.TokenSecretKey: []byte("token")},
+		}
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec, secret).Build()
+	}
+
+	It("Removed stages are the stages of the status missing in the spec", func() {
+		k8sClient := newStageClient()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		Expect(GetRemovedQueryTrackerStages(ytsaurus)).Should(Equal([]string{"dev"}))
+
+		ytsaurusSpec.Spec.QueryTrackers.Stages = []ytv1.QueryTrackerStageSpec{{Name: "dev"}}
+		Expect(GetRemovedQueryTrackerStages(ytsaurus)).Should(BeEmpty())
+
+		ytsaurusSpec.Spec.QueryTrackers = nil
+		Expect(GetRemovedQueryTrackerStages(ytsaurus)).Should(Equal([]string{"dev"}))
+	})
+
+	It("Query tracker stage Sync; removed stage resources are deleted", func() {
+		ctx := context.Background()
+		k8sClient := newStageClient()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		cfgen := ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain")
+
+		qt := NewRemovedQueryTrackerStage(cfgen, ytsaurus, NewFakeYtsaurusClient(mock_yt.NewMockClient(mockCtrl)), "dev")
+		Expect(qt.IsUpdatable()).Should(BeFalse())
+		server := NewFakeServer()
+		qt.server = server
+		Expect(qt.Fetch(ctx)).Should(Succeed())
+
+		status, err := qt.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusPending))
+
+		Expect(qt.Sync(ctx)).Should(Succeed())
+		Expect(server.removed).Should(BeTrue())
+		Expect(ytsaurusSpec.Status.QueryTrackerStages).Should(BeEmpty())
+
+		secret := &corev1.Secret{}
+		err = k8sClient.Get(ctx, client.ObjectKey{Name: "yt-query-tracker-dev-secret", Namespace: "default"}, secret)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Query tracker stage Status; state is initialized again by the new image", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.QueryTrackers.Stages = []ytv1.QueryTrackerStageSpec{
+			{Name: "dev", InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1}},
+		}
+		meta.SetStatusCondition(&ytsaurusSpec.Status.Conditions, metav1.Condition{
+			Type:   "qt-stateQueryTracker-devInitJobCompleted",
+			Status: metav1.ConditionTrue,
+			Reason: "InitJobCompleted",
+		})
+		k8sClient := newStageClient()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		cfgen := ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain")
+
+		tabletNodes := []Component{NewFakeComponent("tnd", consts.TabletNodeType)}
+		qt := NewQueryTrackerStage(cfgen, ytsaurus, NewFakeYtsaurusClient(mock_yt.NewMockClient(mockCtrl)), tabletNodes, &ytsaurusSpec.Spec.QueryTrackers.Stages[0])
+		qt.server = NewFakeServer()
+		Expect(qt.Fetch(ctx)).Should(Succeed())
+
+		status, err := qt.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusPending))
+		Expect(status.Message).Should(ContainSubstring("restart"))
+
+		ytsaurusSpec.Status.QueryTrackerStages[0].StateImage = "ytsaurus/ytsaurus:24.2"
+		status, err = qt.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.Message).ShouldNot(ContainSubstring("restart"))
+	})
+})
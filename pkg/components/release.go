@@ -0,0 +1,169 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+)
+
+// releaseJobNameLabel is the label with the job name, which is set on job pods by all Kubernetes versions.
+const releaseJobNameLabel = "job-name"
+
+// isJobOutdated returns true if the release job exists and was created from another image.
+func isJobOutdated(release *InitJob) bool {
+	if !resources.Exists(release.initJob) {
+		return false
+	}
+	containers := release.initJob.OldObject().(*batchv1.Job).Spec.Template.Spec.Containers
+	return len(containers) == 0 || containers[0].Image != release.image
+}
+
+// needReleaseRestart returns true if the release job must be run again for the new image,
+// i.e. the image was changed after the release had been installed or while it was being uploaded.
+// The job of the installed release may be already removed by its TTL.
+func needReleaseRestart(release *InitJob, installedImage string) bool {
+	if isJobOutdated(release) {
+		return true
+	}
+	return installedImage != "" && installedImage != release.image &&
+		release.IsCompleted() && !resources.Exists(release.initJob)
+}
+
+// createReleaseScript returns a script running the release script of the image, which reports Cypress paths
+// of the nodes of the dirs created or rewritten by the release into the termination message of the job pod.
+// Nodes are compared by revisions, so the report doesn't depend on clocks.
+func createReleaseScript(releaseScript string, dirs []ypath.Path) string {
+	quotedDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		quotedDirs = append(quotedDirs, fmt.Sprintf("'%s'", dir))
+	}
+	script := []string{
+		initJobPrologue,
+		"list_release_nodes() {",
+		fmt.Sprintf("  for dir in %s; do", strings.Join(quotedDirs, " ")),
+		`    [ "$(yt exists "$dir")" = true ] || continue`,
+		`    for name in $(yt list "$dir"); do`,
+		`      echo "$dir/$name $(yt get "$dir/$name/@revision")"`,
+		"    done",
+		"  done | sort",
+		"}",
+		"list_release_nodes > /tmp/release-nodes-before",
+		releaseScript,
+		"list_release_nodes > /tmp/release-nodes-after",
+		fmt.Sprintf(
+			"comm -13 /tmp/release-nodes-before /tmp/release-nodes-after | cut -d ' ' -f 1 > %s",
+			corev1.TerminationMessagePathDefault),
+	}
+	return strings.Join(script, "\n")
+}
+
+// isUnversionedReleasePath returns true for files named after their directory, e.g. clickhouse-trampoline/clickhouse-trampoline.
+// Such files are rewritten by every release and used by all of them, so they never belong to a release.
+func isUnversionedReleasePath(path string) bool {
+	i := strings.LastIndex(path, "/")
+	return i > 0 && strings.HasSuffix(path[:i], "/"+path[i+1:])
+}
+
+// getReleaseJobPaths returns Cypress paths reported by the completed release job, unversioned files are skipped.
+// It returns false if the report is not found, e.g. the pod of the job is already removed.
+func getReleaseJobPaths(ctx context.Context, release *InitJob) ([]string, bool, error) {
+	var pods corev1.PodList
+	if err := release.apiProxy.ListObjects(
+		ctx,
+		&pods,
+		client.InNamespace(release.labeller.ObjectMeta.Namespace),
+		client.MatchingLabels{releaseJobNameLabel: release.initJob.Name()}); err != nil {
+		return nil, false, err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+			paths := []string{}
+			for _, path := range strings.Fields(terminated.Message) {
+				if !isUnversionedReleasePath(path) {
+					paths = append(paths, path)
+				}
+			}
+			return paths, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// installRelease marks the image as installed along with the paths written by its release,
+// the previously installed image is kept at the head of previous images.
+// A release which has written nothing reuses files published by previous releases and it is unknown which ones,
+// so it takes the paths of all of them to keep them while it is kept.
+func installRelease(installedImage *string, previousImages *[]string, releasePaths *map[string][]string, image string, paths []string) {
+	if len(paths) == 0 {
+		for _, previousPaths := range *releasePaths {
+			for _, path := range previousPaths {
+				if !slices.Contains(paths, path) {
+					paths = append(paths, path)
+				}
+			}
+		}
+		slices.Sort(paths)
+	}
+	if *releasePaths == nil {
+		*releasePaths = map[string][]string{}
+	}
+	(*releasePaths)[image] = paths
+
+	if *installedImage != "" {
+		images := []string{*installedImage}
+		for _, previous := range *previousImages {
+			if previous != image && previous != *installedImage {
+				images = append(images, previous)
+			}
+		}
+		*previousImages = images
+	}
+	*installedImage = image
+}
+
+// getRemovedReleasePaths returns the recorded paths of the releases of the images which are not used by kept releases,
+// otherPaths are paths used by releases of other resources of the cluster. Unversioned files are never removed.
+func getRemovedReleasePaths(releasePaths map[string][]string, images []string, otherPaths []string) []string {
+	keptPaths := slices.Clone(otherPaths)
+	for image, paths := range releasePaths {
+		if !slices.Contains(images, image) {
+			keptPaths = append(keptPaths, paths...)
+		}
+	}
+	var removedPaths []string
+	for _, image := range images {
+		for _, path := range releasePaths[image] {
+			if isUnversionedReleasePath(path) || slices.Contains(keptPaths, path) || slices.Contains(removedPaths, path) {
+				continue
+			}
+			removedPaths = append(removedPaths, path)
+		}
+	}
+	return removedPaths
+}
+
+// createReleaseCleanupScript returns a script removing the paths of releases from Cypress.
+func createReleaseCleanupScript(paths []string) string {
+	script := []string{
+		initJobWithNativeDriverPrologue(),
+	}
+	for _, path := range paths {
+		script = append(script, fmt.Sprintf("/usr/bin/yt remove --force --recursive '%s'", path))
+	}
+	return strings.Join(script, "\n")
+}
@@ -0,0 +1,73 @@
+package components
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// createReleaseJobPod creates a succeeded pod of the job, which reports the release paths in its termination message.
+// The pod of the previous job is removed, the fake client doesn't remove it along with the job.
+func createReleaseJobPod(ctx context.Context, k8sClient client.Client, jobName, report string) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-pod", jobName),
+			Namespace: "default",
+			Labels:    map[string]string{releaseJobNameLabel: jobName},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "ytsaurus-init",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: report},
+					},
+				},
+			},
+		},
+	}
+	Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pod))).Should(Succeed())
+	Expect(k8sClient.Create(ctx, pod)).Should(Succeed())
+}
+
+var _ = Describe("Release paths test", func() {
+	It("Unversioned files are never removed, paths of kept releases are kept", func() {
+		releasePaths := map[string][]string{
+			"chyt:2.15.0": {"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.15.0"},
+			"chyt:2.14.0": {"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0"},
+			"chyt:2.13.0": {
+				"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.13.0",
+				"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.14.0",
+				"//sys/bin/clickhouse-trampoline/clickhouse-trampoline",
+			},
+			"chyt:2.12.0": {"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.12.0"},
+		}
+		paths := getRemovedReleasePaths(
+			releasePaths,
+			[]string{"chyt:2.13.0", "chyt:2.12.0"},
+			[]string{"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.12.0"})
+		Expect(paths).Should(Equal([]string{"//sys/bin/ytserver-clickhouse/ytserver-clickhouse-2.13.0"}))
+	})
+
+	It("Release which has written nothing keeps paths of previous releases", func() {
+		installedImage := "spyt:1.76.0"
+		previousImages := []string{"spyt:1.75.0"}
+		releasePaths := map[string][]string{
+			"spyt:1.76.0": {"//home/spark/spyt/releases/1.76.0"},
+			"spyt:1.75.0": {"//home/spark/spyt/releases/1.75.0"},
+		}
+		installRelease(&installedImage, &previousImages, &releasePaths, "spyt:1.76.0-fixed", []string{})
+		Expect(installedImage).Should(Equal("spyt:1.76.0-fixed"))
+		Expect(previousImages).Should(Equal([]string{"spyt:1.76.0", "spyt:1.75.0"}))
+		Expect(releasePaths["spyt:1.76.0-fixed"]).Should(Equal([]string{
+			"//home/spark/spyt/releases/1.75.0",
+			"//home/spark/spyt/releases/1.76.0",
+		}))
+	})
+})
@@ -0,0 +1,353 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// RemoteClusters keeps connections of remote clusters registered in //sys/clusters
+// and reports connectivity of each remote cluster in the status.
+// It is the only owner of remote clusters registrations, including remote clusters of query engines.
+type RemoteClusters struct {
+	localComponent
+	cfgen          *ytconfig.Generator
+	ytsaurusClient internalYtsaurusClient
+
+	remoteClusters []ytv1.RemoteYtsaurus
+	statuses       []ytv1.RemoteClusterStatus
+	// checkJobs check connectivity of remote clusters from the cluster, by names of remote clusters.
+	checkJobs map[string]*InitJob
+}
+
+func NewRemoteClusters(
+	cfgen *ytconfig.Generator,
+	ytsaurus *apiproxy.Ytsaurus,
+	yc internalYtsaurusClient,
+) *RemoteClusters {
+	resource := ytsaurus.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       ytsaurus.APIProxy(),
+		ComponentLabel: consts.YTComponentLabelRemoteClusters,
+		ComponentName:  string(consts.RemoteClustersType),
+	}
+
+	return &RemoteClusters{
+		localComponent: newLocalComponent(&l, ytsaurus),
+		cfgen:          cfgen,
+		ytsaurusClient: yc,
+		checkJobs:      map[string]*InitJob{},
+	}
+}
+
+func (rc *RemoteClusters) IsUpdatable() bool {
+	return false
+}
+
+func (rc *RemoteClusters) GetType() consts.ComponentType { return consts.RemoteClustersType }
+
+func (rc *RemoteClusters) newCheckJob(name string) *InitJob {
+	resource := rc.ytsaurus.GetResource()
+	return NewInitJob(
+		rc.labeller,
+		rc.ytsaurus.APIProxy(),
+		rc.ytsaurus,
+		resource.Spec.ImagePullSecrets,
+		fmt.Sprintf("check-%s", name),
+		consts.ClientConfigFileName,
+		resource.Spec.CoreImage,
+		rc.cfgen.GetNativeClientConfig)
+}
+
+// Fetch reads referenced remote clusters and jobs checking their connectivity.
+// Results of previous checks are kept while the registered connection is not changed.
+func (rc *RemoteClusters) Fetch(ctx context.Context) error {
+	resource := rc.ytsaurus.GetResource()
+	rc.remoteClusters = nil
+	rc.statuses = nil
+	for _, ref := range resource.Spec.GetRemoteClusters() {
+		var remote ytv1.RemoteYtsaurus
+		if err := rc.ytsaurus.APIProxy().FetchObject(ctx, ref.Name, &remote); err != nil {
+			return err
+		}
+		var previous *ytv1.RemoteClusterStatus
+		for i := range resource.Status.RemoteClusters {
+			if resource.Status.RemoteClusters[i].Name == ref.Name {
+				previous = &resource.Status.RemoteClusters[i]
+			}
+		}
+
+		if remote.Name == "" {
+			rc.statuses = append(rc.statuses, ytv1.RemoteClusterStatus{
+				Name:       ref.Name,
+				Registered: previous != nil && previous.Registered,
+				Message:    "RemoteYtsaurus is not found",
+			})
+			continue
+		}
+
+		status := ytv1.RemoteClusterStatus{
+			Name:               remote.Name,
+			ObservedGeneration: remote.Generation,
+			Registered:         previous != nil && previous.Registered,
+			Message:            "Connectivity is not checked yet",
+		}
+		if previous != nil && previous.ObservedGeneration == remote.Generation && previous.LastCheckTime != nil {
+			status = *previous
+		}
+		rc.remoteClusters = append(rc.remoteClusters, remote)
+		rc.statuses = append(rc.statuses, status)
+
+		checkJob := rc.newCheckJob(remote.Name)
+		if err := checkJob.Fetch(ctx); err != nil {
+			return err
+		}
+		rc.checkJobs[remote.Name] = checkJob
+	}
+	return nil
+}
+
+// getStaleClusters returns previously registered clusters which are not referenced anymore.
+// Clusters whose RemoteYtsaurus has been removed may still be registered, so they are unregistered too.
+func (rc *RemoteClusters) getStaleClusters() []string {
+	resource := rc.ytsaurus.GetResource()
+
+	var keep []string
+	for _, ref := range resource.Spec.GetRemoteClusters() {
+		keep = append(keep, ref.Name)
+	}
+
+	var stale []string
+	for _, status := range resource.Status.RemoteClusters {
+		if status.Registered && !slices.Contains(keep, status.Name) {
+			stale = append(stale, status.Name)
+		}
+	}
+	return stale
+}
+
+// getRegisteredClusters returns connections of clusters registered in //sys/clusters, by names of clusters.
+func getRegisteredClusters(ctx context.Context, ytClient yt.Client) (map[string]any, error) {
+	registered := map[string]any{}
+	if err := ytClient.GetNode(ctx, ypath.Path("//sys/clusters"), &registered, nil); err != nil {
+		return nil, fmt.Errorf("failed to get //sys/clusters: %w", err)
+	}
+	return registered, nil
+}
+
+func (rc *RemoteClusters) getStatus(name string) *ytv1.RemoteClusterStatus {
+	for i := range rc.statuses {
+		if rc.statuses[i].Name == name {
+			return &rc.statuses[i]
+		}
+	}
+	return nil
+}
+
+// isForeign returns true if the cluster is registered in //sys/clusters with another connection
+// and this registration was not made by the operator, e.g. it was made by hand.
+func (rc *RemoteClusters) isForeign(registered map[string]any, remote *ytv1.RemoteYtsaurus) bool {
+	connection, ok := registered[remote.Name]
+	return ok && !rc.getStatus(remote.Name).Registered && !ysonEqual(connection, rc.cfgen.GetRemoteClusterConnection(remote))
+}
+
+// reportForeignClusters reports remote clusters whose names are already taken in //sys/clusters.
+func (rc *RemoteClusters) reportForeignClusters(registered map[string]any) {
+	for i := range rc.remoteClusters {
+		remote := &rc.remoteClusters[i]
+		if rc.isForeign(registered, remote) {
+			status := rc.getStatus(remote.Name)
+			status.Connected = false
+			status.Message = fmt.Sprintf("Cluster %s is already registered in //sys/clusters not by the operator, it is not overwritten", remote.Name)
+		}
+	}
+}
+
+// isRegistered returns true if //sys/clusters has the desired connections of remote clusters
+// and has no stale clusters, i.e. registrations deleted or changed by hand are detected.
+func (rc *RemoteClusters) isRegistered(registered map[string]any) bool {
+	for i := range rc.remoteClusters {
+		remote := &rc.remoteClusters[i]
+		if rc.isForeign(registered, remote) {
+			continue
+		}
+		if !ysonEqual(registered[remote.Name], rc.cfgen.GetRemoteClusterConnection(remote)) {
+			return false
+		}
+	}
+	for _, name := range rc.getStaleClusters() {
+		if _, ok := registered[name]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (rc *RemoteClusters) registerClusters(ctx context.Context, ytClient yt.Client, registered map[string]any) error {
+	logger := log.FromContext(ctx)
+
+	for _, name := range rc.getStaleClusters() {
+		if _, ok := registered[name]; !ok {
+			continue
+		}
+		path := ypath.Path(fmt.Sprintf("//sys/clusters/%s", name))
+		if err := ytClient.RemoveNode(ctx, path, &yt.RemoveNodeOptions{Force: true}); err != nil {
+			logger.Error(err, fmt.Sprintf("Removing '%s' failed", path))
+			return err
+		}
+		logger.Info("Remote cluster was unregistered", "name", name)
+	}
+
+	for i := range rc.remoteClusters {
+		remote := &rc.remoteClusters[i]
+		if rc.isForeign(registered, remote) {
+			rc.ytsaurus.APIProxy().RecordWarning(
+				"RemoteClusters",
+				fmt.Sprintf("Cluster %s is already registered in //sys/clusters not by the operator, it is not overwritten", remote.Name))
+			continue
+		}
+		connection := rc.cfgen.GetRemoteClusterConnection(remote)
+		if ysonEqual(registered[remote.Name], connection) {
+			continue
+		}
+		path := ypath.Path(fmt.Sprintf("//sys/clusters/%s", remote.Name))
+		if err := ytClient.SetNode(ctx, path, connection, nil); err != nil {
+			logger.Error(err, fmt.Sprintf("Setting '%s' failed", path))
+			return err
+		}
+		rc.getStatus(remote.Name).Registered = true
+		logger.Info("Remote cluster was registered", "name", remote.Name)
+	}
+
+	rc.ytsaurus.GetResource().Status.RemoteClusters = rc.statuses
+	return nil
+}
+
+func (rc *RemoteClusters) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
+	var err error
+
+	ytClientStatus, err := rc.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return ytClientStatus, err
+	}
+	if !IsRunningStatus(ytClientStatus.SyncStatus) {
+		// Registrations in Cypress are checked once the cluster is available.
+		if equality.Semantic.DeepEqual(rc.ytsaurus.GetResource().Status.RemoteClusters, rc.statuses) {
+			return SimpleStatus(SyncStatusReady), err
+		}
+		return WaitingStatus(SyncStatusBlocked, rc.ytsaurusClient.GetName()), err
+	}
+
+	ytClient := rc.ytsaurusClient.GetYtClient()
+	if ytClient == nil {
+		return WaitingStatus(SyncStatusPending, "getting yt client"), err
+	}
+	registered, err := getRegisteredClusters(ctx, ytClient)
+	if err != nil {
+		return WaitingStatus(SyncStatusBlocked, "//sys/clusters"), err
+	}
+	rc.reportForeignClusters(registered)
+
+	if rc.isRegistered(registered) && equality.Semantic.DeepEqual(rc.ytsaurus.GetResource().Status.RemoteClusters, rc.statuses) {
+		return SimpleStatus(SyncStatusReady), err
+	}
+
+	if !dry {
+		err = rc.registerClusters(ctx, ytClient, registered)
+	}
+	return WaitingStatus(SyncStatusPending, "remote clusters registration"), err
+}
+
+func (rc *RemoteClusters) Status(ctx context.Context) (ComponentStatus, error) {
+	return rc.doSync(ctx, true)
+}
+
+func (rc *RemoteClusters) Sync(ctx context.Context) error {
+	_, err := rc.doSync(ctx, false)
+	return err
+}
+
+// createRemoteClusterCheckScript returns a script connecting to the remote cluster by its connection registered in //sys/clusters,
+// so the connectivity is checked from the network of the cluster rather than from the operator.
+func createRemoteClusterCheckScript(name string) string {
+	script := []string{
+		initJobWithNativeDriverPrologue(),
+		fmt.Sprintf("connection=$(/usr/bin/yt get '//sys/clusters/%s' --format '<format=text>yson')", name),
+		`printf '{driver=%s;}' "${connection}" > /tmp/remote-driver.yson`,
+		fmt.Sprintf("YT_DRIVER_CONFIG_PATH=/tmp/remote-driver.yson timeout %d /usr/bin/yt exists //sys",
+			int(consts.RemoteClusterCheckTimeout.Seconds())),
+	}
+	return strings.Join(script, "\n")
+}
+
+// startCheck runs the job checking connectivity of the remote cluster, the job isn't restarted on failure.
+func (rc *RemoteClusters) startCheck(ctx context.Context, name string) error {
+	checkJob := rc.checkJobs[name]
+	checkJob.SetInitScript(createRemoteClusterCheckScript(name))
+	job := checkJob.Build()
+	job.Spec.BackoffLimit = ptr.To(int32(0))
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	return resources.Sync(ctx, checkJob.configHelper, checkJob.initJob)
+}
+
+// finishCheck reports the result of the finished check and removes its job.
+func (rc *RemoteClusters) finishCheck(ctx context.Context, status *ytv1.RemoteClusterStatus) error {
+	checkJob := rc.checkJobs[status.Name]
+	status.Connected = checkJob.initJob.Completed()
+	status.Message = ""
+	if !status.Connected {
+		status.Message = fmt.Sprintf("Remote cluster is not reachable from the cluster, see logs of job %s", checkJob.initJob.Name())
+	}
+	status.LastCheckTime = &metav1.Time{Time: time.Now()}
+	if err := checkJob.removeIfExists(ctx); err != nil {
+		return err
+	}
+	return checkJob.configHelper.RemoveIfExists(ctx)
+}
+
+// CheckConnectivity checks connectivity of clusters registered by the operator once the check period has passed,
+// checks run in jobs and their results are reported in the status.
+// It returns true while checks are running and true if results of finished checks were reported.
+func (rc *RemoteClusters) CheckConnectivity(ctx context.Context) (running bool, reported bool, err error) {
+	for i := range rc.ytsaurus.GetResource().Status.RemoteClusters {
+		status := &rc.ytsaurus.GetResource().Status.RemoteClusters[i]
+		checkJob, ok := rc.checkJobs[status.Name]
+		if !ok || status.ObservedGeneration == 0 || !status.Registered {
+			continue
+		}
+
+		switch {
+		case checkJob.initJob.Completed() || checkJob.initJob.Failed():
+			if err := rc.finishCheck(ctx, status); err != nil {
+				return running, reported, err
+			}
+			reported = true
+		case resources.Exists(checkJob.initJob):
+			running = true
+		case status.LastCheckTime == nil || time.Since(status.LastCheckTime.Time) >= consts.RemoteClustersCheckPeriod:
+			if err := rc.startCheck(ctx, status.Name); err != nil {
+				return running, reported, err
+			}
+			running = true
+		}
+	}
+	return running, reported, nil
+}
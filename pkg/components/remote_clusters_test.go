@@ -0,0 +1,263 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yson"
+	"go.ytsaurus.tech/yt/go/yt"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Remote clusters test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var remoteSpec *ytv1.RemoteYtsaurus
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					UseShortNames: true,
+				},
+				RemoteClusters: []corev1.LocalObjectReference{
+					{Name: "remote"},
+					{Name: "missing"},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+				RemoteClusters: []ytv1.RemoteClusterStatus{
+					{Name: "old", ObservedGeneration: 1, Registered: true, Connected: true},
+				},
+			},
+		}
+
+		remoteSpec = &ytv1.RemoteYtsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "remote",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Spec: ytv1.RemoteYtsaurusSpec{
+				MasterConnectionSpec: ytv1.MasterConnectionSpec{
+					CellTag:       2,
+					HostAddresses: []string{"ms-0.remote.example.com"},
+				},
+			},
+		}
+	})
+
+	newComponent := func(ctx context.Context, k8sClient client.Client) (*RemoteClusters, *apiproxy.Ytsaurus) {
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		component := NewRemoteClusters(ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain"), ytsaurus, NewFakeYtsaurusClient(mockYtClient))
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		return component, ytsaurus
+	}
+
+	newK8sClient := func() client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, remoteSpec).
+			WithStatusSubresource(ytsaurusSpec, &batchv1.Job{}).
+			Build()
+	}
+
+	// getConnection returns the connection of the remote cluster as it is read from Cypress.
+	getConnection := func() any {
+		connection := ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain").GetRemoteClusterConnection(remoteSpec)
+		data, err := yson.Marshal(connection)
+		Expect(err).Should(Succeed())
+		var result any
+		Expect(yson.Unmarshal(data, &result)).Should(Succeed())
+		return result
+	}
+
+	expectRegisteredClusters := func(registered map[string]any, times int) {
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters")), gomock.Any(), gomock.Nil()).
+			SetArg(2, registered).
+			Return(nil).
+			Times(times)
+	}
+
+	It("RemoteClusters Sync; remote clusters are registered and stale ones are removed", func() {
+		ctx := context.Background()
+		component, ytsaurus := newComponent(ctx, newK8sClient())
+
+		expectRegisteredClusters(map[string]any{"old": map[string]any{}, "local": map[string]any{}}, 2)
+		expectRegisteredClusters(map[string]any{"remote": getConnection(), "local": map[string]any{}}, 1)
+
+		status, err := component.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusPending))
+
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters/old")), gomock.Eq(&yt.RemoveNodeOptions{Force: true})).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters/remote")), gomock.Any(), gomock.Nil()).
+			Return(nil)
+		Expect(component.Sync(ctx)).Should(Succeed())
+
+		Expect(ytsaurus.GetResource().Status.RemoteClusters).Should(Equal([]ytv1.RemoteClusterStatus{
+			{Name: "remote", ObservedGeneration: 2, Registered: true, Message: "Connectivity is not checked yet"},
+			{Name: "missing", Message: "RemoteYtsaurus is not found"},
+		}))
+		status, err = component.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusReady))
+	})
+
+	It("RemoteClusters Sync; remote cluster removed from Cypress by hand is registered again", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.RemoteClusters = ytsaurusSpec.Spec.RemoteClusters[:1]
+		ytsaurusSpec.Status.RemoteClusters = []ytv1.RemoteClusterStatus{
+			{Name: "remote", ObservedGeneration: 2, Registered: true, Message: "Connectivity is not checked yet"},
+		}
+		component, _ := newComponent(ctx, newK8sClient())
+
+		expectRegisteredClusters(map[string]any{}, 2)
+		status, err := component.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusPending))
+
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters/remote")), gomock.Any(), gomock.Nil()).
+			Return(nil)
+		Expect(component.Sync(ctx)).Should(Succeed())
+	})
+
+	It("RemoteClusters Sync; remote clusters of query engines are registered and unregistered", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.RemoteClusters = nil
+		ytsaurusSpec.Spec.QueryTrackers = &ytv1.QueryTrackerSpec{
+			RemoteClusters: []corev1.LocalObjectReference{{Name: "remote"}},
+		}
+		ytsaurusSpec.Status.RemoteClusters = []ytv1.RemoteClusterStatus{
+			{Name: "old", ObservedGeneration: 1, Registered: true, Connected: true},
+			{Name: "deleted", Registered: true, Message: "RemoteYtsaurus is not found"},
+		}
+		component, ytsaurus := newComponent(ctx, newK8sClient())
+
+		expectRegisteredClusters(map[string]any{"old": map[string]any{}, "deleted": map[string]any{}}, 1)
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters/old")), gomock.Eq(&yt.RemoveNodeOptions{Force: true})).
+			Return(nil)
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters/deleted")), gomock.Eq(&yt.RemoveNodeOptions{Force: true})).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/clusters/remote")), gomock.Any(), gomock.Nil()).
+			Return(nil)
+		Expect(component.Sync(ctx)).Should(Succeed())
+
+		Expect(ytsaurus.GetResource().Status.RemoteClusters).Should(Equal([]ytv1.RemoteClusterStatus{
+			{Name: "remote", ObservedGeneration: 2, Registered: true, Message: "Connectivity is not checked yet"},
+		}))
+	})
+
+	It("RemoteClusters Sync; cluster registered not by the operator is neither overwritten nor removed", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.RemoteClusters = ytsaurusSpec.Spec.RemoteClusters[:1]
+		ytsaurusSpec.Status.RemoteClusters = nil
+		component, ytsaurus := newComponent(ctx, newK8sClient())
+
+		// SetNode and RemoveNode are not expected.
+		expectRegisteredClusters(map[string]any{"remote": map[string]any{"primary_master": map[string]any{}}}, 3)
+		Expect(component.Sync(ctx)).Should(Succeed())
+
+		remoteStatus := ytsaurus.GetResource().Status.RemoteClusters[0]
+		Expect(remoteStatus.Registered).Should(BeFalse())
+		Expect(remoteStatus.Message).Should(ContainSubstring("not by the operator"))
+
+		component, _ = newComponent(ctx, newK8sClient())
+		status, err := component.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusReady))
+
+		running, reported, err := component.CheckConnectivity(ctx)
+		Expect(err).Should(Succeed())
+		Expect(running).Should(BeFalse())
+		Expect(reported).Should(BeFalse())
+
+		ytsaurusSpec.Spec.RemoteClusters = nil
+		component, _ = newComponent(ctx, newK8sClient())
+		Expect(component.getStaleClusters()).Should(BeEmpty())
+		Expect(component.Sync(ctx)).Should(Succeed())
+	})
+
+	It("RemoteClusters CheckConnectivity; unreachable remote cluster is reported by the check job", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.RemoteClusters = ytsaurusSpec.Spec.RemoteClusters[:1]
+		ytsaurusSpec.Status.RemoteClusters = []ytv1.RemoteClusterStatus{
+			{Name: "remote", ObservedGeneration: 2, Registered: true, Message: "Connectivity is not checked yet"},
+		}
+		k8sClient := newK8sClient()
+		component, _ := newComponent(ctx, k8sClient)
+
+		running, reported, err := component.CheckConnectivity(ctx)
+		Expect(err).Should(Succeed())
+		Expect(running).Should(BeTrue())
+		Expect(reported).Should(BeFalse())
+
+		job := &batchv1.Job{}
+		jobKey := client.ObjectKey{Namespace: "default", Name: "yt-remote-clusters-init-job-check-remote"}
+		Expect(k8sClient.Get(ctx, jobKey, job)).Should(Succeed())
+		Expect(job.Spec.Template.Spec.RestartPolicy).Should(Equal(corev1.RestartPolicyNever))
+		cm := &corev1.ConfigMap{}
+		cmKey := client.ObjectKey{Namespace: "default", Name: "check-remote-yt-remote-clusters-init-job-config"}
+		Expect(k8sClient.Get(ctx, cmKey, cm)).Should(Succeed())
+		Expect(cm.Data[consts.InitClusterScriptFileName]).Should(ContainSubstring("/usr/bin/yt get '//sys/clusters/remote'"))
+
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+
+		component, ytsaurus := newComponent(ctx, k8sClient)
+		running, reported, err = component.CheckConnectivity(ctx)
+		Expect(err).Should(Succeed())
+		Expect(running).Should(BeFalse())
+		Expect(reported).Should(BeTrue())
+
+		remoteStatus := ytsaurus.GetResource().Status.RemoteClusters[0]
+		Expect(remoteStatus.Connected).Should(BeFalse())
+		Expect(remoteStatus.Message).Should(ContainSubstring("not reachable from the cluster"))
+		Expect(remoteStatus.LastCheckTime).ShouldNot(BeNil())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, jobKey, &batchv1.Job{}))).Should(BeTrue())
+
+		// The next check is run after the check period.
+		component, _ = newComponent(ctx, k8sClient)
+		running, reported, err = component.CheckConnectivity(ctx)
+		Expect(err).Should(Succeed())
+		Expect(running).Should(BeFalse())
+		Expect(reported).Should(BeFalse())
+	})
+})
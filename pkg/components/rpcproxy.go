@@ -23,7 +23,6 @@ type RpcProxy struct {
 
 	serviceType      *corev1.ServiceType
 	balancingService *resources.RPCService
-	tlsSecret        *resources.TLSSecret
 }
 
 func NewRPCProxy(
@@ -43,6 +42,15 @@ func NewRPCProxy(
 		spec.InstanceSpec.MonitoringPort = ptr.To(int32(consts.RPCProxyMonitoringPort))
 	}
 
+	var tlsSecret *resources.TLSSecret
+	if secret := spec.Transport.TLSSecret; secret != nil {
+		tlsSecret = resources.NewTLSSecret(
+			secret.Name,
+			consts.RPCSecretVolumeName,
+			consts.RPCSecretMountPoint,
+			ytsaurus.APIProxy())
+	}
+
 	srv := newServer(
 		&l,
 		ytsaurus,
@@ -59,6 +67,7 @@ func NewRPCProxy(
 			ContainerPort: consts.RPCProxyRPCPort,
 			Protocol:      corev1.ProtocolTCP,
 		}),
+		WithTLSSecret(tlsSecret),
 	)
 
 	var balancingService *resources.RPCService = nil
@@ -71,21 +80,12 @@ func NewRPCProxy(
 		balancingService.SetNodePort(spec.NodePort)
 	}
 
-	var tlsSecret *resources.TLSSecret
-	if secret := spec.Transport.TLSSecret; secret != nil {
-		tlsSecret = resources.NewTLSSecret(
-			secret.Name,
-			consts.RPCSecretVolumeName,
-			consts.RPCSecretMountPoint)
-	}
-
 	return &RpcProxy{
 		localServerComponent: newLocalServerComponent(&l, ytsaurus, srv),
 		cfgen:                cfgen,
 		master:               masterReconciler,
 		serviceType:          spec.ServiceType,
 		balancingService:     balancingService,
-		tlsSecret:            tlsSecret,
 	}
 }
 
@@ -128,11 +128,6 @@ func (rp *RpcProxy) doSync(ctx context.Context, dry bool) (ComponentStatus, erro
 
 	if rp.NeedSync() {
 		if !dry {
-			statefulSet := rp.server.buildStatefulSet()
-			if secret := rp.tlsSecret; secret != nil {
-				secret.AddVolume(&statefulSet.Spec.Template.Spec)
-				secret.AddVolumeMount(&statefulSet.Spec.Template.Spec.Containers[0])
-			}
 			err = rp.server.Sync(ctx)
 		}
 		return WaitingStatus(SyncStatusPending, "components"), err
@@ -132,17 +132,17 @@ func (s *Scheduler) doSync(ctx context.Context, dry bool) (ComponentStatus, erro
 	if s.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
 		if IsUpdatingComponent(s.ytsaurus, s) {
 			if s.ytsaurus.GetUpdateState() == ytv1.UpdateStateWaitingForPodsRemoval {
-				if !dry {
-					err = removePods(ctx, s.server, &s.localComponent)
+				if status, err := handlePodsRemoval(ctx, s.ytsaurus, &s.localComponent, s.server, dry); status != nil {
+					return *status, err
 				}
-				return WaitingStatus(SyncStatusUpdating, "pods removal"), err
 			}
 
 			if status, err := s.updateOpArchive(ctx, dry); status != nil {
 				return *status, err
 			}
 
-			if s.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsCreation &&
+			if s.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsRemoval &&
+				s.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForPodsCreation &&
 				s.ytsaurus.GetUpdateState() != ytv1.UpdateStateWaitingForOpArchiveUpdate {
 				return NewComponentStatus(SyncStatusReady, "Nothing to do now"), err
 			}
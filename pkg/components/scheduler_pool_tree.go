@@ -0,0 +1,449 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// rootPoolName is the parent name of top-level pools of a pool tree.
+const rootPoolName = "<Root>"
+
+type poolResources struct {
+	CPU    *float64 `yson:"cpu,omitempty"`
+	Memory *int64   `yson:"memory,omitempty"`
+}
+
+// schedulerPoolAttributes are attributes of a scheduler pool managed by the operator.
+type schedulerPoolAttributes struct {
+	Weight                   *float64       `yson:"weight,omitempty"`
+	StrongGuaranteeResources *poolResources `yson:"strong_guarantee_resources,omitempty"`
+	MaxOperationCount        *int64         `yson:"max_operation_count,omitempty"`
+	MaxRunningOperationCount *int64         `yson:"max_running_operation_count,omitempty"`
+	ACL                      []yt.ACE       `yson:"acl,omitempty"`
+}
+
+// SchedulerPoolTree keeps a pool tree of the cluster and its pools in line with the spec.
+type SchedulerPoolTree struct {
+	poolTree *apiproxy.SchedulerPoolTree
+	cfgen    *ytconfig.Generator
+	ytsaurus *ytv1.Ytsaurus
+
+	clientSecret *resources.StringSecret
+
+	ytClient yt.Client
+
+	drift []string
+}
+
+func NewSchedulerPoolTree(
+	cfgen *ytconfig.Generator,
+	poolTree *apiproxy.SchedulerPoolTree,
+	ytsaurus *ytv1.Ytsaurus) *SchedulerPoolTree {
+	resource := poolTree.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       poolTree.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-pool-tree-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("SchedulerPoolTree-%s", resource.Name),
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	return &SchedulerPoolTree{
+		poolTree: poolTree,
+		cfgen:    cfgen,
+		ytsaurus: ytsaurus,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			poolTree.APIProxy()),
+	}
+}
+
+func (t *SchedulerPoolTree) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, t.clientSecret)
+}
+
+func (t *SchedulerPoolTree) getNodesFilter() string {
+	return strings.Join(t.poolTree.GetResource().Spec.NodeTags, " & ")
+}
+
+// getOrderedPools returns pools of the spec with parents going before their children.
+func (t *SchedulerPoolTree) getOrderedPools() ([]ytv1.SchedulerPoolSpec, error) {
+	pools := t.poolTree.GetResource().Spec.Pools
+
+	names := make(map[string]bool)
+	for _, pool := range pools {
+		if names[pool.Name] {
+			return nil, fmt.Errorf("pool %s is declared twice", pool.Name)
+		}
+		names[pool.Name] = true
+	}
+
+	var ordered []ytv1.SchedulerPoolSpec
+	created := make(map[string]bool)
+	for len(ordered) < len(pools) {
+		progress := false
+		for _, pool := range pools {
+			if created[pool.Name] || (pool.Parent != "" && !created[pool.Parent]) {
+				continue
+			}
+			ordered = append(ordered, pool)
+			created[pool.Name] = true
+			progress = true
+		}
+		if !progress {
+			var unresolved []string
+			for _, pool := range pools {
+				if !created[pool.Name] {
+					unresolved = append(unresolved, pool.Name)
+				}
+			}
+			return nil, fmt.Errorf("pools %v have undeclared or cyclic parents", unresolved)
+		}
+	}
+	return ordered, nil
+}
+
+func (t *SchedulerPoolTree) validate() error {
+	var tags []string
+	for _, execNodes := range t.ytsaurus.Spec.ExecNodes {
+		tags = append(tags, execNodes.Tags...)
+	}
+	for _, tag := range t.poolTree.GetResource().Spec.NodeTags {
+		if !slices.Contains(tags, tag) {
+			return fmt.Errorf("no exec nodes have tag %s", tag)
+		}
+	}
+
+	for _, pool := range t.poolTree.GetResource().Spec.Pools {
+		for name := range pool.StrongGuaranteeResources {
+			if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+				return fmt.Errorf("unsupported strong guarantee resource %s of pool %s", name, pool.Name)
+			}
+		}
+	}
+
+	_, err := t.getOrderedPools()
+	return err
+}
+
+func getSchedulerPoolAttributes(pool ytv1.SchedulerPoolSpec) schedulerPoolAttributes {
+	attributes := schedulerPoolAttributes{
+		MaxOperationCount:        pool.MaxOperationCount,
+		MaxRunningOperationCount: pool.MaxRunningOperationCount,
+	}
+	if pool.Weight != nil {
+		attributes.Weight = ptr.To(pool.Weight.AsApproximateFloat64())
+	}
+	if pool.StrongGuaranteeResources != nil {
+		attributes.StrongGuaranteeResources = &poolResources{}
+		if cpu, ok := pool.StrongGuaranteeResources[corev1.ResourceCPU]; ok {
+			attributes.StrongGuaranteeResources.CPU = ptr.To(cpu.AsApproximateFloat64())
+		}
+		if memory, ok := pool.StrongGuaranteeResources[corev1.ResourceMemory]; ok {
+			attributes.StrongGuaranteeResources.Memory = ptr.To(memory.Value())
+		}
+	}
+	if pool.ACL != nil {
+		attributes.ACL = toYtACL(pool.ACL)
+	}
+	return attributes
+}
+
+// aclEqual compares the entries ignoring attributes which are not managed by the operator.
+func aclEqual(current, desired []yt.ACE) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	for i := range current {
+		if current[i].Action != desired[i].Action ||
+			!slices.Equal(current[i].Subjects, desired[i].Subjects) ||
+			!slices.Equal(current[i].Permissions, desired[i].Permissions) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeValue(value any) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "<none>"
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%+v", v.Interface())
+}
+
+// setAttribute sets the attribute, a change of an existing object is recorded as drift.
+func (t *SchedulerPoolTree) setAttribute(ctx context.Context, path ypath.Path, object, attribute string, current, value any, created bool) error {
+	if !created {
+		drift := fmt.Sprintf("%s: %s is %s instead of %s",
+			object, attribute, describeValue(current), describeValue(value))
+		t.drift = append(t.drift, drift)
+		t.poolTree.APIProxy().RecordNormal("DriftCorrected", drift)
+	}
+	if err := t.ytClient.SetNode(ctx, path.Attr(attribute), value, nil); err != nil {
+		return fmt.Errorf("failed to set %s of %s: %w", attribute, object, err)
+	}
+	return nil
+}
+
+func (t *SchedulerPoolTree) syncPool(ctx context.Context, path ypath.Path, pool ytv1.SchedulerPoolSpec) error {
+	object := fmt.Sprintf("pool %s", pool.Name)
+
+	exists, err := t.ytClient.NodeExists(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	var current schedulerPoolAttributes
+	if !exists {
+		attributes := map[string]any{
+			"name":      pool.Name,
+			"pool_tree": t.poolTree.GetResource().GetPoolTreeName(),
+		}
+		if pool.Parent != "" {
+			attributes["parent_name"] = pool.Parent
+		}
+		_, err = t.ytClient.CreateObject(ctx, yt.NodeSchedulerPool, &yt.CreateObjectOptions{
+			Attributes: attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", object, err)
+		}
+	} else if err = t.ytClient.GetNode(ctx, path.Attrs(), &current, nil); err != nil {
+		return err
+	}
+
+	desired := getSchedulerPoolAttributes(pool)
+	created := !exists
+	if desired.Weight != nil && !reflect.DeepEqual(current.Weight, desired.Weight) {
+		if err = t.setAttribute(ctx, path, object, "weight", current.Weight, *desired.Weight, created); err != nil {
+			return err
+		}
+	}
+	if desired.StrongGuaranteeResources != nil && !reflect.DeepEqual(current.StrongGuaranteeResources, desired.StrongGuaranteeResources) {
+		if err = t.setAttribute(ctx, path, object, "strong_guarantee_resources", current.StrongGuaranteeResources, desired.StrongGuaranteeResources, created); err != nil {
+			return err
+		}
+	}
+	if desired.MaxOperationCount != nil && !reflect.DeepEqual(current.MaxOperationCount, desired.MaxOperationCount) {
+		if err = t.setAttribute(ctx, path, object, "max_operation_count", current.MaxOperationCount, *desired.MaxOperationCount, created); err != nil {
+			return err
+		}
+	}
+	if desired.MaxRunningOperationCount != nil && !reflect.DeepEqual(current.MaxRunningOperationCount, desired.MaxRunningOperationCount) {
+		if err = t.setAttribute(ctx, path, object, "max_running_operation_count", current.MaxRunningOperationCount, *desired.MaxRunningOperationCount, created); err != nil {
+			return err
+		}
+	}
+	if desired.ACL != nil && !aclEqual(current.ACL, desired.ACL) {
+		if err = t.setAttribute(ctx, path, object, "acl", current.ACL, desired.ACL, created); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectPoolParents collects parents of all pools of the tree given by the tree node,
+// the parent of top-level pools is empty as in the spec.
+func collectPoolParents(node map[string]any, parent string, parents map[string]string) {
+	for name, child := range node {
+		parents[name] = parent
+		if childNode, ok := child.(map[string]any); ok {
+			collectPoolParents(childNode, name, parents)
+		}
+	}
+}
+
+// findPoolPath returns the path of the pool in the tree given by the tree node.
+func findPoolPath(node map[string]any, path ypath.Path, name string) (ypath.Path, bool) {
+	for childName, child := range node {
+		if childName == name {
+			return path.Child(name), true
+		}
+		if childNode, ok := child.(map[string]any); ok {
+			if poolPath, found := findPoolPath(childNode, path.Child(childName), name); found {
+				return poolPath, true
+			}
+		}
+	}
+	return "", false
+}
+
+// movePool moves the existing pool to the parent of the spec by setting its parent name,
+// names of pools are unique within the tree, so the pool can't be created under the new parent.
+// The pool is looked up in the current tree, as its ancestors may have been moved already.
+func (t *SchedulerPoolTree) movePool(ctx context.Context, treePath ypath.Path, pool ytv1.SchedulerPoolSpec, currentParent string) error {
+	tree := map[string]any{}
+	if err := t.ytClient.GetNode(ctx, treePath, &tree, nil); err != nil {
+		return err
+	}
+	path, ok := findPoolPath(tree, treePath, pool.Name)
+	if !ok {
+		return fmt.Errorf("pool %s is not found in the tree", pool.Name)
+	}
+	if currentParent == "" {
+		currentParent = rootPoolName
+	}
+	parent := pool.Parent
+	if parent == "" {
+		parent = rootPoolName
+	}
+	return t.setAttribute(ctx, path, fmt.Sprintf("pool %s", pool.Name), "parent_name", currentParent, parent, false)
+}
+
+func (t *SchedulerPoolTree) syncPoolTree(ctx context.Context) error {
+	resource := t.poolTree.GetResource()
+	name := resource.GetPoolTreeName()
+	object := fmt.Sprintf("pool tree %s", name)
+	treePath := ypath.Path("//sys/pool_trees").Child(name)
+	nodesFilter := t.getNodesFilter()
+
+	exists, err := t.ytClient.NodeExists(ctx, treePath, nil)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		_, err = t.ytClient.CreateObject(ctx, yt.NodeSchedulerPoolTree, &yt.CreateObjectOptions{
+			Attributes: map[string]any{
+				"name": name,
+				"config": map[string]any{
+					"nodes_filter": nodesFilter,
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", object, err)
+		}
+	} else {
+		var currentNodesFilter string
+		if err = t.ytClient.GetNode(ctx, treePath.Attr("config").Child("nodes_filter"), &currentNodesFilter, nil); err != nil {
+			return err
+		}
+		if currentNodesFilter != nodesFilter {
+			if err = t.setAttribute(ctx, treePath, object, "config/nodes_filter", currentNodesFilter, nodesFilter, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if resource.Spec.Default {
+		var defaultTree string
+		if err = t.ytClient.GetNode(ctx, ypath.Path("//sys/pool_trees/@default_tree"), &defaultTree, nil); err != nil {
+			return err
+		}
+		if defaultTree != name {
+			if err = t.setAttribute(ctx, ypath.Path("//sys/pool_trees"), "pool trees", "default_tree", defaultTree, name, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	tree := map[string]any{}
+	if err = t.ytClient.GetNode(ctx, treePath, &tree, nil); err != nil {
+		return err
+	}
+	existingParents := make(map[string]string)
+	collectPoolParents(tree, "", existingParents)
+
+	pools, err := t.getOrderedPools()
+	if err != nil {
+		return err
+	}
+	poolPaths := make(map[string]ypath.Path)
+	for _, pool := range pools {
+		parentPath := treePath
+		if pool.Parent != "" {
+			parentPath = poolPaths[pool.Parent]
+		}
+		poolPaths[pool.Name] = parentPath.Child(pool.Name)
+		if currentParent, ok := existingParents[pool.Name]; ok && currentParent != pool.Parent {
+			if err = t.movePool(ctx, treePath, pool, currentParent); err != nil {
+				return err
+			}
+		}
+		if err = t.syncPool(ctx, poolPaths[pool.Name], pool); err != nil {
+			return err
+		}
+	}
+
+	existingPools := make([]string, 0, len(existingParents))
+	for pool := range existingParents {
+		existingPools = append(existingPools, pool)
+	}
+	sort.Strings(existingPools)
+	for _, pool := range existingPools {
+		if _, ok := poolPaths[pool]; !ok {
+			t.drift = append(t.drift, fmt.Sprintf("pool %s is not declared", pool))
+		}
+	}
+	return nil
+}
+
+func (t *SchedulerPoolTree) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	status := &t.poolTree.GetResource().Status
+
+	if t.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		t.poolTree.SetState(ctx, ytv1.SchedulerPoolTreeStatePending, "Waiting for ytsaurus to be running")
+		return nil
+	}
+
+	if !resources.Exists(t.clientSecret) {
+		t.poolTree.SetState(ctx, ytv1.SchedulerPoolTreeStatePending, "Waiting for ytsaurus client secret")
+		return nil
+	}
+
+	if err := t.validate(); err != nil {
+		t.poolTree.SetState(ctx, ytv1.SchedulerPoolTreeStateFailed, err.Error())
+		return nil
+	}
+
+	if t.ytClient == nil {
+		token, _ := t.clientSecret.GetValue(consts.TokenSecretKey)
+		ytClient, err := newYtClient(t.cfgen, token)
+		if err != nil {
+			return err
+		}
+		t.ytClient = ytClient
+	}
+
+	// Errors of YTsaurus API are reported in the status, the tree is reconciled again later.
+	t.drift = nil
+	err := t.syncPoolTree(ctx)
+	status.Drift = t.drift
+	if err != nil {
+		logger.Error(err, "failed to sync scheduler pool tree")
+		t.poolTree.SetState(ctx, ytv1.SchedulerPoolTreeStateFailed, err.Error())
+		return nil
+	}
+
+	t.poolTree.SetState(ctx, ytv1.SchedulerPoolTreeStateSynced, "Pool tree is in sync with the spec")
+	return nil
+}
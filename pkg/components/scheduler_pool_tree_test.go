@@ -0,0 +1,240 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Scheduler pool tree test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var poolTreeSpec *ytv1.SchedulerPoolTree
+	var clientSecret *corev1.Secret
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	treePath := ypath.Path("//sys/pool_trees/gpu")
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+				ExecNodes: []ytv1.ExecNodesSpec{
+					{
+						InstanceSpec: ytv1.InstanceSpec{
+							InstanceCount: 1,
+						},
+						ClusterNodesSpec: ytv1.ClusterNodesSpec{
+							Tags: []string{"gpu"},
+						},
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		poolTreeSpec = &ytv1.SchedulerPoolTree{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gpu",
+				Namespace: "default",
+			},
+			Spec: ytv1.SchedulerPoolTreeSpec{
+				Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				NodeTags: []string{"gpu"},
+				Pools: []ytv1.SchedulerPoolSpec{
+					{
+						Name:              "adhoc",
+						Parent:            "analytics",
+						MaxOperationCount: ptr.To(int64(10)),
+					},
+					{
+						Name:   "analytics",
+						Weight: ptr.To(resource.MustParse("2")),
+						StrongGuaranteeResources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("4"),
+						},
+					},
+				},
+			},
+		}
+
+		clientSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "yt-client-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				consts.TokenSecretKey: []byte("token"),
+			},
+		}
+	})
+
+	syncPoolTree := func(ctx context.Context, k8sClient client.Client) *ytv1.SchedulerPoolTree {
+		ytsaurus := &ytv1.Ytsaurus{}
+		poolTree := &ytv1.SchedulerPoolTree{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(poolTreeSpec), poolTree)).Should(Succeed())
+		proxy := apiproxy.NewSchedulerPoolTree(poolTree, k8sClient, record.NewFakeRecorder(100), scheme)
+		component := NewSchedulerPoolTree(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus)
+		component.ytClient = mockYtClient
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		Expect(component.Sync(ctx)).Should(Succeed())
+		Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+		return poolTree
+	}
+
+	It("SchedulerPoolTree Sync; drift is corrected and reported", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, poolTreeSpec, clientSecret).
+			WithStatusSubresource(poolTreeSpec).
+			Build()
+
+		analyticsPath := treePath.Child("analytics")
+		adhocPath := analyticsPath.Child("adhoc")
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(treePath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(treePath.Attr("config").Child("nodes_filter")), gomock.Any(), gomock.Nil()).
+			SetArg(2, "").
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(treePath.Attr("config/nodes_filter")), gomock.Eq("gpu"), gomock.Nil()).
+			Return(nil)
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(analyticsPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(analyticsPath.Attrs()), gomock.Any(), gomock.Nil()).
+			SetArg(2, schedulerPoolAttributes{
+				Weight:                   ptr.To(1.0),
+				StrongGuaranteeResources: &poolResources{CPU: ptr.To(4.0)},
+			}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(analyticsPath.Attr("weight")), gomock.Eq(2.0), gomock.Nil()).
+			Return(nil)
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(adhocPath), gomock.Nil()).Return(false, nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeSchedulerPool), gomock.Eq(&yt.CreateObjectOptions{
+				Attributes: map[string]any{"name": "adhoc", "pool_tree": "gpu", "parent_name": "analytics"},
+			})).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(adhocPath.Attr("max_operation_count")), gomock.Eq(int64(10)), gomock.Nil()).
+			Return(nil)
+
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(treePath), gomock.Any(), gomock.Nil()).
+			SetArg(2, map[string]any{
+				"analytics": map[string]any{"adhoc": map[string]any{}},
+				"research":  map[string]any{},
+			}).
+			Return(nil)
+
+		poolTree := syncPoolTree(ctx, k8sClient)
+		Expect(poolTree.Status.State).Should(Equal(ytv1.SchedulerPoolTreeStateSynced))
+		Expect(poolTree.Status.Drift).Should(Equal([]string{
+			`pool tree gpu: config/nodes_filter is "" instead of "gpu"`,
+			"pool analytics: weight is 1 instead of 2",
+			"pool research is not declared",
+		}))
+	})
+
+	It("SchedulerPoolTree Sync; pool is moved to the new parent", func() {
+		ctx := context.Background()
+		poolTreeSpec.Spec.Pools[1].Weight = nil
+		poolTreeSpec.Spec.Pools[1].StrongGuaranteeResources = nil
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, poolTreeSpec, clientSecret).
+			WithStatusSubresource(poolTreeSpec).
+			Build()
+
+		analyticsPath := treePath.Child("analytics")
+		adhocPath := analyticsPath.Child("adhoc")
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(treePath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(treePath.Attr("config").Child("nodes_filter")), gomock.Any(), gomock.Nil()).
+			SetArg(2, "gpu").
+			Return(nil)
+		// Pool adhoc is a top-level pool, it is moved under analytics rather than created there.
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(treePath), gomock.Any(), gomock.Nil()).
+			SetArg(2, map[string]any{
+				"analytics": map[string]any{},
+				"adhoc":     map[string]any{},
+			}).
+			Return(nil).
+			Times(2)
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(analyticsPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(analyticsPath.Attrs()), gomock.Any(), gomock.Nil()).
+			Return(nil)
+
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(treePath.Child("adhoc").Attr("parent_name")), gomock.Eq("analytics"), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(adhocPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(adhocPath.Attrs()), gomock.Any(), gomock.Nil()).
+			SetArg(2, schedulerPoolAttributes{MaxOperationCount: ptr.To(int64(10))}).
+			Return(nil)
+
+		poolTree := syncPoolTree(ctx, k8sClient)
+		Expect(poolTree.Status.State).Should(Equal(ytv1.SchedulerPoolTreeStateSynced))
+		Expect(poolTree.Status.Drift).Should(Equal([]string{
+			`pool adhoc: parent_name is "<Root>" instead of "analytics"`,
+		}))
+	})
+
+	It("SchedulerPoolTree Sync; invalid spec", func() {
+		ctx := context.Background()
+		poolTreeSpec.Spec.NodeTags = []string{"tpu"}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, poolTreeSpec, clientSecret).
+			WithStatusSubresource(poolTreeSpec).
+			Build()
+
+		poolTree := syncPoolTree(ctx, k8sClient)
+		Expect(poolTree.Status.State).Should(Equal(ytv1.SchedulerPoolTreeStateFailed))
+		Expect(poolTree.Status.Message).Should(Equal("no exec nodes have tag tpu"))
+	})
+})
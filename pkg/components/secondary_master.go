@@ -0,0 +1,184 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// SecondaryMaster runs masters of a secondary cell and keeps roles of the cell.
+// Cluster initialization, snapshots export and read-only exit are driven by the primary Master component.
+type SecondaryMaster struct {
+	localServerComponent
+	cfgen          *ytconfig.Generator
+	ytsaurusClient internalYtsaurusClient
+	spec           *ytv1.MastersSpec
+}
+
+func NewSecondaryMaster(
+	cfgen *ytconfig.Generator,
+	ytsaurus *apiproxy.Ytsaurus,
+	yc internalYtsaurusClient,
+	spec *ytv1.MastersSpec,
+) *SecondaryMaster {
+	resource := ytsaurus.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       ytsaurus.APIProxy(),
+		ComponentLabel: fmt.Sprintf("%s-%d", consts.YTComponentLabelSecondaryMaster, spec.CellTag),
+		ComponentName:  fmt.Sprintf("%s-%d", consts.SecondaryMasterType, spec.CellTag),
+		Annotations:    resource.Spec.ExtraPodAnnotations,
+	}
+
+	if spec.InstanceSpec.MonitoringPort == nil {
+		spec.InstanceSpec.MonitoringPort = ptr.To(int32(consts.MasterMonitoringPort))
+	}
+
+	srv := newServer(
+		&l,
+		ytsaurus,
+		&spec.InstanceSpec,
+		"/usr/bin/ytserver-master",
+		"ytserver-master.yson",
+		cfgen.GetSecondaryMastersStatefulSetName(spec.CellTag),
+		cfgen.GetSecondaryMastersServiceName(spec.CellTag),
+		func() ([]byte, error) { return cfgen.GetSecondaryMasterConfig(spec) },
+		WithContainerPorts(corev1.ContainerPort{
+			Name:          consts.YTRPCPortName,
+			ContainerPort: consts.MasterRPCPort,
+			Protocol:      corev1.ProtocolTCP,
+		}),
+	)
+
+	return &SecondaryMaster{
+		localServerComponent: newLocalServerComponent(&l, ytsaurus, srv),
+		cfgen:                cfgen,
+		ytsaurusClient:       yc,
+		spec:                 spec,
+	}
+}
+
+func (m *SecondaryMaster) IsUpdatable() bool {
+	return true
+}
+
+func (m *SecondaryMaster) GetType() consts.ComponentType { return consts.SecondaryMasterType }
+
+func (m *SecondaryMaster) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, m.server)
+}
+
+func (m *SecondaryMaster) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
+	var err error
+
+	if ytv1.IsReadyToUpdateClusterState(m.ytsaurus.GetClusterState()) && m.server.needUpdate() {
+		return SimpleStatus(SyncStatusNeedLocalUpdate), err
+	}
+
+	if m.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
+		if status, err := handleUpdatingClusterState(ctx, m.ytsaurus, m, &m.localComponent, m.server, dry); status != nil {
+			return *status, err
+		}
+	}
+
+	if m.NeedSync() {
+		if !dry {
+			err = m.doServerSync(ctx)
+		}
+		return WaitingStatus(SyncStatusPending, "components"), err
+	}
+
+	if !m.server.arePodsReady(ctx) {
+		return WaitingStatus(SyncStatusBlocked, "pods"), err
+	}
+
+	if len(m.spec.CellRoles) != 0 && m.ytsaurus.GetClusterState() != ytv1.ClusterStateUpdating {
+		// Masters are in read-only during the update, roles are synced after it.
+		if status, err := m.syncCellRoles(ctx, dry); status != nil {
+			return *status, err
+		}
+	}
+
+	return SimpleStatus(SyncStatusReady), err
+}
+
+func (m *SecondaryMaster) getCellRolesPath() ypath.Path {
+	return ypath.Path(fmt.Sprintf("//sys/@config/multicell_manager/cell_descriptors/%d/roles", m.spec.CellTag))
+}
+
+// syncCellRoles sets roles of the cell in the master dynamic config if they differ from the spec.
+func (m *SecondaryMaster) syncCellRoles(ctx context.Context, dry bool) (*ComponentStatus, error) {
+	ytClientStatus, err := m.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return &ytClientStatus, err
+	}
+	ytClient := m.ytsaurusClient.GetYtClient()
+	if !IsRunningStatus(ytClientStatus.SyncStatus) || ytClient == nil {
+		return ptr.To(WaitingStatus(SyncStatusBlocked, m.ytsaurusClient.GetName())), nil
+	}
+
+	path := m.getCellRolesPath()
+	exists, err := ytClient.NodeExists(ctx, path, nil)
+	if err != nil {
+		return ptr.To(WaitingStatus(SyncStatusPending, "cell roles")), err
+	}
+	if exists {
+		var roles []ytv1.MasterCellRole
+		if err := ytClient.GetNode(ctx, path, &roles, nil); err != nil {
+			return ptr.To(WaitingStatus(SyncStatusPending, "cell roles")), err
+		}
+		if slices.Equal(roles, m.spec.CellRoles) {
+			return nil, nil
+		}
+	}
+
+	if !dry {
+		logger := log.FromContext(ctx)
+		if err := ytClient.SetNode(ctx, path, m.spec.CellRoles, &yt.SetNodeOptions{Recursive: true}); err != nil {
+			logger.Error(err, "Setting cell roles failed", "cellTag", m.spec.CellTag)
+			return ptr.To(WaitingStatus(SyncStatusPending, "cell roles")), err
+		}
+		logger.Info("Cell roles were set", "cellTag", m.spec.CellTag, "roles", m.spec.CellRoles)
+	}
+	return ptr.To(WaitingStatus(SyncStatusPending, "cell roles")), nil
+}
+
+func (m *SecondaryMaster) Status(ctx context.Context) (ComponentStatus, error) {
+	return m.doSync(ctx, true)
+}
+
+func (m *SecondaryMaster) Sync(ctx context.Context) error {
+	_, err := m.doSync(ctx, false)
+	return err
+}
+
+func (m *SecondaryMaster) doServerSync(ctx context.Context) error {
+	statefulSet := m.server.buildStatefulSet()
+	podSpec := &statefulSet.Spec.Template.Spec
+
+	if err := AddSidecarsToPodSpec(m.spec.Sidecars, podSpec); err != nil {
+		return err
+	}
+
+	if len(m.spec.HostAddresses) != 0 {
+		hostAddressLabel := m.spec.HostAddressLabel
+		if hostAddressLabel == "" {
+			hostAddressLabel = defaultHostAddressLabel
+		}
+		AddAffinity(statefulSet, hostAddressLabel, m.spec.HostAddresses)
+	}
+	return m.server.Sync(ctx)
+}
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Secondary master test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var mockYtClient *mock_yt.MockClient
+
+	rolesPath := ypath.Path("//sys/@config/multicell_manager/cell_descriptors/11/roles")
+	roles := []ytv1.MasterCellRole{ytv1.MasterCellRoleCypressNodeHost, ytv1.MasterCellRoleChunkHost}
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage: "ytsaurus/ytsaurus:latest",
+				},
+				SecondaryMasters: []ytv1.MastersSpec{
+					{
+						InstanceSpec:         ytv1.InstanceSpec{InstanceCount: 1},
+						MasterConnectionSpec: ytv1.MasterConnectionSpec{CellTag: 11},
+						CellRoles:            roles,
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+	})
+
+	newSecondaryMaster := func() *SecondaryMaster {
+		scheme := runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, client, record.NewFakeRecorder(100), scheme)
+		cfgen := ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain")
+		master := NewSecondaryMaster(cfgen, ytsaurus, NewFakeYtsaurusClient(mockYtClient), &ytsaurusSpec.Spec.SecondaryMasters[0])
+		master.server = NewFakeServer()
+		return master
+	}
+
+	It("SecondaryMaster Sync; cell roles are set", func() {
+		ctx := context.Background()
+		master := newSecondaryMaster()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(rolesPath), gomock.Nil()).Return(false, nil).Times(2)
+		status, err := master.Status(ctx)
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusPending))
+
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(rolesPath), gomock.Eq(roles), gomock.Eq(&yt.SetNodeOptions{Recursive: true})).
+			Return(nil)
+		Expect(master.Sync(ctx)).Should(Succeed())
+	})
+
+	It("SecondaryMaster Status; cell roles are not rewritten", func() {
+		master := newSecondaryMaster()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(rolesPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(rolesPath), gomock.Any(), gomock.Nil()).
+			SetArg(2, roles).
+			Return(nil)
+		status, err := master.Status(context.Background())
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusReady))
+	})
+
+	It("SecondaryMaster Status; cell roles are not synced during the update", func() {
+		ytsaurusSpec.Status.State = ytv1.ClusterStateUpdating
+		master := newSecondaryMaster()
+
+		status, err := master.Status(context.Background())
+		Expect(err).Should(Succeed())
+		Expect(status.SyncStatus).Should(Equal(SyncStatusReady))
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"path"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -33,6 +34,8 @@ type server interface {
 	configNeedsReload() bool
 	needBuild() bool
 	needSync() bool
+	currentReplicas() int32
+	removeResources(ctx context.Context) error
 	buildStatefulSet() *appsv1.StatefulSet
 	rebuildStatefulSet() *appsv1.StatefulSet
 }
@@ -50,9 +53,12 @@ type serverImpl struct {
 	statefulSet       *resources.StatefulSet
 	headlessService   *resources.HeadlessService
 	monitoringService *resources.MonitoringService
+	serviceMonitor    *resources.ServiceMonitor
 	caBundle          *resources.CABundle
-	tlsSecret         *resources.TLSSecret
+	tlsSecrets        []*resources.TLSSecret
 	configHelper      *ConfigHelper
+	// canaryConfigHelper keeps configs of canary pods while the main config map is used by the rest of pods.
+	canaryConfigHelper *ConfigHelper
 
 	builtStatefulSet *appsv1.StatefulSet
 
@@ -102,17 +108,15 @@ func newServerConfigured(
 		caBundle = resources.NewCABundle(caBundleSpec.Name, consts.CABundleVolumeName, consts.CABundleMountPoint)
 	}
 
-	var tlsSecret *resources.TLSSecret
-	transportSpec := instanceSpec.NativeTransport
-	if transportSpec == nil {
-		// FIXME(khlebnikov): do not mount common bus secret into all servers
-		transportSpec = commonSpec.NativeTransport
-	}
+	var tlsSecrets []*resources.TLSSecret
+	// FIXME(khlebnikov): do not mount common bus secret into all servers
+	transportSpec := ytconfig.GetNativeTransport(&commonSpec, instanceSpec.NativeTransport)
 	if transportSpec != nil && transportSpec.TLSSecret != nil {
-		tlsSecret = resources.NewTLSSecret(
+		tlsSecrets = append(tlsSecrets, resources.NewTLSSecret(
 			transportSpec.TLSSecret.Name,
 			consts.BusSecretVolumeName,
-			consts.BusSecretMountPoint)
+			consts.BusSecretMountPoint,
+			proxy))
 	}
 
 	opts := &options{
@@ -131,6 +135,18 @@ func newServerConfigured(
 		fn(opts)
 	}
 
+	configHelper := NewConfigHelper(
+		l,
+		proxy,
+		l.GetMainConfigMapName(),
+		commonSpec.ConfigOverrides,
+		map[string]ytconfig.GeneratorDescriptor{
+			configFileName: {
+				F:   generator,
+				Fmt: ytconfig.ConfigFormatYson,
+			},
+		})
+
 	return &serverImpl{
 		labeller:     l,
 		image:        image,
@@ -154,19 +170,11 @@ func newServerConfigured(
 			l,
 			proxy,
 		),
-		caBundle:  caBundle,
-		tlsSecret: tlsSecret,
-		configHelper: NewConfigHelper(
-			l,
-			proxy,
-			l.GetMainConfigMapName(),
-			commonSpec.ConfigOverrides,
-			map[string]ytconfig.GeneratorDescriptor{
-				configFileName: {
-					F:   generator,
-					Fmt: ytconfig.ConfigFormatYson,
-				},
-			}),
+		serviceMonitor:     resources.NewServiceMonitor(commonSpec.Monitoring, l, proxy),
+		caBundle:           caBundle,
+		tlsSecrets:         append(tlsSecrets, opts.tlsSecrets...),
+		configHelper:       configHelper,
+		canaryConfigHelper: configHelper.NewCanaryConfigHelper(),
 
 		componentContainerPorts: opts.containerPorts,
 
@@ -176,11 +184,18 @@ func newServerConfigured(
 }
 
 func (s *serverImpl) Fetch(ctx context.Context) error {
+	for _, tlsSecret := range s.tlsSecrets {
+		if err := tlsSecret.Fetch(ctx); err != nil {
+			return err
+		}
+	}
 	return resources.Fetch(ctx,
 		s.statefulSet,
 		s.configHelper,
+		s.canaryConfigHelper,
 		s.headlessService,
 		s.monitoringService,
+		s.serviceMonitor,
 	)
 }
 
@@ -190,8 +205,16 @@ func (s *serverImpl) exists() bool {
 		resources.Exists(s.monitoringService)
 }
 
+// getPodsConfigHelper returns the helper of configs of pods which get changes of the spec.
+func (s *serverImpl) getPodsConfigHelper() *ConfigHelper {
+	if s.isCanaryUpdate() {
+		return s.canaryConfigHelper
+	}
+	return s.configHelper
+}
+
 func (s *serverImpl) configNeedsReload() bool {
-	needReload, err := s.configHelper.NeedReload()
+	needReload, err := s.getPodsConfigHelper().NeedReload()
 	if err != nil {
 		needReload = false
 	}
@@ -199,9 +222,12 @@ func (s *serverImpl) configNeedsReload() bool {
 }
 
 func (s *serverImpl) needBuild() bool {
-	return s.configHelper.NeedInit() ||
+	return s.getPodsConfigHelper().NeedInit() ||
 		!s.exists() ||
-		s.statefulSet.NeedSync(s.instanceSpec.InstanceCount)
+		s.statefulSet.NeedSync(s.instanceSpec.InstanceCount) ||
+		s.serviceMonitor.NeedSync() ||
+		s.needCanarySync() ||
+		s.needCertificatesSync()
 }
 
 func (s *serverImpl) needSync() bool {
@@ -212,14 +238,71 @@ func (s *serverImpl) Sync(ctx context.Context) error {
 	_ = s.configHelper.Build()
 	_ = s.headlessService.Build()
 	_ = s.monitoringService.Build()
+	_ = s.serviceMonitor.Build()
 	_ = s.buildStatefulSet()
 
-	return resources.Sync(ctx,
+	configHelper := s.configHelper
+	if s.isCanaryUpdate() {
+		// The main config map keeps the current configs for pods which are not canary.
+		_ = s.canaryConfigHelper.Build()
+		if !s.configHelper.NeedInit() {
+			configHelper = s.canaryConfigHelper
+		} else if err := s.canaryConfigHelper.Sync(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := resources.Sync(ctx,
 		s.statefulSet,
-		s.configHelper,
+		configHelper,
 		s.headlessService,
 		s.monitoringService,
-	)
+		s.serviceMonitor,
+	); err != nil {
+		return err
+	}
+
+	if !s.isCanaryUpdate() && !s.isCanaryConfigUsed() {
+		return s.canaryConfigHelper.RemoveIfExists(ctx)
+	}
+	return nil
+}
+
+// isCanaryConfigUsed checks that the current pod template still mounts the config map of canary pods.
+func (s *serverImpl) isCanaryConfigUsed() bool {
+	if !resources.Exists(s.statefulSet) {
+		return false
+	}
+	for _, volume := range s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template.Spec.Volumes {
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == s.canaryConfigHelper.GetConfigMapName() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *serverImpl) currentReplicas() int32 {
+	if !resources.Exists(s.statefulSet) {
+		return 0
+	}
+	return ptr.Deref(s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Replicas, 0)
+}
+
+// removeResources deletes the statefulset, services and config of the instance group removed from the spec.
+func (s *serverImpl) removeResources(ctx context.Context) error {
+	objects := []resources.Resource{s.statefulSet, s.headlessService, s.monitoringService, s.serviceMonitor}
+	for _, object := range objects {
+		if !resources.Exists(object) {
+			continue
+		}
+		if err := s.proxy.DeleteObject(ctx, object.OldObject()); err != nil {
+			return err
+		}
+	}
+	if err := s.canaryConfigHelper.RemoveIfExists(ctx); err != nil {
+		return err
+	}
+	return s.configHelper.RemoveIfExists(ctx)
 }
 
 func (s *serverImpl) arePodsRemoved(ctx context.Context) bool {
@@ -231,7 +314,12 @@ func (s *serverImpl) arePodsRemoved(ctx context.Context) bool {
 }
 
 func (s *serverImpl) podsImageCorrespondsToSpec() bool {
-	return s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template.Spec.Containers[0].Image == s.image
+	statefulSet := s.statefulSet.OldObject().(*appsv1.StatefulSet)
+	if statefulSet.Spec.Template.Spec.Containers[0].Image != s.image {
+		return false
+	}
+	// After promotion of the canary update the rest of pods still run the previous version.
+	return s.isCanaryUpdate() || getStatefulSetPartition(statefulSet) == 0
 }
 
 func (s *serverImpl) needUpdate() bool {
@@ -239,10 +327,25 @@ func (s *serverImpl) needUpdate() bool {
 		return false
 	}
 
+	if s.isCanaryUpdate() {
+		// Changes are rolled out to canary pods without cluster update.
+		return false
+	}
+
 	if !s.podsImageCorrespondsToSpec() {
 		return true
 	}
 
+	if s.userTLSSecretsChanged() {
+		// Restart with user-provided certificates is not known to be safe, it goes through the cluster update.
+		return true
+	}
+
+	if s.isRollingUpdate() && s.tlsSecretsChanged() {
+		// Pods with OnDelete strategy are rolled by the operator, otherwise see needCertificatesSync.
+		return true
+	}
+
 	needReload, err := s.configHelper.NeedReload()
 	if err != nil {
 		return false
@@ -250,6 +353,34 @@ func (s *serverImpl) needUpdate() bool {
 	return needReload
 }
 
+// tlsSecretsChanged checks that mounted TLS secrets were changed since pods creation, e.g. certificates were renewed.
+func (s *serverImpl) tlsSecretsChanged() bool {
+	podTemplate := &s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template
+	for _, tlsSecret := range s.tlsSecrets {
+		if tlsSecret.IsVersionChanged(podTemplate) {
+			return true
+		}
+	}
+	return false
+}
+
+// userTLSSecretsChanged checks that mounted TLS secrets which are not issued by the operator were changed since pods creation.
+func (s *serverImpl) userTLSSecretsChanged() bool {
+	podTemplate := &s.statefulSet.OldObject().(*appsv1.StatefulSet).Spec.Template
+	for _, tlsSecret := range s.tlsSecrets {
+		if !isManagedTLSSecret(tlsSecret) && tlsSecret.IsVersionChanged(podTemplate) {
+			return true
+		}
+	}
+	return false
+}
+
+// isManagedTLSSecret checks that the secret is issued by the operator, see Certificates.
+func isManagedTLSSecret(tlsSecret *resources.TLSSecret) bool {
+	return tlsSecret.SecretName == consts.NativeTransportCertificateSecretName ||
+		tlsSecret.SecretName == consts.HTTPSCertificateSecretName
+}
+
 func (s *serverImpl) arePodsReady(ctx context.Context) bool {
 	return s.statefulSet.ArePodsReady(ctx, s.instanceSpec.MinReadyInstanceCount)
 }
@@ -265,7 +396,8 @@ func (s *serverImpl) buildStatefulSet() *appsv1.StatefulSet {
 func (s *serverImpl) rebuildStatefulSet() *appsv1.StatefulSet {
 	locationCreationCommand := getLocationInitCommand(s.instanceSpec.Locations)
 
-	volumes := createServerVolumes(s.instanceSpec.Volumes, s.labeller.GetMainConfigMapName())
+	// Canary pods mount their own config map, so the rest of pods keep reading the current configs.
+	volumes := createServerVolumes(s.instanceSpec.Volumes, s.getPodsConfigHelper().GetConfigMapName())
 	volumeMounts := createVolumeMounts(s.instanceSpec.VolumeMounts)
 
 	statefulSet := s.statefulSet.Build()
@@ -279,6 +411,20 @@ func (s *serverImpl) rebuildStatefulSet() *appsv1.StatefulSet {
 	}
 
 	statefulSet.Spec.Replicas = &s.instanceSpec.InstanceCount
+	if s.isCanaryUpdate() {
+		// Pods with ordinals less than partition keep the previous version.
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: ptr.To(s.getCanaryPartition()),
+			},
+		}
+	} else if s.isRollingUpdate() {
+		// Pods are replaced by the operator batch by batch.
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}
+	}
 	statefulSet.Spec.ServiceName = s.headlessService.Name()
 	statefulSet.Spec.VolumeClaimTemplates = createVolumeClaims(s.instanceSpec.VolumeClaimTemplates)
 
@@ -365,9 +511,17 @@ func (s *serverImpl) rebuildStatefulSet() *appsv1.StatefulSet {
 		}
 	}
 
-	if s.tlsSecret != nil {
-		s.tlsSecret.AddVolume(&statefulSet.Spec.Template.Spec)
-		s.tlsSecret.AddVolumeMount(&statefulSet.Spec.Template.Spec.Containers[0])
+	for _, tlsSecret := range s.tlsSecrets {
+		tlsSecret.AddVolume(&statefulSet.Spec.Template.Spec)
+		tlsSecret.AddVolumeMount(&statefulSet.Spec.Template.Spec.Containers[0])
+		tlsSecret.AddVersionAnnotation(&statefulSet.Spec.Template)
+	}
+
+	if s.isCanaryUpdate() {
+		// Configs are read on pod start, so canary pods are restarted with the changed template once their configs change.
+		if version := s.canaryConfigHelper.GetConfigVersion(); version != "" {
+			metav1.SetMetaDataAnnotation(&statefulSet.Spec.Template.ObjectMeta, consts.ConfigVersionAnnotation, version)
+		}
 	}
 
 	s.builtStatefulSet = statefulSet
@@ -379,3 +533,60 @@ func (s *serverImpl) removePods(ctx context.Context) error {
 	ss.Spec.Replicas = ptr.To(int32(0))
 	return s.Sync(ctx)
 }
+
+func (s *serverImpl) isCanaryUpdate() bool {
+	return s.instanceSpec.Canary != nil
+}
+
+// getCanaryPartition returns the ordinal starting from which pods get the new version.
+func (s *serverImpl) getCanaryPartition() int32 {
+	return max(s.instanceSpec.InstanceCount-s.instanceSpec.Canary.InstanceCount, 0)
+}
+
+func getStatefulSetPartition(statefulSet *appsv1.StatefulSet) int32 {
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType || rollingUpdate == nil {
+		return 0
+	}
+	return ptr.Deref(rollingUpdate.Partition, 0)
+}
+
+// needCanarySync checks that canary pods have to be updated, changes are applied to the statefulset
+// and the statefulset controller replaces pods starting from the canary partition.
+func (s *serverImpl) needCanarySync() bool {
+	if !s.isCanaryUpdate() || !s.exists() {
+		return false
+	}
+	statefulSet := s.statefulSet.OldObject().(*appsv1.StatefulSet)
+	return statefulSet.Spec.Template.Spec.Containers[0].Image != s.image ||
+		getStatefulSetPartition(statefulSet) != s.getCanaryPartition() ||
+		s.tlsSecretsChanged() ||
+		s.configNeedsReload()
+}
+
+// needCertificatesSync checks that only certificates issued by the operator were renewed, such changes are applied
+// to the statefulset and the statefulset controller restarts pods one by one without cluster update.
+func (s *serverImpl) needCertificatesSync() bool {
+	if s.isCanaryUpdate() || s.isRollingUpdate() || !s.exists() {
+		return false
+	}
+	return s.tlsSecretsChanged() &&
+		!s.userTLSSecretsChanged() &&
+		s.podsImageCorrespondsToSpec() &&
+		!s.configNeedsReload()
+}
+
+func (s *serverImpl) isRollingUpdate() bool {
+	strategy := s.instanceSpec.UpdateStrategy
+	return strategy != nil && strategy.Type == ytv1.ComponentUpdateStrategyTypeRolling
+}
+
+func (s *serverImpl) rollPods(ctx context.Context, updateStartedAt time.Time) (bool, error) {
+	batchSize := int32(1)
+	var maxUnavailable *int32
+	if rollingUpdate := s.instanceSpec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		batchSize = ptr.Deref(rollingUpdate.BatchSize, batchSize)
+		maxUnavailable = rollingUpdate.MaxUnavailable
+	}
+	return s.statefulSet.RollPods(ctx, updateStartedAt, batchSize, ptr.Deref(maxUnavailable, batchSize))
+}
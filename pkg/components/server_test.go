@@ -0,0 +1,259 @@
+package components
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Server canary update test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:23.2",
+					UseShortNames: true,
+				},
+				HTTPProxies: []ytv1.HTTPProxiesSpec{
+					{
+						InstanceSpec: ytv1.InstanceSpec{InstanceCount: 3},
+						Role:         consts.DefaultHTTPProxyRole,
+					},
+				},
+			},
+		}
+	})
+
+	It("Canary update; rollout, promotion and abort", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		newServer := func(resource *ytv1.Ytsaurus) *serverImpl {
+			ytsaurus := apiproxy.NewYtsaurus(resource, k8sClient, record.NewFakeRecorder(100), scheme)
+			cfgen := ytconfig.NewGenerator(resource, "cluster_domain")
+			hp := NewHTTPProxy(cfgen, ytsaurus, NewMaster(cfgen, ytsaurus), resource.Spec.HTTPProxies[0])
+			Expect(hp.Fetch(ctx)).Should(Succeed())
+			return hp.server.(*serverImpl)
+		}
+		getStatefulSet := func() *appsv1.StatefulSet {
+			statefulSet := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "hp"}, statefulSet)).Should(Succeed())
+			return statefulSet
+		}
+
+		Expect(newServer(ytsaurusSpec.DeepCopy()).Sync(ctx)).Should(Succeed())
+
+		By("New image is applied to canary pods only")
+		canarySpec := ytsaurusSpec.DeepCopy()
+		canarySpec.Spec.HTTPProxies[0].Image = ptr.To("ytsaurus/ytsaurus:24.1")
+		canarySpec.Spec.HTTPProxies[0].Canary = &ytv1.CanaryUpdateSpec{InstanceCount: 1}
+		server := newServer(canarySpec)
+		Expect(server.needUpdate()).Should(BeFalse())
+		Expect(server.needBuild()).Should(BeTrue())
+		Expect(server.Sync(ctx)).Should(Succeed())
+
+		statefulSet := getStatefulSet()
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].Image).Should(Equal("ytsaurus/ytsaurus:24.1"))
+		Expect(statefulSet.Spec.UpdateStrategy.Type).Should(Equal(appsv1.RollingUpdateStatefulSetStrategyType))
+		Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).Should(Equal(ptr.To(int32(2))))
+
+		server = newServer(canarySpec)
+		Expect(server.needUpdate()).Should(BeFalse())
+		Expect(server.needBuild()).Should(BeFalse())
+
+		By("Reverted image aborts the canary")
+		abortSpec := canarySpec.DeepCopy()
+		abortSpec.Spec.HTTPProxies[0].Image = nil
+		server = newServer(abortSpec)
+		Expect(server.needUpdate()).Should(BeFalse())
+		Expect(server.needBuild()).Should(BeTrue())
+
+		By("Removed canary promotes the update to the rest of pods")
+		promoteSpec := canarySpec.DeepCopy()
+		promoteSpec.Spec.HTTPProxies[0].Canary = nil
+		server = newServer(promoteSpec)
+		Expect(server.needUpdate()).Should(BeTrue())
+		Expect(server.removePods(ctx)).Should(Succeed())
+
+		statefulSet = getStatefulSet()
+		Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate).Should(BeNil())
+		Expect(newServer(promoteSpec).needUpdate()).Should(BeFalse())
+	})
+
+	It("Canary update; config changes restart canary pods only", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec).Build()
+		newServer := func(resource *ytv1.Ytsaurus) *serverImpl {
+			ytsaurus := apiproxy.NewYtsaurus(resource, k8sClient, record.NewFakeRecorder(100), scheme)
+			cfgen := ytconfig.NewGenerator(resource, "cluster_domain")
+			hp := NewHTTPProxy(cfgen, ytsaurus, NewMaster(cfgen, ytsaurus), resource.Spec.HTTPProxies[0])
+			Expect(hp.Fetch(ctx)).Should(Succeed())
+			return hp.server.(*serverImpl)
+		}
+		getStatefulSet := func() *appsv1.StatefulSet {
+			statefulSet := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "hp"}, statefulSet)).Should(Succeed())
+			return statefulSet
+		}
+
+		getConfigMap := func(name string) *corev1.ConfigMap {
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, configMap)).Should(Succeed())
+			return configMap
+		}
+		getConfigMapVolume := func(statefulSet *appsv1.StatefulSet) string {
+			for _, volume := range statefulSet.Spec.Template.Spec.Volumes {
+				if volume.Name == consts.ConfigTemplateVolumeName {
+					return volume.ConfigMap.Name
+				}
+			}
+			return ""
+		}
+
+		initial := newServer(ytsaurusSpec.DeepCopy())
+		Expect(initial.Sync(ctx)).Should(Succeed())
+		Expect(getStatefulSet().Spec.Template.Annotations).ShouldNot(HaveKey(consts.ConfigVersionAnnotation))
+		mainConfigMapName := initial.configHelper.GetConfigMapName()
+		canaryConfigMapName := initial.canaryConfigHelper.GetConfigMapName()
+		mainConfigs := getConfigMap(mainConfigMapName).Data
+
+		canarySpec := ytsaurusSpec.DeepCopy()
+		canarySpec.Spec.HTTPProxies[0].Image = ptr.To(ytsaurusSpec.Spec.CoreImage)
+		canarySpec.Spec.HTTPProxies[0].Canary = &ytv1.CanaryUpdateSpec{InstanceCount: 1}
+		canarySpec.Spec.HTTPProxies[0].Loggers = []ytv1.TextLoggerSpec{
+			{
+				BaseLoggerSpec: ytv1.BaseLoggerSpec{Name: "debug", MinLogLevel: ytv1.LogLevelDebug},
+				WriterType:     ytv1.LogWriterTypeStderr,
+			},
+		}
+		server := newServer(canarySpec)
+		Expect(server.needUpdate()).Should(BeFalse())
+		Expect(server.needBuild()).Should(BeTrue())
+		Expect(server.Sync(ctx)).Should(Succeed())
+
+		statefulSet := getStatefulSet()
+		Expect(statefulSet.Spec.Template.Annotations).Should(HaveKey(consts.ConfigVersionAnnotation))
+		Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).Should(Equal(ptr.To(int32(2))))
+
+		By("Pods which are not canary keep reading the previous configs")
+		Expect(getConfigMapVolume(statefulSet)).Should(Equal(canaryConfigMapName))
+		Expect(getConfigMap(mainConfigMapName).Data).Should(Equal(mainConfigs))
+		Expect(getConfigMap(canaryConfigMapName).Data).ShouldNot(Equal(mainConfigs))
+		configVersion := statefulSet.Spec.Template.Annotations[consts.ConfigVersionAnnotation]
+		Expect(newServer(canarySpec).needBuild()).Should(BeFalse())
+
+		By("Reverted config restarts canary pods with the previous config")
+		abortSpec := canarySpec.DeepCopy()
+		abortSpec.Spec.HTTPProxies[0].Loggers = nil
+		server = newServer(abortSpec)
+		Expect(server.needBuild()).Should(BeTrue())
+		Expect(server.Sync(ctx)).Should(Succeed())
+		Expect(getStatefulSet().Spec.Template.Annotations[consts.ConfigVersionAnnotation]).ShouldNot(Equal(configVersion))
+		Expect(getConfigMap(canaryConfigMapName).Data).Should(Equal(mainConfigs))
+
+		By("Config map of canary pods is removed once the template does not use it")
+		promoteSpec := abortSpec.DeepCopy()
+		promoteSpec.Spec.HTTPProxies[0].Canary = nil
+		Expect(newServer(promoteSpec).Sync(ctx)).Should(Succeed())
+		Expect(getConfigMapVolume(getStatefulSet())).Should(Equal(mainConfigMapName))
+		Expect(newServer(promoteSpec).Sync(ctx)).Should(Succeed())
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: canaryConfigMapName}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+	})
+
+	It("Renewed certificates are rolled out without cluster update", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.NativeTransport = &ytv1.RPCTransportSpec{
+			TLSSecret: &corev1.LocalObjectReference{Name: consts.NativeTransportCertificateSecretName},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: consts.NativeTransportCertificateSecretName, Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec, secret).Build()
+		newServer := func(resource *ytv1.Ytsaurus) *serverImpl {
+			ytsaurus := apiproxy.NewYtsaurus(resource, k8sClient, record.NewFakeRecorder(100), scheme)
+			cfgen := ytconfig.NewGenerator(resource, "cluster_domain")
+			hp := NewHTTPProxy(cfgen, ytsaurus, NewMaster(cfgen, ytsaurus), resource.Spec.HTTPProxies[0])
+			Expect(hp.Fetch(ctx)).Should(Succeed())
+			return hp.server.(*serverImpl)
+		}
+
+		Expect(newServer(ytsaurusSpec.DeepCopy()).Sync(ctx)).Should(Succeed())
+		Expect(newServer(ytsaurusSpec.DeepCopy()).needBuild()).Should(BeFalse())
+
+		By("Renewed certificate is applied to the statefulset")
+		secret.Data[corev1.TLSCertKey] = []byte("renewed cert")
+		Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+		server := newServer(ytsaurusSpec.DeepCopy())
+		Expect(server.needUpdate()).Should(BeFalse())
+		Expect(server.needBuild()).Should(BeTrue())
+
+		By("Pods with OnDelete strategy are rolled by the cluster update")
+		rollingSpec := ytsaurusSpec.DeepCopy()
+		rollingSpec.Spec.HTTPProxies[0].UpdateStrategy = &ytv1.ComponentUpdateStrategy{
+			Type: ytv1.ComponentUpdateStrategyTypeRolling,
+		}
+		Expect(newServer(rollingSpec).needUpdate()).Should(BeTrue())
+
+		Expect(server.Sync(ctx)).Should(Succeed())
+		server = newServer(ytsaurusSpec.DeepCopy())
+		Expect(server.needUpdate()).Should(BeFalse())
+		Expect(server.needBuild()).Should(BeFalse())
+	})
+
+	It("Changed user-provided certificates are rolled out by the cluster update", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Spec.NativeTransport = &ytv1.RPCTransportSpec{
+			TLSSecret: &corev1.LocalObjectReference{Name: "native-tls"},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "native-tls", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurusSpec, secret).Build()
+		newServer := func(resource *ytv1.Ytsaurus) *serverImpl {
+			ytsaurus := apiproxy.NewYtsaurus(resource, k8sClient, record.NewFakeRecorder(100), scheme)
+			cfgen := ytconfig.NewGenerator(resource, "cluster_domain")
+			hp := NewHTTPProxy(cfgen, ytsaurus, NewMaster(cfgen, ytsaurus), resource.Spec.HTTPProxies[0])
+			Expect(hp.Fetch(ctx)).Should(Succeed())
+			return hp.server.(*serverImpl)
+		}
+
+		Expect(newServer(ytsaurusSpec.DeepCopy()).Sync(ctx)).Should(Succeed())
+
+		secret.Data[corev1.TLSCertKey] = []byte("changed cert")
+		Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+		server := newServer(ytsaurusSpec.DeepCopy())
+		Expect(server.needUpdate()).Should(BeTrue())
+		Expect(server.needCertificatesSync()).Should(BeFalse())
+	})
+})
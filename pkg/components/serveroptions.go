@@ -3,6 +3,8 @@ package components
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
 )
 
 type options struct {
@@ -10,6 +12,8 @@ type options struct {
 
 	readinessProbeEndpointPort intstr.IntOrString
 	readinessProbeEndpointPath string
+
+	tlsSecrets []*resources.TLSSecret
 }
 
 type Option func(opts *options)
@@ -31,3 +35,12 @@ func WithContainerPorts(ports ...corev1.ContainerPort) Option {
 		opts.containerPorts = append(opts.containerPorts, ports...)
 	}
 }
+
+// WithTLSSecret mounts TLS secret into the server container, pods are restarted when the secret changes.
+func WithTLSSecret(secret *resources.TLSSecret) Option {
+	return func(opts *options) {
+		if secret != nil {
+			opts.tlsSecrets = append(opts.tlsSecrets, secret)
+		}
+	}
+}
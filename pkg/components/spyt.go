@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 
+	"go.ytsaurus.tech/yt/go/ypath"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 
@@ -26,6 +28,7 @@ type Spyt struct {
 
 	initUser        *InitJob
 	initEnvironment *InitJob
+	cleanup         *InitJob
 }
 
 func NewSpyt(
@@ -63,6 +66,15 @@ func NewSpyt(
 			consts.ClientConfigFileName,
 			spyt.GetResource().Spec.Image,
 			cfgen.GetNativeClientConfig),
+		cleanup: NewInitJob(
+			&l,
+			spyt.APIProxy(),
+			spyt,
+			ytsaurus.Spec.ImagePullSecrets,
+			"cleanup",
+			consts.ClientConfigFileName,
+			ytsaurus.Spec.CoreImage,
+			cfgen.GetNativeClientConfig),
 		secret: resources.NewStringSecret(
 			l.GetSecretName(),
 			&l,
@@ -70,6 +82,12 @@ func NewSpyt(
 	}
 }
 
+// spytReleaseDirs are Cypress directories which SPYT releases publish their versions into.
+var spytReleaseDirs = []ypath.Path{
+	"//home/spark/spyt/releases",
+	"//home/spark/conf/releases",
+}
+
 func (s *Spyt) createInitUserScript() string {
 	token, _ := s.secret.GetValue(consts.TokenSecretKey)
 	commands := createUserCommand("spyt_releaser", "", token, true)
@@ -82,11 +100,49 @@ func (s *Spyt) createInitUserScript() string {
 }
 
 func (s *Spyt) createInitScript() string {
-	script := []string{
-		"/entrypoint.sh",
+	return createReleaseScript("/entrypoint.sh", spytReleaseDirs)
+}
+
+// getOtherReleasePaths returns paths of the releases of other spyts of the cluster.
+func (s *Spyt) getOtherReleasePaths(ctx context.Context) ([]string, error) {
+	resource := s.spyt.GetResource()
+	var spytList ytv1.SpytList
+	if err := s.spyt.APIProxy().ListObjects(ctx, &spytList, client.InNamespace(resource.Namespace)); err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, spyt := range spytList.Items {
+		if spyt.Name == resource.Name || spyt.Spec.Ytsaurus == nil || spyt.Spec.Ytsaurus.Name != s.ytsaurus.Name {
+			continue
+		}
+		for _, releasePaths := range spyt.Status.ReleasePaths {
+			paths = append(paths, releasePaths...)
+		}
 	}
+	return paths, nil
+}
 
-	return strings.Join(script, "\n")
+// getRemovedReleasePaths returns paths of the releases of the images to remove from Cypress,
+// paths used by kept releases of this and other spyts are not removed.
+func (s *Spyt) getRemovedReleasePaths(ctx context.Context, images []string) ([]string, error) {
+	otherPaths, err := s.getOtherReleasePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getRemovedReleasePaths(s.spyt.GetResource().Status.ReleasePaths, images, otherPaths), nil
+}
+
+// forgetReleases removes the releases of the images from the status once they are removed from Cypress.
+func (s *Spyt) forgetReleases(images []string) {
+	status := &s.spyt.GetResource().Status
+	for _, image := range images {
+		if _, ok := status.ReleasePaths[image]; !ok {
+			s.spyt.APIProxy().RecordWarning(
+				"Release",
+				fmt.Sprintf("Paths of SPYT release %s are unknown, they must be removed from Cypress manually", image))
+		}
+		delete(status.ReleasePaths, image)
+	}
 }
 
 func (s *Spyt) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
@@ -96,10 +152,6 @@ func (s *Spyt) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
 		return WaitingStatus(SyncStatusBlocked, s.ytsaurus.GetName()), err
 	}
 
-	if s.spyt.GetResource().Status.ReleaseStatus == ytv1.SpytReleaseStatusFinished {
-		return SimpleStatus(SyncStatusReady), err
-	}
-
 	// Create user for spyt initialization.
 	if s.secret.NeedSync(consts.TokenSecretKey, "") {
 		if !dry {
@@ -143,13 +195,70 @@ func (s *Spyt) doSync(ctx context.Context, dry bool) (ComponentStatus, error) {
 		}
 	}
 
+	resource := s.spyt.GetResource()
+	if needReleaseRestart(s.initEnvironment, resource.Status.InstalledImage) {
+		if !dry {
+			err = s.initEnvironment.prepareRestart(ctx, dry)
+		}
+		resource.Status.ReleaseStatus = ytv1.SpytReleaseStatusUploadingIntoCypress
+		return WaitingStatus(SyncStatusPending, "release restart"), err
+	}
+
 	status, err = s.initEnvironment.Sync(ctx, dry)
 	if status.SyncStatus != SyncStatusReady {
-		s.spyt.GetResource().Status.ReleaseStatus = ytv1.SpytReleaseStatusUploadingIntoCypress
+		resource.Status.ReleaseStatus = ytv1.SpytReleaseStatusUploadingIntoCypress
 		return status, err
 	}
 
-	s.spyt.GetResource().Status.ReleaseStatus = ytv1.SpytReleaseStatusFinished
+	if resource.Status.InstalledImage != resource.Spec.Image {
+		resource.Status.ReleaseStatus = ytv1.SpytReleaseStatusUploadingIntoCypress
+		// Published paths are reported by the job, so the release is repeated
+		// if the job has been already removed by its TTL.
+		paths, found, err := getReleaseJobPaths(ctx, s.initEnvironment)
+		if err != nil {
+			return WaitingStatus(SyncStatusPending, "release paths"), err
+		}
+		if !found {
+			err = s.initEnvironment.prepareRestart(ctx, dry)
+			return WaitingStatus(SyncStatusPending, "release restart"), err
+		}
+		if !dry {
+			installRelease(
+				&resource.Status.InstalledImage,
+				&resource.Status.PreviousImages,
+				&resource.Status.ReleasePaths,
+				resource.Spec.Image,
+				paths)
+			s.spyt.APIProxy().RecordNormal("Release", fmt.Sprintf("SPYT release %s is installed", resource.Spec.Image))
+		}
+		return WaitingStatus(SyncStatusPending, "installed image"), err
+	}
+
+	if keep := resource.Spec.KeepPreviousReleases; len(resource.Status.PreviousImages) > int(keep) {
+		resource.Status.ReleaseStatus = ytv1.SpytReleaseStatusCleaningUp
+		images := resource.Status.PreviousImages[keep:]
+		paths, err := s.getRemovedReleasePaths(ctx, images)
+		if err != nil {
+			return WaitingStatus(SyncStatusPending, "cleanup of previous releases"), err
+		}
+		if len(paths) != 0 {
+			if !dry {
+				s.cleanup.SetInitScript(createReleaseCleanupScript(paths))
+			}
+			status, err = s.cleanup.Sync(ctx, dry)
+			if err != nil || status.SyncStatus != SyncStatusReady {
+				return status, err
+			}
+		}
+		if !dry {
+			s.forgetReleases(images)
+			resource.Status.PreviousImages = resource.Status.PreviousImages[:keep]
+			err = s.cleanup.prepareRestart(ctx, dry)
+		}
+		return WaitingStatus(SyncStatusPending, "cleanup of previous releases"), err
+	}
+
+	resource.Status.ReleaseStatus = ytv1.SpytReleaseStatusFinished
 
 	return SimpleStatus(SyncStatusReady), nil
 }
@@ -158,6 +267,7 @@ func (s *Spyt) Fetch(ctx context.Context) error {
 	return resources.Fetch(ctx,
 		s.initUser,
 		s.initEnvironment,
+		s.cleanup,
 		s.secret,
 	)
 }
@@ -175,3 +285,34 @@ func (s *Spyt) Sync(ctx context.Context) error {
 	_, err := s.doSync(ctx, false)
 	return err
 }
+
+// Remove runs the cleanup job removing all kept releases from Cypress, it returns true once they are removed.
+func (s *Spyt) Remove(ctx context.Context) (bool, error) {
+	resource := s.spyt.GetResource()
+	images := resource.Status.PreviousImages
+	if resource.Status.InstalledImage != "" {
+		images = append([]string{resource.Status.InstalledImage}, images...)
+	}
+	if len(images) == 0 {
+		return true, nil
+	}
+
+	if s.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		return false, nil
+	}
+
+	resource.Status.ReleaseStatus = ytv1.SpytReleaseStatusCleaningUp
+	paths, err := s.getRemovedReleasePaths(ctx, images)
+	if err != nil {
+		return false, err
+	}
+	if len(paths) != 0 {
+		s.cleanup.SetInitScript(createReleaseCleanupScript(paths))
+		status, err := s.cleanup.Sync(ctx, false)
+		if err != nil || status.SyncStatus != SyncStatusReady {
+			return false, err
+		}
+	}
+	s.forgetReleases(images)
+	return true, nil
+}
@@ -0,0 +1,225 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Spyt release test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var spytSpec *ytv1.Spyt
+	var releaserSecret *corev1.Secret
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		spytSpec = &ytv1.Spyt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "spyt",
+				Namespace: "default",
+			},
+			Spec: ytv1.SpytSpec{
+				Ytsaurus:             &corev1.LocalObjectReference{Name: "ytsaurus"},
+				Image:                "ytsaurus/spyt:1.75.0",
+				KeepPreviousReleases: 1,
+			},
+		}
+
+		releaserSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus-spyt-spyt-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				consts.TokenSecretKey: []byte("token"),
+			},
+		}
+	})
+
+	// getSpytJobReport returns paths reported by the release job, the release is published under the image tag.
+	getSpytJobReport := func(job *batchv1.Job) string {
+		if job.Name != "ytsaurus-spyt-spyt-init-job-spyt-environment" {
+			return ""
+		}
+		_, version, _ := strings.Cut(job.Spec.Template.Spec.Containers[0].Image, ":")
+		if version == "latest" {
+			// The latest image publishes a version which already exists.
+			return ""
+		}
+		return fmt.Sprintf("//home/spark/conf/releases/%s\n//home/spark/spyt/releases/%s\n", version, version)
+	}
+
+	// syncSpyt runs sync until the release is ready, all jobs are completed once they are created.
+	// It returns scripts of cleanup jobs which have been run.
+	syncSpyt := func(ctx context.Context, k8sClient client.Client) (*ytv1.Spyt, []string) {
+		var cleanupScripts []string
+		for i := 0; i < 30; i++ {
+			spyt := &ytv1.Spyt{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(spytSpec), spyt)).Should(Succeed())
+			proxy := apiproxy.NewSpyt(spyt, k8sClient, record.NewFakeRecorder(100), scheme)
+			component := NewSpyt(ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain"), proxy, ytsaurusSpec)
+			Expect(component.Fetch(ctx)).Should(Succeed())
+			if component.Status(ctx).SyncStatus == SyncStatusReady {
+				return spyt, cleanupScripts
+			}
+			Expect(component.Sync(ctx)).Should(Succeed())
+			Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+
+			jobs := &batchv1.JobList{}
+			Expect(k8sClient.List(ctx, jobs)).Should(Succeed())
+			for _, job := range jobs.Items {
+				if job.Status.Succeeded != 0 {
+					continue
+				}
+				if job.Name == "ytsaurus-spyt-spyt-init-job-cleanup" {
+					cm := &corev1.ConfigMap{}
+					key := client.ObjectKey{Namespace: "default", Name: "cleanup-ytsaurus-spyt-spyt-init-job-config"}
+					Expect(k8sClient.Get(ctx, key, cm)).Should(Succeed())
+					cleanupScripts = append(cleanupScripts, cm.Data[consts.InitClusterScriptFileName])
+				}
+				job.Status.Succeeded = 1
+				Expect(k8sClient.Status().Update(ctx, &job)).Should(Succeed())
+				createReleaseJobPod(ctx, k8sClient, job.Name, getSpytJobReport(&job))
+			}
+		}
+		Fail("spyt release is not ready")
+		return nil, nil
+	}
+
+	setImage := func(ctx context.Context, k8sClient client.Client, image string) {
+		spyt := &ytv1.Spyt{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(spytSpec), spyt)).Should(Succeed())
+		spyt.Spec.Image = image
+		Expect(k8sClient.Update(ctx, spyt)).Should(Succeed())
+	}
+
+	It("Spyt image upgrades; release is uploaded again and outdated releases are removed", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(spytSpec, releaserSecret).
+			WithStatusSubresource(spytSpec, &batchv1.Job{}).
+			Build()
+
+		spyt, cleanupScripts := syncSpyt(ctx, k8sClient)
+		Expect(spyt.Status.ReleaseStatus).Should(Equal(ytv1.SpytReleaseStatusFinished))
+		Expect(spyt.Status.InstalledImage).Should(Equal("ytsaurus/spyt:1.75.0"))
+		Expect(spyt.Status.PreviousImages).Should(BeEmpty())
+		Expect(cleanupScripts).Should(BeEmpty())
+
+		setImage(ctx, k8sClient, "ytsaurus/spyt:1.76.0")
+		spyt, cleanupScripts = syncSpyt(ctx, k8sClient)
+		Expect(spyt.Status.InstalledImage).Should(Equal("ytsaurus/spyt:1.76.0"))
+		Expect(spyt.Status.PreviousImages).Should(Equal([]string{"ytsaurus/spyt:1.75.0"}))
+		Expect(cleanupScripts).Should(BeEmpty())
+
+		job := &batchv1.Job{}
+		key := client.ObjectKey{Namespace: "default", Name: "ytsaurus-spyt-spyt-init-job-spyt-environment"}
+		Expect(k8sClient.Get(ctx, key, job)).Should(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Image).Should(Equal("ytsaurus/spyt:1.76.0"))
+
+		setImage(ctx, k8sClient, "ytsaurus/spyt:1.77.0")
+		spyt, cleanupScripts = syncSpyt(ctx, k8sClient)
+		Expect(spyt.Status.InstalledImage).Should(Equal("ytsaurus/spyt:1.77.0"))
+		Expect(spyt.Status.PreviousImages).Should(Equal([]string{"ytsaurus/spyt:1.76.0"}))
+		Expect(cleanupScripts).Should(HaveLen(1))
+		Expect(cleanupScripts[0]).Should(ContainSubstring("/usr/bin/yt remove --force --recursive '//home/spark/spyt/releases/1.75.0'"))
+		Expect(cleanupScripts[0]).Should(ContainSubstring("/usr/bin/yt remove --force --recursive '//home/spark/conf/releases/1.75.0'"))
+		Expect(cleanupScripts[0]).ShouldNot(ContainSubstring("1.76.0"))
+		Expect(spyt.Status.ReleasePaths).Should(Equal(map[string][]string{
+			"ytsaurus/spyt:1.77.0": {"//home/spark/conf/releases/1.77.0", "//home/spark/spyt/releases/1.77.0"},
+			"ytsaurus/spyt:1.76.0": {"//home/spark/conf/releases/1.76.0", "//home/spark/spyt/releases/1.76.0"},
+		}))
+
+		// The latest image publishes nothing new, so its release keeps the paths of the previous ones.
+		setImage(ctx, k8sClient, "ytsaurus/spyt:latest")
+		spyt, cleanupScripts = syncSpyt(ctx, k8sClient)
+		Expect(spyt.Status.InstalledImage).Should(Equal("ytsaurus/spyt:latest"))
+		Expect(spyt.Status.PreviousImages).Should(Equal([]string{"ytsaurus/spyt:1.77.0"}))
+		Expect(cleanupScripts).Should(BeEmpty())
+		Expect(spyt.Status.ReleasePaths).Should(HaveKey("ytsaurus/spyt:latest"))
+		Expect(spyt.Status.ReleasePaths["ytsaurus/spyt:latest"]).Should(ContainElement("//home/spark/spyt/releases/1.76.0"))
+		Expect(spyt.Status.ReleasePaths).ShouldNot(HaveKey("ytsaurus/spyt:1.76.0"))
+	})
+
+	It("Spyt remove; kept releases are removed unless other spyts use them", func() {
+		ctx := context.Background()
+		spytSpec.Status = ytv1.SpytStatus{
+			ReleaseStatus:  ytv1.SpytReleaseStatusFinished,
+			InstalledImage: "ytsaurus/spyt:1.76.0",
+			PreviousImages: []string{"ytsaurus/spyt:1.75.0"},
+			ReleasePaths: map[string][]string{
+				"ytsaurus/spyt:1.76.0": {"//home/spark/spyt/releases/1.76.0"},
+				"ytsaurus/spyt:1.75.0": {"//home/spark/spyt/releases/1.75.0"},
+			},
+		}
+		// Another spyt of the cluster uses the release 1.75.0.
+		otherSpyt := &ytv1.Spyt{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-spyt", Namespace: "default"},
+			Spec: ytv1.SpytSpec{
+				Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				Image:    "ytsaurus/spyt:1.75.0",
+			},
+			Status: ytv1.SpytStatus{
+				InstalledImage: "ytsaurus/spyt:1.75.0",
+				ReleasePaths: map[string][]string{
+					"ytsaurus/spyt:1.75.0": {"//home/spark/spyt/releases/1.75.0"},
+				},
+			},
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(spytSpec, otherSpyt, releaserSecret).
+			WithStatusSubresource(spytSpec, &batchv1.Job{}).
+			Build()
+
+		proxy := apiproxy.NewSpyt(spytSpec, k8sClient, record.NewFakeRecorder(100), scheme)
+		component := NewSpyt(ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain"), proxy, ytsaurusSpec)
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		Expect(component.Remove(ctx)).Should(BeFalse())
+
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: "default", Name: "cleanup-ytsaurus-spyt-spyt-init-job-config"}
+		Expect(k8sClient.Get(ctx, key, cm)).Should(Succeed())
+		script := cm.Data[consts.InitClusterScriptFileName]
+		Expect(script).Should(ContainSubstring("'//home/spark/spyt/releases/1.76.0'"))
+		Expect(script).ShouldNot(ContainSubstring("1.75.0"))
+		Expect(spytSpec.Status.ReleaseStatus).Should(Equal(ytv1.SpytReleaseStatusCleaningUp))
+	})
+})
@@ -0,0 +1,88 @@
+package components
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// strawberryAPI executes commands of the strawberry controller for a family of operations, e.g. chyt.
+type strawberryAPI interface {
+	Execute(ctx context.Context, command string, params map[string]any, result any) error
+}
+
+// strawberryOpletBriefInfo is a part of get_brief_info result which is reflected in statuses.
+type strawberryOpletBriefInfo struct {
+	State        string `json:"state"`
+	Health       string `json:"health"`
+	HealthReason string `json:"health_reason"`
+	YTOperation  struct {
+		ID    string `json:"id"`
+		State string `json:"state"`
+	} `json:"yt_operation"`
+}
+
+// httpStrawberryAPI executes commands via the HTTP API of the strawberry controller.
+type httpStrawberryAPI struct {
+	url   string
+	token string
+}
+
+func newHTTPStrawberryAPI(cfgen *ytconfig.Generator, cluster, family, token string) *httpStrawberryAPI {
+	return &httpStrawberryAPI{
+		url: fmt.Sprintf("http://%s:%d/%s/%s",
+			cfgen.GetStrawberryControllerServiceAddress(), consts.StrawberryHTTPAPIPort, cluster, family),
+		token: token,
+	}
+}
+
+func (a *httpStrawberryAPI) Execute(ctx context.Context, command string, params map[string]any, result any) error {
+	body, err := json.Marshal(map[string]any{"params": params})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url+"/"+command, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "OAuth "+a.token)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		var failure struct {
+			ToPrint string `json:"to_print"`
+		}
+		if json.Unmarshal(responseBody, &failure) == nil && failure.ToPrint != "" {
+			return fmt.Errorf("strawberry command %s failed: %s", command, failure.ToPrint)
+		}
+		return fmt.Errorf("strawberry command %s failed with status %s: %s", command, response.Status, responseBody)
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(responseBody, &struct {
+		Result any `json:"result"`
+	}{Result: result})
+}
@@ -298,6 +298,20 @@ func (c *StrawberryController) doSync(ctx context.Context, dry bool) (ComponentS
 		return status, err
 	}
 
+	// Families added to the spec are registered in Cypress by running the job again.
+	if c.initChytClusterJob.IsCompleted() {
+		needReload, err := c.initChytClusterJob.configHelper.NeedReload()
+		if err != nil {
+			return WaitingStatus(SyncStatusPending, "init cluster config"), err
+		}
+		if needReload {
+			if !dry {
+				err = c.initChytClusterJob.prepareRestart(ctx, dry)
+			}
+			return WaitingStatus(SyncStatusPending, "init cluster job restart"), err
+		}
+	}
+
 	if !dry {
 		c.prepareInitChytClusterJob()
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -77,6 +78,10 @@ func (fc *FakeComponent) SetReadyCondition(status ComponentStatus) {}
 
 type FakeServer struct {
 	podsReady bool
+	replicas  int32
+	needBuilt bool
+	synced    bool
+	removed   bool
 }
 
 func NewFakeServer() *FakeServer {
@@ -100,13 +105,22 @@ func (fs *FakeServer) configNeedsReload() bool {
 }
 
 func (fs *FakeServer) needBuild() bool {
-	return false
+	return fs.needBuilt
 }
 
 func (fs *FakeServer) needSync() bool {
 	return false
 }
 
+func (fs *FakeServer) currentReplicas() int32 {
+	return fs.replicas
+}
+
+func (fs *FakeServer) removeResources(ctx context.Context) error {
+	fs.removed = true
+	return nil
+}
+
 func (fs *FakeServer) arePodsRemoved(ctx context.Context) bool {
 	return true
 }
@@ -116,6 +130,7 @@ func (fs *FakeServer) arePodsReady(ctx context.Context) bool {
 }
 
 func (fs *FakeServer) Sync(ctx context.Context) error {
+	fs.synced = true
 	return nil
 }
 
@@ -131,6 +146,14 @@ func (fs *FakeServer) removePods(ctx context.Context) error {
 	return nil
 }
 
+func (fs *FakeServer) isRollingUpdate() bool {
+	return false
+}
+
+func (fs *FakeServer) rollPods(ctx context.Context, updateStartedAt time.Time) (bool, error) {
+	return true, nil
+}
+
 func (fs *FakeServer) GetImage() string {
 	return ""
 }
@@ -0,0 +1,247 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yson"
+	"go.ytsaurus.tech/yt/go/yt"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// TabletCellBundle keeps a tablet cell bundle of the cluster in line with its spec:
+// the bundle is created if missing, drifted attributes are overwritten and tablet cells are added or removed.
+type TabletCellBundle struct {
+	tabletCellBundle *apiproxy.TabletCellBundle
+	cfgen            *ytconfig.Generator
+	ytsaurus         *ytv1.Ytsaurus
+
+	clientSecret *resources.StringSecret
+
+	ytClient yt.Client
+}
+
+func NewTabletCellBundle(
+	cfgen *ytconfig.Generator,
+	tabletCellBundle *apiproxy.TabletCellBundle,
+	ytsaurus *ytv1.Ytsaurus) *TabletCellBundle {
+	resource := tabletCellBundle.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       tabletCellBundle.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-tablet-cell-bundle-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("TabletCellBundle-%s", resource.Name),
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	return &TabletCellBundle{
+		tabletCellBundle: tabletCellBundle,
+		cfgen:            cfgen,
+		ytsaurus:         ytsaurus,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			tabletCellBundle.APIProxy()),
+	}
+}
+
+func (b *TabletCellBundle) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, b.clientSecret)
+}
+
+func (b *TabletCellBundle) getBundleAttributes() map[string]any {
+	spec := b.tabletCellBundle.GetResource().Spec
+
+	options := getBundleReplicationOptions(b.cfgen.GetMaxReplicationFactor())
+	if options == nil {
+		options = map[string]any{}
+	}
+	if spec.ChangelogPrimaryMedium != nil {
+		options["changelog_primary_medium"] = *spec.ChangelogPrimaryMedium
+	}
+	if spec.SnapshotPrimaryMedium != nil {
+		options["snapshot_primary_medium"] = *spec.SnapshotPrimaryMedium
+	}
+
+	attributes := map[string]any{
+		"options":         options,
+		"node_tag_filter": spec.NodeTagFilter,
+	}
+	if spec.ACL != nil {
+		attributes["acl"] = toYtACL(spec.ACL)
+	}
+	return attributes
+}
+
+type tabletCellBundleAttributes struct {
+	Options       map[string]any `yson:"options"`
+	NodeTagFilter string         `yson:"node_tag_filter"`
+	ACL           []yt.ACE       `yson:"acl"`
+}
+
+// ysonEqual compares values as they are stored in Cypress, e.g. int of the spec with int64 read from Cypress.
+func ysonEqual(current, desired any) bool {
+	data, err := yson.Marshal(desired)
+	if err != nil {
+		return false
+	}
+	var normalized any
+	if err = yson.Unmarshal(data, &normalized); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(current, normalized)
+}
+
+// setAttribute sets the attribute of the existing bundle, the change is recorded as drift.
+func (b *TabletCellBundle) setAttribute(ctx context.Context, path ypath.Path, attribute string, current, value any) error {
+	b.tabletCellBundle.APIProxy().RecordNormal("DriftCorrected", fmt.Sprintf("%s is %s instead of %s",
+		attribute, describeValue(current), describeValue(value)))
+	if err := b.ytClient.SetNode(ctx, path.Attr(attribute), value, nil); err != nil {
+		return fmt.Errorf("failed to set bundle attribute %s: %w", attribute, err)
+	}
+	return nil
+}
+
+// syncAttributes overwrites attributes of the existing bundle which differ from the spec.
+func (b *TabletCellBundle) syncAttributes(ctx context.Context, path ypath.Path, attributes map[string]any) error {
+	var current tabletCellBundleAttributes
+	if err := b.ytClient.GetNode(ctx, path.Attrs(), &current, nil); err != nil {
+		return err
+	}
+
+	// Options which are not managed by the spec are kept as is.
+	options := map[string]any{}
+	for option, value := range current.Options {
+		options[option] = value
+	}
+	optionsDrifted := false
+	for option, value := range attributes["options"].(map[string]any) {
+		if !ysonEqual(current.Options[option], value) {
+			options[option] = value
+			optionsDrifted = true
+		}
+	}
+	if optionsDrifted {
+		if err := b.setAttribute(ctx, path, "options", current.Options, options); err != nil {
+			return err
+		}
+	}
+
+	if nodeTagFilter := attributes["node_tag_filter"].(string); current.NodeTagFilter != nodeTagFilter {
+		if err := b.setAttribute(ctx, path, "node_tag_filter", current.NodeTagFilter, nodeTagFilter); err != nil {
+			return err
+		}
+	}
+
+	if acl, ok := attributes["acl"].([]yt.ACE); ok && !aclEqual(current.ACL, acl) {
+		if err := b.setAttribute(ctx, path, "acl", current.ACL, acl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *TabletCellBundle) syncBundle(ctx context.Context) error {
+	resource := b.tabletCellBundle.GetResource()
+	name := resource.GetBundleName()
+	path := ypath.Path("//sys/tablet_cell_bundles").Child(name)
+	attributes := b.getBundleAttributes()
+
+	exists, err := b.ytClient.NodeExists(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		attributes["name"] = name
+		_, err = b.ytClient.CreateObject(ctx, yt.NodeTabletCellBundle, &yt.CreateObjectOptions{
+			Attributes: attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create bundle: %w", err)
+		}
+	} else if err = b.syncAttributes(ctx, path, attributes); err != nil {
+		return err
+	}
+
+	if err = CreateTabletCells(ctx, b.ytClient, name, resource.Spec.TabletCellCount); err != nil {
+		return fmt.Errorf("failed to create tablet cells: %w", err)
+	}
+	if err = RemoveTabletCells(ctx, b.ytClient, name, resource.Spec.TabletCellCount); err != nil {
+		return fmt.Errorf("failed to remove tablet cells: %w", err)
+	}
+
+	return nil
+}
+
+func (b *TabletCellBundle) updateStatus(ctx context.Context) error {
+	status := &b.tabletCellBundle.GetResource().Status
+	name := b.tabletCellBundle.GetResource().GetBundleName()
+
+	bundles, err := GetTabletCellBundlesHealth(ctx, b.ytClient)
+	if err != nil {
+		return err
+	}
+	for _, bundle := range bundles {
+		if bundle.Name == name {
+			status.Health = bundle.Health
+		}
+	}
+
+	return b.ytClient.GetNode(
+		ctx,
+		ypath.Path("//sys/tablet_cell_bundles").Child(name).Attr("tablet_cell_count"),
+		&status.TabletCellCount,
+		nil)
+}
+
+func (b *TabletCellBundle) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if b.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		b.tabletCellBundle.SetState(ctx, ytv1.TabletCellBundleStatePending, "Waiting for ytsaurus to be running")
+		return nil
+	}
+
+	if !resources.Exists(b.clientSecret) {
+		b.tabletCellBundle.SetState(ctx, ytv1.TabletCellBundleStatePending, "Waiting for ytsaurus client secret")
+		return nil
+	}
+
+	if b.ytClient == nil {
+		token, _ := b.clientSecret.GetValue(consts.TokenSecretKey)
+		ytClient, err := newYtClient(b.cfgen, token)
+		if err != nil {
+			return err
+		}
+		b.ytClient = ytClient
+	}
+
+	// Errors of YTsaurus API are reported in the status, the bundle is reconciled again later.
+	if err := b.syncBundle(ctx); err != nil {
+		logger.Error(err, "failed to sync tablet cell bundle")
+		b.tabletCellBundle.SetState(ctx, ytv1.TabletCellBundleStateFailed, err.Error())
+		return nil
+	}
+
+	if err := b.updateStatus(ctx); err != nil {
+		logger.Error(err, "failed to get tablet cell bundle status")
+		b.tabletCellBundle.SetState(ctx, ytv1.TabletCellBundleStateFailed, err.Error())
+		return nil
+	}
+
+	b.tabletCellBundle.SetState(ctx, ytv1.TabletCellBundleStateSynced, "Bundle is in sync with the spec")
+	return nil
+}
@@ -0,0 +1,259 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Tablet cell bundle test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var bundleSpec *ytv1.TabletCellBundle
+	var clientSecret *corev1.Secret
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	bundlePath := ypath.Path("//sys/tablet_cell_bundles/analytics")
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+				DataNodes: []ytv1.DataNodesSpec{
+					{
+						InstanceSpec: ytv1.InstanceSpec{
+							InstanceCount: 3,
+						},
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		bundleSpec = &ytv1.TabletCellBundle{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "analytics",
+				Namespace: "default",
+			},
+			Spec: ytv1.TabletCellBundleSpec{
+				Ytsaurus:               &corev1.LocalObjectReference{Name: "ytsaurus"},
+				NodeTagFilter:          "analytics",
+				ChangelogPrimaryMedium: ptr.To("ssd"),
+				TabletCellCount:        2,
+				ACL: []ytv1.AccessControlEntry{
+					{
+						Action:      "allow",
+						Subjects:    []string{"analysts"},
+						Permissions: []string{"use"},
+					},
+				},
+			},
+		}
+
+		clientSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "yt-client-secret",
+				Namespace: "default",
+			},
+			StringData: map[string]string{
+				consts.TokenSecretKey: "token",
+			},
+		}
+	})
+
+	syncBundle := func(ctx context.Context, k8sClient client.Client) *ytv1.TabletCellBundle {
+		ytsaurus := &ytv1.Ytsaurus{}
+		bundle := &ytv1.TabletCellBundle{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(bundleSpec), bundle)).Should(Succeed())
+		proxy := apiproxy.NewTabletCellBundle(bundle, k8sClient, record.NewFakeRecorder(100), scheme)
+		component := NewTabletCellBundle(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus)
+		component.ytClient = mockYtClient
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		Expect(component.Sync(ctx)).Should(Succeed())
+		Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+		return bundle
+	}
+
+	expectStatus := func() {
+		mockYtClient.EXPECT().
+			ListNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/tablet_cell_bundles")), gomock.Any(), gomock.Any()).
+			SetArg(2, []TabletCellBundleHealth{{Name: "analytics", Health: "good"}, {Name: "default", Health: "failed"}}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(bundlePath.Attr("tablet_cell_count")), gomock.Any(), gomock.Nil()).
+			SetArg(2, 2).
+			Return(nil)
+	}
+
+	expectTabletCells := func(cells []tabletCellInfo) {
+		mockYtClient.EXPECT().
+			ListNode(gomock.Any(), gomock.Eq(ypath.Path("//sys/tablet_cells")), gomock.Any(), gomock.Any()).
+			SetArg(2, cells).
+			Return(nil)
+	}
+
+	It("TabletCellBundle Sync; create bundle", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, bundleSpec, clientSecret).
+			WithStatusSubresource(bundleSpec).
+			Build()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(bundlePath), gomock.Nil()).Return(false, nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeTabletCellBundle), gomock.Eq(&yt.CreateObjectOptions{
+				Attributes: map[string]any{
+					"name":            "analytics",
+					"node_tag_filter": "analytics",
+					"options":         map[string]any{"changelog_primary_medium": "ssd"},
+					"acl": []yt.ACE{
+						{Action: yt.ActionAllow, Subjects: []string{"analysts"}, Permissions: []yt.Permission{yt.PermissionUse}},
+					},
+				},
+			})).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(bundlePath.Attr("tablet_cell_count")), gomock.Any(), gomock.Nil()).
+			SetArg(2, 0).
+			Return(nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeType("tablet_cell")), gomock.Any()).
+			Return(yt.NodeID{}, nil).
+			Times(2)
+		expectTabletCells([]tabletCellInfo{
+			{ID: "1-2-3-4", TabletCellBundle: "analytics", CreationTime: "2024-01-01T00:00:00.000000Z"},
+			{ID: "5-6-7-8", TabletCellBundle: "analytics", CreationTime: "2024-01-01T00:00:00.000000Z"},
+		})
+		expectStatus()
+
+		bundle := syncBundle(ctx, k8sClient)
+		Expect(bundle.Status.State).Should(Equal(ytv1.TabletCellBundleStateSynced))
+		Expect(bundle.Status.Health).Should(Equal("good"))
+		Expect(bundle.Status.TabletCellCount).Should(Equal(2))
+	})
+
+	It("TabletCellBundle Sync; update bundle and remove cells", func() {
+		ctx := context.Background()
+		bundleSpec.Spec.TabletCellCount = 1
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, bundleSpec, clientSecret).
+			WithStatusSubresource(bundleSpec).
+			Build()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(bundlePath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(bundlePath.Attrs()), gomock.Any(), gomock.Nil()).
+			SetArg(2, tabletCellBundleAttributes{
+				Options:       map[string]any{"snapshot_primary_medium": "default", "changelog_primary_medium": "default"},
+				NodeTagFilter: "analytics",
+			}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(bundlePath.Attr("options")), gomock.Eq(map[string]any{
+				"snapshot_primary_medium":  "default",
+				"changelog_primary_medium": "ssd",
+			}), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(bundlePath.Attr("acl")), gomock.Any(), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(bundlePath.Attr("tablet_cell_count")), gomock.Any(), gomock.Nil()).
+			SetArg(2, 2).
+			Return(nil)
+		expectTabletCells([]tabletCellInfo{
+			{ID: "5-6-7-8", TabletCellBundle: "analytics", CreationTime: "2024-01-02T00:00:00.000000Z"},
+			{ID: "0-0-0-1", TabletCellBundle: "default", CreationTime: "2024-01-03T00:00:00.000000Z"},
+			{ID: "1-2-3-4", TabletCellBundle: "analytics", CreationTime: "2024-01-01T00:00:00.000000Z"},
+		})
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(ypath.Path("#5-6-7-8")), gomock.Nil()).
+			Return(nil)
+		expectStatus()
+
+		bundle := syncBundle(ctx, k8sClient)
+		Expect(bundle.Status.State).Should(Equal(ytv1.TabletCellBundleStateSynced))
+	})
+
+	It("TabletCellBundle Sync; bundle without drift", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, bundleSpec, clientSecret).
+			WithStatusSubresource(bundleSpec).
+			Build()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(bundlePath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(bundlePath.Attrs()), gomock.Any(), gomock.Nil()).
+			SetArg(2, tabletCellBundleAttributes{
+				Options:       map[string]any{"snapshot_primary_medium": "default", "changelog_primary_medium": "ssd"},
+				NodeTagFilter: "analytics",
+				ACL: []yt.ACE{
+					{Action: yt.ActionAllow, Subjects: []string{"analysts"}, Permissions: []yt.Permission{yt.PermissionUse}},
+				},
+			}).
+			Return(nil)
+		mockYtClient.EXPECT().SetNode(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(bundlePath.Attr("tablet_cell_count")), gomock.Any(), gomock.Nil()).
+			SetArg(2, 2).
+			Return(nil)
+		expectTabletCells([]tabletCellInfo{
+			{ID: "1-2-3-4", TabletCellBundle: "analytics", CreationTime: "2024-01-01T00:00:00.000000Z"},
+			{ID: "5-6-7-8", TabletCellBundle: "analytics", CreationTime: "2024-01-02T00:00:00.000000Z"},
+		})
+		expectStatus()
+
+		bundle := syncBundle(ctx, k8sClient)
+		Expect(bundle.Status.State).Should(Equal(ytv1.TabletCellBundleStateSynced))
+	})
+
+	It("TabletCellBundle Sync; waiting for ytsaurus", func() {
+		ctx := context.Background()
+		ytsaurusSpec.Status.State = ytv1.ClusterStateUpdating
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, bundleSpec, clientSecret).
+			WithStatusSubresource(bundleSpec).
+			Build()
+
+		bundle := syncBundle(ctx, k8sClient)
+		Expect(bundle.Status.State).Should(Equal(ytv1.TabletCellBundleStatePending))
+	})
+})
@@ -3,6 +3,7 @@ package components
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/utils/ptr"
 
@@ -95,7 +96,12 @@ func (tn *TabletNode) doSync(ctx context.Context, dry bool) (ComponentStatus, er
 	}
 
 	if tn.ytsaurus.GetClusterState() == ytv1.ClusterStateUpdating {
-		if status, err := handleUpdatingClusterState(ctx, tn.ytsaurus, tn, &tn.localComponent, tn.server, dry); status != nil {
+		if tn.ytsaurus.GetUpdateState() == ytv1.UpdateStateWaitingForTabletNodesRollingUpdate && IsUpdatingComponent(tn.ytsaurus, tn) {
+			// New spec is applied first, pods are not restarted because of OnDelete statefulset update strategy.
+			if !tn.server.needUpdate() {
+				return tn.handleRollingUpdate(ctx, dry)
+			}
+		} else if status, err := handleUpdatingClusterState(ctx, tn.ytsaurus, tn, &tn.localComponent, tn.server, dry); status != nil {
 			return *status, err
 		}
 	}
@@ -169,11 +175,8 @@ func (tn *TabletNode) getBundleOptions(bundle string) map[string]any {
 		}
 	}
 
-	if tn.cfgen.GetMaxReplicationFactor() < 3 {
-		options["changelog_replication_factor"] = 1
-		options["changelog_read_quorum"] = 1
-		options["changelog_write_quorum"] = 1
-		options["snapshot_replication_factor"] = 1
+	for option, value := range getBundleReplicationOptions(tn.cfgen.GetMaxReplicationFactor()) {
+		options[option] = value
 	}
 
 	return options
@@ -257,3 +260,118 @@ func (tn *TabletNode) Sync(ctx context.Context) error {
 func (tn *TabletNode) Fetch(ctx context.Context) error {
 	return resources.Fetch(ctx, tn.server)
 }
+
+type TabletSlot struct {
+	State  string `yson:"state"`
+	CellID string `yson:"cell_id"`
+}
+
+// handleRollingUpdate restarts tablet nodes one by one: node is banned, its tablet cells
+// are moved to other nodes by master, then the pod is recreated and the node is unbanned.
+func (tn *TabletNode) handleRollingUpdate(ctx context.Context, dry bool) (ComponentStatus, error) {
+	ytClientStatus, err := tn.ytsaurusClient.Status(ctx)
+	if err != nil {
+		return ytClientStatus, err
+	}
+	// Client reports Updating status during cluster update when it is already initialized.
+	if ytClientStatus.SyncStatus != SyncStatusReady && ytClientStatus.SyncStatus != SyncStatusUpdating {
+		return WaitingStatus(SyncStatusBlocked, tn.ytsaurusClient.GetName()), err
+	}
+
+	statefulSetName := tn.cfgen.GetTabletNodesStatefulSetName(tn.spec.Name)
+	for i := 0; i < int(tn.spec.InstanceCount); i++ {
+		podName := fmt.Sprintf("%s-%d", statefulSetName, i)
+		if tn.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeUpdatedCondition(podName)) {
+			continue
+		}
+		if !dry {
+			err = tn.rollNode(ctx, podName)
+		}
+		return WaitingStatus(SyncStatusUpdating, fmt.Sprintf("rolling update of %s", podName)), err
+	}
+
+	if !dry {
+		setPodsUpdatedCondition(ctx, &tn.localComponent)
+	}
+	return SimpleStatus(SyncStatusUpdating), err
+}
+
+func (tn *TabletNode) rollNode(ctx context.Context, podName string) error {
+	logger := log.FromContext(ctx)
+	ytClient := tn.ytsaurusClient.GetYtClient()
+	nodePath := ypath.Path("//sys/cluster_nodes").Child(tn.cfgen.GetTabletNodePodAddress(tn.spec.Name, podName))
+
+	if !tn.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeBannedCondition(podName)) {
+		if err := ytClient.SetNode(ctx, nodePath.Attr("banned"), true, nil); err != nil {
+			return err
+		}
+		tn.setNodeCondition(ctx, labeller.GetNodeBannedCondition(podName), "Node was banned")
+		return nil
+	}
+
+	if !tn.ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeDrainedCondition(podName)) {
+		var tabletSlots []TabletSlot
+		if err := ytClient.GetNode(ctx, nodePath.Attr("tablet_slots"), &tabletSlots, nil); err != nil {
+			return err
+		}
+		for _, slot := range tabletSlots {
+			if slot.State != "none" {
+				logger.Info("waiting for tablet cells to move from node", "podName", podName, "cellId", slot.CellID)
+				return nil
+			}
+		}
+		tn.setNodeCondition(ctx, labeller.GetNodeDrainedCondition(podName), "Tablet cells were moved from node")
+		return nil
+	}
+
+	restarted := tn.ytsaurus.GetUpdateStatusCondition(labeller.GetNodeRestartedCondition(podName))
+	if restarted == nil || restarted.Status != metav1.ConditionTrue {
+		pod := &corev1.Pod{}
+		if err := tn.ytsaurus.APIProxy().FetchObject(ctx, podName, pod); err != nil {
+			return err
+		}
+		if pod.Name != "" {
+			if err := tn.ytsaurus.APIProxy().DeleteObject(ctx, pod); err != nil {
+				return err
+			}
+		}
+		tn.setNodeCondition(ctx, labeller.GetNodeRestartedCondition(podName), "Pod was deleted")
+		return nil
+	}
+
+	if !tn.isPodRecreated(ctx, podName, restarted.LastTransitionTime.Time) {
+		return nil
+	}
+
+	var nodeState string
+	if err := ytClient.GetNode(ctx, nodePath.Attr("state"), &nodeState, nil); err != nil {
+		return err
+	}
+	if nodeState != "online" {
+		logger.Info("waiting for node to become online", "podName", podName, "state", nodeState)
+		return nil
+	}
+
+	if err := ytClient.SetNode(ctx, nodePath.Attr("banned"), false, nil); err != nil {
+		return err
+	}
+	tn.setNodeCondition(ctx, labeller.GetNodeUpdatedCondition(podName), "Node was updated")
+	return nil
+}
+
+func (tn *TabletNode) isPodRecreated(ctx context.Context, podName string, deletedAt time.Time) bool {
+	pod := &corev1.Pod{}
+	if err := tn.ytsaurus.APIProxy().FetchObject(ctx, podName, pod); err != nil {
+		return false
+	}
+	return !pod.CreationTimestamp.Time.Before(deletedAt) && resources.IsPodReady(pod)
+}
+
+func (tn *TabletNode) setNodeCondition(ctx context.Context, conditionType, message string) {
+	tn.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Update",
+		Message: message,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -22,6 +23,7 @@ import (
 
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
 	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 )
@@ -535,5 +537,78 @@ var _ = Describe("Tablet node test", func() {
 			Expect(err).Should(Succeed())
 			Expect(status.SyncStatus).Should(Equal(SyncStatusReady))
 		})
+
+		It("Tablet node Sync; rolling update", func() {
+			ytsaurusSpec.Spec.TabletNodes[0].Name = "default"
+			ytsaurusSpec.Spec.TabletNodes[0].UpdateStrategy = &ytv1.ComponentUpdateStrategy{
+				Type: ytv1.ComponentUpdateStrategyTypeRolling,
+			}
+			ytsaurusSpec.Status.State = ytv1.ClusterStateUpdating
+			ytsaurusSpec.Status.UpdateStatus.Flow = ytv1.UpdateFlowTabletNodes
+			ytsaurusSpec.Status.UpdateStatus.State = ytv1.UpdateStateWaitingForTabletNodesRollingUpdate
+			ytsaurus := apiproxy.NewYtsaurus(ytsaurusSpec, client, record.NewFakeRecorder(100), scheme)
+
+			podName := "tnd-ytsaurus-0"
+			nodePath := ypath.Path("//sys/cluster_nodes/tnd-ytsaurus-0.tablet-nodes-ytsaurus.default.svc.cluster_domain:9022")
+			Expect(client.Create(context.Background(), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace},
+			})).Should(Succeed())
+
+			ytsaurusClient := NewFakeYtsaurusClient(mockYtClient)
+			cfgen := ytconfig.NewLocalNodeGenerator(ytsaurusSpec, "cluster_domain")
+			tabletNode := NewTabletNode(cfgen, ytsaurus, ytsaurusClient, ytsaurusSpec.Spec.TabletNodes[0], false)
+			tabletNode.server = NewFakeServer()
+
+			mockYtClient.EXPECT().
+				SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("banned")), gomock.Eq(true), gomock.Nil()).
+				Return(nil)
+			Expect(tabletNode.Sync(context.Background())).Should(Succeed())
+			Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeBannedCondition(podName))).Should(BeTrue())
+
+			mockYtClient.EXPECT().
+				GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("tablet_slots")), gomock.Any(), gomock.Nil()).
+				SetArg(2, []TabletSlot{{State: "leading", CellID: "1-2-3-4"}}).
+				Return(nil)
+			Expect(tabletNode.Sync(context.Background())).Should(Succeed())
+			Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeDrainedCondition(podName))).Should(BeFalse())
+
+			mockYtClient.EXPECT().
+				GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("tablet_slots")), gomock.Any(), gomock.Nil()).
+				SetArg(2, []TabletSlot{{State: "none"}}).
+				Return(nil)
+			Expect(tabletNode.Sync(context.Background())).Should(Succeed())
+			Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeDrainedCondition(podName))).Should(BeTrue())
+
+			Expect(tabletNode.Sync(context.Background())).Should(Succeed())
+			Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeRestartedCondition(podName))).Should(BeTrue())
+			pod := &corev1.Pod{}
+			err := client.Get(context.Background(), types.NamespacedName{Name: podName, Namespace: namespace}, pod)
+			Expect(err).Should(HaveOccurred())
+
+			Expect(client.Create(context.Background(), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              podName,
+					Namespace:         namespace,
+					CreationTimestamp: metav1.NewTime(time.Now().Add(time.Minute)),
+				},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			})).Should(Succeed())
+
+			mockYtClient.EXPECT().
+				GetNode(gomock.Any(), gomock.Eq(nodePath.Attr("state")), gomock.Any(), gomock.Nil()).
+				SetArg(2, "online").
+				Return(nil)
+			mockYtClient.EXPECT().
+				SetNode(gomock.Any(), gomock.Eq(nodePath.Attr("banned")), gomock.Eq(false), gomock.Nil()).
+				Return(nil)
+			Expect(tabletNode.Sync(context.Background())).Should(Succeed())
+			Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetNodeUpdatedCondition(podName))).Should(BeTrue())
+
+			Expect(tabletNode.Sync(context.Background())).Should(Succeed())
+			Expect(ytsaurus.IsUpdateStatusConditionTrue(labeller.GetPodsUpdatedCondition(tabletNode.GetName()))).Should(BeTrue())
+		})
 	})
 })
@@ -26,6 +26,8 @@ type UI struct {
 	master       Component
 	secret       *resources.StringSecret
 	caBundle     *resources.CABundle
+
+	remoteClusters []ytv1.RemoteYtsaurus
 }
 
 const UIClustersConfigFileName = "clusters-config.json"
@@ -51,6 +53,12 @@ func NewUI(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master Compon
 		caBundle = resources.NewCABundle(caBundleSpec.Name, consts.CABundleVolumeName, consts.CABundleMountPoint)
 	}
 
+	u := &UI{
+		cfgen:    cfgen,
+		caBundle: caBundle,
+		master:   master,
+	}
+
 	microservice := newMicroservice(
 		&l,
 		ytsaurus,
@@ -58,7 +66,7 @@ func NewUI(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master Compon
 		resource.Spec.UI.InstanceCount,
 		map[string]ytconfig.GeneratorDescriptor{
 			UIClustersConfigFileName: {
-				F:   cfgen.GetUIClustersConfig,
+				F:   func() ([]byte, error) { return cfgen.GetUIClustersConfig(u.remoteClusters) },
 				Fmt: ytconfig.ConfigFormatJson,
 			},
 			UICustomConfigFileName: {
@@ -71,26 +79,22 @@ func NewUI(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master Compon
 
 	microservice.getHttpService().SetHttpNodePort(resource.Spec.UI.HttpNodePort)
 
-	return &UI{
-		localComponent: newLocalComponent(&l, ytsaurus),
-		cfgen:          cfgen,
-		microservice:   microservice,
-		initJob: NewInitJob(
-			&l,
-			ytsaurus.APIProxy(),
-			ytsaurus,
-			resource.Spec.ImagePullSecrets,
-			"default",
-			consts.ClientConfigFileName,
-			resource.Spec.CoreImage,
-			cfgen.GetNativeClientConfig),
-		secret: resources.NewStringSecret(
-			l.GetSecretName(),
-			&l,
-			ytsaurus.APIProxy()),
-		caBundle: caBundle,
-		master:   master,
-	}
+	u.localComponent = newLocalComponent(&l, ytsaurus)
+	u.microservice = microservice
+	u.initJob = NewInitJob(
+		&l,
+		ytsaurus.APIProxy(),
+		ytsaurus,
+		resource.Spec.ImagePullSecrets,
+		"default",
+		consts.ClientConfigFileName,
+		resource.Spec.CoreImage,
+		cfgen.GetNativeClientConfig)
+	u.secret = resources.NewStringSecret(
+		l.GetSecretName(),
+		&l,
+		ytsaurus.APIProxy())
+	return u
 }
 
 func (u *UI) IsUpdatable() bool {
@@ -100,6 +104,12 @@ func (u *UI) IsUpdatable() bool {
 func (u *UI) GetType() consts.ComponentType { return consts.UIType }
 
 func (u *UI) Fetch(ctx context.Context) error {
+	remoteClusters, err := fetchRemoteClusters(ctx, u.ytsaurus.APIProxy(), u.ytsaurus.GetResource().Spec.GetRemoteClusters())
+	if err != nil {
+		return err
+	}
+	u.remoteClusters = remoteClusters
+
 	return resources.Fetch(ctx,
 		u.microservice,
 		u.initJob,
@@ -1,8 +1,16 @@
 package components
 
 import (
+	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 )
 
 func sha256String(value string) string {
@@ -35,3 +43,56 @@ func createUserCommand(userName, password, token string, isSuperuser bool) []str
 
 	return result
 }
+
+// userPasswordSetter sets passwords of YTsaurus users, the password is passed as its sha256 hash.
+type userPasswordSetter interface {
+	SetUserPassword(ctx context.Context, userName, passwordSHA256 string) error
+}
+
+// httpUserPasswordSetter executes set_user_password command via http proxies,
+// since the command is not supported by the go client.
+type httpUserPasswordSetter struct {
+	proxy string
+	token string
+}
+
+func newHTTPUserPasswordSetter(cfgen *ytconfig.Generator, token string) *httpUserPasswordSetter {
+	proxy, _ := getYtProxyAddress(cfgen)
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	return &httpUserPasswordSetter{
+		proxy: proxy,
+		token: token,
+	}
+}
+
+func (s *httpUserPasswordSetter) SetUserPassword(ctx context.Context, userName, passwordSHA256 string) error {
+	parameters, err := json.Marshal(map[string]string{
+		"user":                userName,
+		"new_password_sha256": passwordSHA256,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.proxy+"/api/v4/set_user_password", nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "OAuth "+s.token)
+	request.Header.Set("X-YT-Parameters", string(parameters))
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("set_user_password failed with status %s: %s", response.Status, body)
+	}
+	return nil
+}
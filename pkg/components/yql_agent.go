@@ -24,6 +24,7 @@ type YqlAgent struct {
 	master          Component
 	initEnvironment *InitJob
 	secret          *resources.StringSecret
+	remoteClusters  []ytv1.RemoteYtsaurus
 }
 
 func NewYQLAgent(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master Component) *YqlAgent {
@@ -40,6 +41,11 @@ func NewYQLAgent(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master
 		resource.Spec.YQLAgents.InstanceSpec.MonitoringPort = ptr.To(int32(consts.YQLAgentMonitoringPort))
 	}
 
+	yqla := &YqlAgent{
+		cfgen:  cfgen,
+		master: master,
+	}
+
 	srv := newServer(
 		&l,
 		ytsaurus,
@@ -49,7 +55,7 @@ func NewYQLAgent(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master
 		cfgen.GetYQLAgentStatefulSetName(),
 		cfgen.GetYQLAgentServiceName(),
 		func() ([]byte, error) {
-			return cfgen.GetYQLAgentConfig(resource.Spec.YQLAgents)
+			return cfgen.GetYQLAgentConfig(resource.Spec.YQLAgents, yqla.remoteClusters)
 		},
 		WithContainerPorts(corev1.ContainerPort{
 			Name:          consts.YTRPCPortName,
@@ -58,24 +64,21 @@ func NewYQLAgent(cfgen *ytconfig.Generator, ytsaurus *apiproxy.Ytsaurus, master
 		}),
 	)
 
-	return &YqlAgent{
-		localServerComponent: newLocalServerComponent(&l, ytsaurus, srv),
-		cfgen:                cfgen,
-		master:               master,
-		initEnvironment: NewInitJob(
-			&l,
-			ytsaurus.APIProxy(),
-			ytsaurus,
-			resource.Spec.ImagePullSecrets,
-			"yql-agent-environment",
-			consts.ClientConfigFileName,
-			resource.Spec.CoreImage,
-			cfgen.GetNativeClientConfig),
-		secret: resources.NewStringSecret(
-			l.GetSecretName(),
-			&l,
-			ytsaurus.APIProxy()),
-	}
+	yqla.localServerComponent = newLocalServerComponent(&l, ytsaurus, srv)
+	yqla.initEnvironment = NewInitJob(
+		&l,
+		ytsaurus.APIProxy(),
+		ytsaurus,
+		resource.Spec.ImagePullSecrets,
+		"yql-agent-environment",
+		consts.ClientConfigFileName,
+		resource.Spec.CoreImage,
+		cfgen.GetNativeClientConfig)
+	yqla.secret = resources.NewStringSecret(
+		l.GetSecretName(),
+		&l,
+		ytsaurus.APIProxy())
+	return yqla
 }
 
 func (yqla *YqlAgent) IsUpdatable() bool {
@@ -89,6 +92,15 @@ func (yqla *YqlAgent) GetName() string {
 }
 
 func (yqla *YqlAgent) Fetch(ctx context.Context) error {
+	// Remote clusters of query tracker are available in YQL queries too.
+	if queryTrackers := yqla.ytsaurus.GetResource().Spec.QueryTrackers; queryTrackers != nil {
+		remoteClusters, err := fetchRemoteClusters(ctx, yqla.ytsaurus.APIProxy(), queryTrackers.RemoteClusters)
+		if err != nil {
+			return err
+		}
+		yqla.remoteClusters = remoteClusters
+	}
+
 	return resources.Fetch(ctx,
 		yqla.server,
 		yqla.initEnvironment,
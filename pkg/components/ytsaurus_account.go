@@ -0,0 +1,269 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// accountResources holds resource limits or usage of an account as they are stored in YTsaurus.
+type accountResources struct {
+	NodeCount          int64            `yson:"node_count"`
+	ChunkCount         int64            `yson:"chunk_count"`
+	DiskSpacePerMedium map[string]int64 `yson:"disk_space_per_medium"`
+}
+
+// YtsaurusAccount keeps an account of the cluster in line with its spec:
+// the account is created if missing, its parent, resource limits and ACL are set.
+type YtsaurusAccount struct {
+	account  *apiproxy.YtsaurusAccount
+	cfgen    *ytconfig.Generator
+	ytsaurus *ytv1.Ytsaurus
+
+	clientSecret *resources.StringSecret
+
+	ytClient yt.Client
+}
+
+func NewYtsaurusAccount(
+	cfgen *ytconfig.Generator,
+	account *apiproxy.YtsaurusAccount,
+	ytsaurus *ytv1.Ytsaurus) *YtsaurusAccount {
+	resource := account.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       account.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-account-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("YtsaurusAccount-%s", resource.Name),
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	return &YtsaurusAccount{
+		account:  account,
+		cfgen:    cfgen,
+		ytsaurus: ytsaurus,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			account.APIProxy()),
+	}
+}
+
+func (a *YtsaurusAccount) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, a.clientSecret)
+}
+
+// validateMedia checks that disk space limits are set for media which exist in the cluster.
+func (a *YtsaurusAccount) validateMedia() error {
+	media := []string{consts.DefaultMedium}
+	for _, medium := range getExtraMedia(&a.ytsaurus.Spec) {
+		media = append(media, medium.Name)
+	}
+	for medium := range a.account.GetResource().Spec.ResourceLimits.DiskSpacePerMedium {
+		if !slices.Contains(media, medium) {
+			return fmt.Errorf("unknown medium %s, known media are %v", medium, media)
+		}
+	}
+	return nil
+}
+
+// setLimit sets the resource limit if it differs from the current one, every change is recorded as an event.
+func (a *YtsaurusAccount) setLimit(ctx context.Context, limitPath ypath.Path, limit string, current, value int64) error {
+	if current == value {
+		return nil
+	}
+	if err := a.ytClient.SetNode(ctx, limitPath, value, nil); err != nil {
+		return fmt.Errorf("failed to set %s limit: %w", limit, err)
+	}
+	a.account.APIProxy().RecordNormal(
+		"ResourceLimitChanged",
+		fmt.Sprintf("Limit %s of account %s is changed from %d to %d",
+			limit, a.account.GetResource().GetAccountName(), current, value))
+	return nil
+}
+
+func (a *YtsaurusAccount) syncResourceLimits(ctx context.Context, path ypath.Path) error {
+	limits := a.account.GetResource().Spec.ResourceLimits
+	limitsPath := path.Attr("resource_limits")
+
+	var current accountResources
+	if err := a.ytClient.GetNode(ctx, limitsPath, &current, nil); err != nil {
+		return err
+	}
+
+	if limits.NodeCount != nil {
+		if err := a.setLimit(ctx, limitsPath.Child("node_count"), "node_count", current.NodeCount, *limits.NodeCount); err != nil {
+			return err
+		}
+	}
+	if limits.ChunkCount != nil {
+		if err := a.setLimit(ctx, limitsPath.Child("chunk_count"), "chunk_count", current.ChunkCount, *limits.ChunkCount); err != nil {
+			return err
+		}
+	}
+
+	media := make([]string, 0, len(limits.DiskSpacePerMedium))
+	for medium := range limits.DiskSpacePerMedium {
+		media = append(media, medium)
+	}
+	sort.Strings(media)
+	for _, medium := range media {
+		value := limits.DiskSpacePerMedium[medium]
+		err := a.setLimit(
+			ctx,
+			limitsPath.Child("disk_space_per_medium").Child(medium),
+			fmt.Sprintf("disk_space_per_medium/%s", medium),
+			current.DiskSpacePerMedium[medium],
+			value.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *YtsaurusAccount) syncAccount(ctx context.Context) error {
+	spec := a.account.GetResource().Spec
+	name := a.account.GetResource().GetAccountName()
+	path := ypath.Path("//sys/accounts").Child(name)
+
+	exists, err := a.ytClient.NodeExists(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		attributes := map[string]any{
+			"name": name,
+		}
+		if spec.ParentName != "" {
+			attributes["parent_name"] = spec.ParentName
+		}
+		_, err = a.ytClient.CreateObject(ctx, yt.NodeAccount, &yt.CreateObjectOptions{
+			Attributes: attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+	} else if spec.ParentName != "" {
+		var parentName string
+		if err = a.ytClient.GetNode(ctx, path.Attr("parent_name"), &parentName, nil); err != nil {
+			return err
+		}
+		if parentName != spec.ParentName {
+			if err = a.ytClient.SetNode(ctx, path.Attr("parent_name"), spec.ParentName, nil); err != nil {
+				return fmt.Errorf("failed to move account to %s: %w", spec.ParentName, err)
+			}
+		}
+	}
+
+	if err = a.syncResourceLimits(ctx, path); err != nil {
+		return err
+	}
+
+	if spec.ACL != nil {
+		return a.syncACL(ctx, path)
+	}
+	return nil
+}
+
+// syncACL sets the ACL of the account if it differs from the current one, every change is recorded as an event.
+func (a *YtsaurusAccount) syncACL(ctx context.Context, path ypath.Path) error {
+	var current []yt.ACE
+	if err := a.ytClient.GetNode(ctx, path.Attr("acl"), &current, nil); err != nil {
+		return err
+	}
+	desired := toYtACL(a.account.GetResource().Spec.ACL)
+	if aclEqual(current, desired) {
+		return nil
+	}
+	if err := a.ytClient.SetNode(ctx, path.Attr("acl"), desired, nil); err != nil {
+		return fmt.Errorf("failed to set account acl: %w", err)
+	}
+	a.account.APIProxy().RecordNormal(
+		"ACLChanged",
+		fmt.Sprintf("ACL of account %s is changed", a.account.GetResource().GetAccountName()))
+	return nil
+}
+
+func (a *YtsaurusAccount) updateStatus(ctx context.Context) error {
+	path := ypath.Path("//sys/accounts").Child(a.account.GetResource().GetAccountName())
+
+	var usage accountResources
+	if err := a.ytClient.GetNode(ctx, path.Attr("resource_usage"), &usage, nil); err != nil {
+		return err
+	}
+
+	status := &ytv1.AccountResourceUsage{
+		NodeCount:  usage.NodeCount,
+		ChunkCount: usage.ChunkCount,
+	}
+	for medium, diskSpace := range usage.DiskSpacePerMedium {
+		if status.DiskSpacePerMedium == nil {
+			status.DiskSpacePerMedium = make(map[string]resource.Quantity)
+		}
+		status.DiskSpacePerMedium[medium] = *resource.NewQuantity(diskSpace, resource.BinarySI)
+	}
+	a.account.GetResource().Status.ResourceUsage = status
+	return nil
+}
+
+func (a *YtsaurusAccount) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if a.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		a.account.SetState(ctx, ytv1.YtsaurusAccountStatePending, "Waiting for ytsaurus to be running")
+		return nil
+	}
+
+	if !resources.Exists(a.clientSecret) {
+		a.account.SetState(ctx, ytv1.YtsaurusAccountStatePending, "Waiting for ytsaurus client secret")
+		return nil
+	}
+
+	if err := a.validateMedia(); err != nil {
+		a.account.SetState(ctx, ytv1.YtsaurusAccountStateFailed, err.Error())
+		return nil
+	}
+
+	if a.ytClient == nil {
+		token, _ := a.clientSecret.GetValue(consts.TokenSecretKey)
+		ytClient, err := newYtClient(a.cfgen, token)
+		if err != nil {
+			return err
+		}
+		a.ytClient = ytClient
+	}
+
+	// Errors of YTsaurus API are reported in the status, the account is reconciled again later.
+	if err := a.syncAccount(ctx); err != nil {
+		logger.Error(err, "failed to sync ytsaurus account")
+		a.account.SetState(ctx, ytv1.YtsaurusAccountStateFailed, err.Error())
+		return nil
+	}
+
+	if err := a.updateStatus(ctx); err != nil {
+		logger.Error(err, "failed to get ytsaurus account usage")
+		a.account.SetState(ctx, ytv1.YtsaurusAccountStateFailed, err.Error())
+		return nil
+	}
+
+	a.account.SetState(ctx, ytv1.YtsaurusAccountStateSynced, "Account is in sync with the spec")
+	return nil
+}
@@ -0,0 +1,218 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+var _ = Describe("Ytsaurus account test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var accountSpec *ytv1.YtsaurusAccount
+	var clientSecret *corev1.Secret
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	accountPath := ypath.Path("//sys/accounts/analytics")
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+				DataNodes: []ytv1.DataNodesSpec{
+					{
+						InstanceSpec: ytv1.InstanceSpec{
+							InstanceCount: 3,
+							Locations: []ytv1.LocationSpec{
+								{LocationType: ytv1.LocationTypeChunkStore, Path: "/yt/hdd", Medium: "default"},
+								{LocationType: ytv1.LocationTypeChunkStore, Path: "/yt/ssd", Medium: "ssd"},
+							},
+						},
+					},
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		accountSpec = &ytv1.YtsaurusAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "analytics",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusAccountSpec{
+				Ytsaurus:   &corev1.LocalObjectReference{Name: "ytsaurus"},
+				ParentName: "tenants",
+				ResourceLimits: ytv1.AccountResourceLimits{
+					NodeCount: ptr.To(int64(1000)),
+					DiskSpacePerMedium: map[string]resource.Quantity{
+						"ssd": resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+
+		clientSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "yt-client-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				consts.TokenSecretKey: []byte("token"),
+			},
+		}
+	})
+
+	syncAccount := func(ctx context.Context, k8sClient client.Client, recorder *record.FakeRecorder) *ytv1.YtsaurusAccount {
+		ytsaurus := &ytv1.Ytsaurus{}
+		account := &ytv1.YtsaurusAccount{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(accountSpec), account)).Should(Succeed())
+		proxy := apiproxy.NewYtsaurusAccount(account, k8sClient, recorder, scheme)
+		component := NewYtsaurusAccount(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus)
+		component.ytClient = mockYtClient
+		Expect(component.Fetch(ctx)).Should(Succeed())
+		Expect(component.Sync(ctx)).Should(Succeed())
+		Expect(proxy.APIProxy().UpdateStatus(ctx)).Should(Succeed())
+		return account
+	}
+
+	It("YtsaurusAccount Sync; create account and set limits", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, accountSpec, clientSecret).
+			WithStatusSubresource(accountSpec).
+			Build()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(accountPath), gomock.Nil()).Return(false, nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeAccount), gomock.Eq(&yt.CreateObjectOptions{
+				Attributes: map[string]any{"name": "analytics", "parent_name": "tenants"},
+			})).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(accountPath.Attr("resource_limits")), gomock.Any(), gomock.Nil()).
+			SetArg(2, accountResources{DiskSpacePerMedium: map[string]int64{"default": 0}}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(accountPath.Attr("resource_limits").Child("node_count")), gomock.Eq(int64(1000)), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(accountPath.Attr("resource_limits").Child("disk_space_per_medium").Child("ssd")), gomock.Eq(int64(1<<30)), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(accountPath.Attr("resource_usage")), gomock.Any(), gomock.Nil()).
+			SetArg(2, accountResources{NodeCount: 10, ChunkCount: 5, DiskSpacePerMedium: map[string]int64{"ssd": 1 << 20}}).
+			Return(nil)
+
+		recorder := record.NewFakeRecorder(100)
+		account := syncAccount(ctx, k8sClient, recorder)
+		Expect(account.Status.State).Should(Equal(ytv1.YtsaurusAccountStateSynced))
+		Expect(account.Status.ResourceUsage.NodeCount).Should(Equal(int64(10)))
+		Expect(account.Status.ResourceUsage.DiskSpacePerMedium["ssd"]).Should(Equal(resource.MustParse("1Mi")))
+
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		Expect(events).Should(ContainElements(
+			"Normal ResourceLimitChanged Limit node_count of account analytics is changed from 0 to 1000",
+			"Normal ResourceLimitChanged Limit disk_space_per_medium/ssd of account analytics is changed from 0 to 1073741824",
+		))
+	})
+
+	It("YtsaurusAccount Sync; ACL is set only if it differs", func() {
+		ctx := context.Background()
+		accountSpec.Spec.ParentName = ""
+		accountSpec.Spec.ResourceLimits = ytv1.AccountResourceLimits{}
+		accountSpec.Spec.ACL = []ytv1.AccessControlEntry{
+			{Action: "allow", Subjects: []string{"analysts"}, Permissions: []string{"use"}},
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, accountSpec, clientSecret).
+			WithStatusSubresource(accountSpec).
+			Build()
+
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(accountPath), gomock.Nil()).Return(true, nil).Times(2)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(accountPath.Attr("resource_limits")), gomock.Any(), gomock.Nil()).
+			Return(nil).
+			Times(2)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(accountPath.Attr("resource_usage")), gomock.Any(), gomock.Nil()).
+			Return(nil).
+			Times(2)
+		desired := []yt.ACE{{Action: "allow", Subjects: []string{"analysts"}, Permissions: []yt.Permission{"use"}}}
+
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(accountPath.Attr("acl")), gomock.Any(), gomock.Nil()).
+			SetArg(2, []yt.ACE{}).
+			Return(nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(accountPath.Attr("acl")), gomock.Eq(desired), gomock.Nil()).
+			Return(nil)
+		recorder := record.NewFakeRecorder(100)
+		account := syncAccount(ctx, k8sClient, recorder)
+		Expect(account.Status.State).Should(Equal(ytv1.YtsaurusAccountStateSynced))
+		Expect(recorder.Events).Should(Receive(Equal("Normal ACLChanged ACL of account analytics is changed")))
+
+		// The ACL read from Cypress has the default inheritance mode, it is not rewritten.
+		current := []yt.ACE{{Action: "allow", Subjects: []string{"analysts"}, Permissions: []yt.Permission{"use"}, InheritanceMode: "object_and_descendants"}}
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(accountPath.Attr("acl")), gomock.Any(), gomock.Nil()).
+			SetArg(2, current).
+			Return(nil)
+		recorder = record.NewFakeRecorder(100)
+		account = syncAccount(ctx, k8sClient, recorder)
+		Expect(account.Status.State).Should(Equal(ytv1.YtsaurusAccountStateSynced))
+		Expect(recorder.Events).ShouldNot(Receive())
+	})
+
+	It("YtsaurusAccount Sync; unknown medium", func() {
+		ctx := context.Background()
+		accountSpec.Spec.ResourceLimits.DiskSpacePerMedium["nvme"] = resource.MustParse("1Gi")
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, accountSpec, clientSecret).
+			WithStatusSubresource(accountSpec).
+			Build()
+
+		account := syncAccount(ctx, k8sClient, record.NewFakeRecorder(100))
+		Expect(account.Status.State).Should(Equal(ytv1.YtsaurusAccountStateFailed))
+		Expect(account.Status.Message).Should(ContainSubstring("unknown medium nvme"))
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/metrics"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
 )
@@ -158,6 +159,7 @@ func (yc *YtsaurusClient) handleUpdatingState(ctx context.Context) (ComponentSta
 			!yc.ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionNoPossibility) {
 			ok, msg, err := yc.HandlePossibilityCheck(ctx)
 			if err != nil {
+				yc.reportPossibilityCheckFailure("Error")
 				return SimpleStatus(SyncStatusUpdating), err
 			}
 
@@ -299,6 +301,26 @@ func (yc *YtsaurusClient) handleUpdatingState(ctx context.Context) (ComponentSta
 			return SimpleStatus(SyncStatusUpdating), nil
 		}
 
+	case ytv1.UpdateStateWaitingForMasterQuorum:
+		if !yc.ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionMasterQuorumVerified) {
+			ok, msg, err := yc.checkMastersQuorum(ctx)
+			if err != nil {
+				return SimpleStatus(SyncStatusUpdating), err
+			}
+
+			if !ok {
+				return WaitingStatus(SyncStatusUpdating, msg), nil
+			}
+
+			yc.ytsaurus.SetUpdateStatusCondition(ctx, metav1.Condition{
+				Type:    consts.ConditionMasterQuorumVerified,
+				Status:  metav1.ConditionTrue,
+				Reason:  "Update",
+				Message: "Master cells have a leader and all peers are following",
+			})
+			return SimpleStatus(SyncStatusUpdating), nil
+		}
+
 	case ytv1.UpdateStateWaitingForTabletCellsRecovery:
 		if !yc.ytsaurus.IsUpdateStatusConditionTrue(consts.ConditionTabletCellsRecovered) {
 			err = yc.RecoverTableCells(ctx, yc.ytsaurus.GetResource().Status.UpdateStatus.TabletCellBundles)
@@ -366,20 +388,7 @@ func (yc *YtsaurusClient) doSync(ctx context.Context, dry bool) (ComponentStatus
 
 	if yc.ytClient == nil {
 		token, _ := yc.secret.GetValue(consts.TokenSecretKey)
-		timeout := time.Second * 10
-		proxy, ok := os.LookupEnv("YTOP_PROXY")
-		disableProxyDiscovery := true
-		if !ok {
-			proxy = yc.cfgen.GetHTTPProxiesAddress(consts.DefaultHTTPProxyRole)
-			disableProxyDiscovery = false
-		}
-		yc.ytClient, err = ythttp.NewClient(&yt.Config{
-			Proxy:                 proxy,
-			Token:                 token,
-			LightRequestTimeout:   &timeout,
-			DisableProxyDiscovery: disableProxyDiscovery,
-		})
-
+		yc.ytClient, err = newYtClient(yc.cfgen, token)
 		if err != nil {
 			return WaitingStatus(SyncStatusPending, "ytClient init"), err
 		}
@@ -398,6 +407,27 @@ func (yc *YtsaurusClient) doSync(ctx context.Context, dry bool) (ComponentStatus
 	return SimpleStatus(SyncStatusReady), err
 }
 
+// getYtProxyAddress returns the address of the default http proxies of the cluster,
+// YTOP_PROXY environment variable overrides the proxy address and disables proxy discovery.
+func getYtProxyAddress(cfgen *ytconfig.Generator) (proxy string, disableProxyDiscovery bool) {
+	if proxy, ok := os.LookupEnv("YTOP_PROXY"); ok {
+		return proxy, true
+	}
+	return cfgen.GetHTTPProxiesAddress(consts.DefaultHTTPProxyRole), false
+}
+
+// newYtClient creates a client to the default http proxies of the cluster.
+func newYtClient(cfgen *ytconfig.Generator, token string) (yt.Client, error) {
+	timeout := time.Second * 10
+	proxy, disableProxyDiscovery := getYtProxyAddress(cfgen)
+	return ythttp.NewClient(&yt.Config{
+		Proxy:                 proxy,
+		Token:                 token,
+		LightRequestTimeout:   &timeout,
+		DisableProxyDiscovery: disableProxyDiscovery,
+	})
+}
+
 func (yc *YtsaurusClient) Status(ctx context.Context) (ComponentStatus, error) {
 	return yc.doSync(ctx, true)
 }
@@ -420,6 +450,7 @@ func (yc *YtsaurusClient) HandlePossibilityCheck(ctx context.Context) (ok bool,
 
 	if len(notGoodBundles) > 0 {
 		msg = fmt.Sprintf("Tablet cell bundles (%v) aren't in 'good' health", notGoodBundles)
+		yc.reportPossibilityCheckFailure("TabletCellBundlesNotGood")
 		return false, msg, nil
 	}
 
@@ -432,6 +463,7 @@ func (yc *YtsaurusClient) HandlePossibilityCheck(ctx context.Context) (ok bool,
 
 	if lvcCount > 0 {
 		msg = fmt.Sprintf("There are lost vital chunks: %v", lvcCount)
+		yc.reportPossibilityCheckFailure("LostVitalChunks")
 		return false, msg, nil
 	}
 
@@ -444,24 +476,49 @@ func (yc *YtsaurusClient) HandlePossibilityCheck(ctx context.Context) (ok bool,
 
 	if qmcCount > 0 {
 		msg = fmt.Sprintf("There are quorum missing chunks: %v", qmcCount)
+		yc.reportPossibilityCheckFailure("QuorumMissingChunks")
 		return false, msg, nil
 	}
 
 	// Check masters.
-	primaryMasterAddresses := make([]string, 0)
-	err = yc.ytClient.ListNode(ctx, ypath.Path("//sys/primary_masters"), &primaryMasterAddresses, nil)
+	ok, msg, _, err = checkPrimaryMastersHydra(ctx, yc.ytClient)
+	if err != nil || !ok {
+		if err == nil {
+			yc.reportPossibilityCheckFailure("MastersNotReady")
+		}
+		return
+	}
+
+	return true, "Update is possible", nil
+}
+
+func (yc *YtsaurusClient) reportPossibilityCheckFailure(reason string) {
+	resource := yc.ytsaurus.GetResource()
+	metrics.ReportPossibilityCheckFailure(resource.Namespace, resource.Name, reason)
+}
+
+// checkPrimaryMastersHydra checks that primary masters have a leader and all other peers are active followers.
+// readOnly is true if some of the masters are in read-only mode.
+func checkPrimaryMastersHydra(ctx context.Context, ytClient yt.Client) (ok bool, msg string, readOnly bool, err error) {
+	return checkMasterCellHydra(ctx, ytClient, "//sys/primary_masters")
+}
+
+// checkMasterCellHydra checks hydra state of the master cell, whose peers are registered under cellPath.
+func checkMasterCellHydra(ctx context.Context, ytClient yt.Client, cellPath string) (ok bool, msg string, readOnly bool, err error) {
+	masterAddresses := make([]string, 0)
+	err = ytClient.ListNode(ctx, ypath.Path(cellPath), &masterAddresses, nil)
 	if err != nil {
 		return
 	}
 
-	leadingPrimaryMasterCount := 0
-	followingPrimaryMasterCount := 0
+	leadingMasterCount := 0
+	followingMasterCount := 0
 
-	for _, primaryMasterAddress := range primaryMasterAddresses {
+	for _, masterAddress := range masterAddresses {
 		var hydra MasterHydra
-		err = yc.ytClient.GetNode(
+		err = ytClient.GetNode(
 			ctx,
-			ypath.Path(fmt.Sprintf("//sys/primary_masters/%v/orchid/monitoring/hydra", primaryMasterAddress)),
+			ypath.Path(fmt.Sprintf("%v/%v/orchid/monitoring/hydra", cellPath, masterAddress)),
 			&hydra,
 			nil)
 		if err != nil {
@@ -469,24 +526,62 @@ func (yc *YtsaurusClient) HandlePossibilityCheck(ctx context.Context) (ok bool,
 		}
 
 		if !hydra.Active {
-			msg = fmt.Sprintf("There is a non-active master: %v", primaryMasterAddresses)
-			return false, msg, nil
+			msg = fmt.Sprintf("There is a non-active master: %v", masterAddresses)
+			return false, msg, readOnly, nil
 		}
 
+		readOnly = readOnly || hydra.ReadOnly
+
 		switch hydra.State {
 		case MasterStateLeading:
-			leadingPrimaryMasterCount += 1
+			leadingMasterCount += 1
 		case MasterStateFollowing:
-			followingPrimaryMasterCount += 1
+			followingMasterCount += 1
 		}
 	}
 
-	if !(leadingPrimaryMasterCount == 1 && followingPrimaryMasterCount+1 == len(primaryMasterAddresses)) {
+	if !(leadingMasterCount == 1 && followingMasterCount+1 == len(masterAddresses)) {
 		msg = "There is no leader or some peer is not active"
+		return false, msg, readOnly, nil
+	}
+
+	return true, "", readOnly, nil
+}
+
+// checkMasterCellQuorum checks that all masters of the cell from the spec are registered,
+// so peers added by master resize have joined the cell, and that they have a single leader.
+func (yc *YtsaurusClient) checkMasterCellQuorum(ctx context.Context, cellPath string, instanceCount int32) (ok bool, msg string, err error) {
+	masterAddresses := make([]string, 0)
+	err = yc.ytClient.ListNode(ctx, ypath.Path(cellPath), &masterAddresses, nil)
+	if err != nil {
+		return
+	}
+
+	if len(masterAddresses) != int(instanceCount) {
+		msg = fmt.Sprintf("%d of %d masters are registered in %s", len(masterAddresses), instanceCount, cellPath)
 		return false, msg, nil
 	}
 
-	return true, "Update is possible", nil
+	ok, msg, _, err = checkMasterCellHydra(ctx, yc.ytClient, cellPath)
+	return
+}
+
+// checkMastersQuorum checks quorum of the primary master cell and all secondary master cells.
+func (yc *YtsaurusClient) checkMastersQuorum(ctx context.Context) (ok bool, msg string, err error) {
+	resource := yc.ytsaurus.GetResource()
+	ok, msg, err = yc.checkMasterCellQuorum(ctx, "//sys/primary_masters", resource.Spec.PrimaryMasters.InstanceCount)
+	if err != nil || !ok {
+		return
+	}
+
+	for _, secondaryMasters := range resource.Spec.SecondaryMasters {
+		cellPath := fmt.Sprintf("//sys/secondary_masters/%d", secondaryMasters.CellTag)
+		ok, msg, err = yc.checkMasterCellQuorum(ctx, cellPath, secondaryMasters.InstanceCount)
+		if err != nil || !ok {
+			return
+		}
+	}
+	return true, "", nil
 }
 
 // Safe mode actions.
@@ -0,0 +1,206 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// YtsaurusGroup keeps a group of the cluster in line with its spec:
+// the group is created if missing, its members are added and members added by the operator are removed.
+type YtsaurusGroup struct {
+	group    *apiproxy.YtsaurusGroup
+	cfgen    *ytconfig.Generator
+	ytsaurus *ytv1.Ytsaurus
+
+	clientSecret *resources.StringSecret
+
+	ytClient yt.Client
+}
+
+func NewYtsaurusGroup(
+	cfgen *ytconfig.Generator,
+	group *apiproxy.YtsaurusGroup,
+	ytsaurus *ytv1.Ytsaurus) *YtsaurusGroup {
+	resource := group.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       group.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-group-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("YtsaurusGroup-%s", resource.Name),
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	return &YtsaurusGroup{
+		group:    group,
+		cfgen:    cfgen,
+		ytsaurus: ytsaurus,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			group.APIProxy()),
+	}
+}
+
+func (g *YtsaurusGroup) Fetch(ctx context.Context) error {
+	return resources.Fetch(ctx, g.clientSecret)
+}
+
+// initClient creates a client to the cluster, returns false if the cluster is not accessible yet.
+func (g *YtsaurusGroup) initClient(ctx context.Context, state ytv1.YtsaurusSubjectState) bool {
+	if g.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		g.group.SetState(ctx, state, "Waiting for ytsaurus to be running")
+		return false
+	}
+
+	if !resources.Exists(g.clientSecret) {
+		g.group.SetState(ctx, state, "Waiting for ytsaurus client secret")
+		return false
+	}
+
+	if g.ytClient == nil {
+		token, _ := g.clientSecret.GetValue(consts.TokenSecretKey)
+		ytClient, err := newYtClient(g.cfgen, token)
+		if err != nil {
+			g.group.SetState(ctx, ytv1.YtsaurusSubjectStateFailed, err.Error())
+			return false
+		}
+		g.ytClient = ytClient
+	}
+	return true
+}
+
+func (g *YtsaurusGroup) syncGroup(ctx context.Context) error {
+	resource := g.group.GetResource()
+	name := resource.GetGroupName()
+	groupPath := ypath.Path("//sys/groups").Child(name)
+
+	exists, err := g.ytClient.NodeExists(ctx, groupPath, nil)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		_, err = g.ytClient.CreateObject(ctx, yt.NodeGroup, &yt.CreateObjectOptions{
+			IgnoreExisting: true,
+			Attributes: map[string]any{
+				"name": name,
+			}})
+		if err != nil {
+			return fmt.Errorf("failed to create group: %w", err)
+		}
+		resource.Status.Created = true
+	}
+
+	var members []string
+	if err = g.ytClient.GetNode(ctx, groupPath.Attr("members"), &members, nil); err != nil {
+		return err
+	}
+
+	// Members which are already in the group are managed elsewhere, e.g. by YtsaurusUser, so they are not recorded.
+	var added []string
+	for _, member := range resource.Spec.Members {
+		if slices.Contains(members, member) {
+			if slices.Contains(resource.Status.Members, member) {
+				added = append(added, member)
+			}
+			continue
+		}
+		if err = g.ytClient.AddMember(ctx, name, member, nil); err != nil {
+			return fmt.Errorf("failed to add member %s: %w", member, err)
+		}
+		added = append(added, member)
+	}
+
+	// Only members added by the operator are revoked.
+	for _, member := range resource.Status.Members {
+		if slices.Contains(resource.Spec.Members, member) || !slices.Contains(members, member) {
+			continue
+		}
+		if err = g.ytClient.RemoveMember(ctx, name, member, nil); err != nil {
+			return fmt.Errorf("failed to remove member %s: %w", member, err)
+		}
+	}
+
+	resource.Status.Members = added
+	return nil
+}
+
+// revokeMembers removes members added by the operator from a group which was not created by the operator.
+func (g *YtsaurusGroup) revokeMembers(ctx context.Context) error {
+	resource := g.group.GetResource()
+	name := resource.GetGroupName()
+
+	var members []string
+	if err := g.ytClient.GetNode(ctx, ypath.Path("//sys/groups").Child(name).Attr("members"), &members, nil); err != nil {
+		return err
+	}
+	for _, member := range resource.Status.Members {
+		if !slices.Contains(members, member) {
+			continue
+		}
+		if err := g.ytClient.RemoveMember(ctx, name, member, nil); err != nil {
+			return fmt.Errorf("failed to remove member %s: %w", member, err)
+		}
+	}
+	resource.Status.Members = nil
+	return nil
+}
+
+func (g *YtsaurusGroup) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if !g.initClient(ctx, ytv1.YtsaurusSubjectStatePending) {
+		return nil
+	}
+
+	// Errors of YTsaurus API are reported in the status, the group is reconciled again later.
+	if err := g.syncGroup(ctx); err != nil {
+		logger.Error(err, "failed to sync ytsaurus group")
+		g.group.SetState(ctx, ytv1.YtsaurusSubjectStateFailed, err.Error())
+		return nil
+	}
+
+	g.group.SetState(ctx, ytv1.YtsaurusSubjectStateSynced, "Group is in sync with the spec")
+	return nil
+}
+
+// Remove removes the group from the cluster if the group was created by the operator,
+// otherwise only members added by the operator are removed. It returns true once it is done.
+func (g *YtsaurusGroup) Remove(ctx context.Context) bool {
+	logger := log.FromContext(ctx)
+	resource := g.group.GetResource()
+
+	if !g.initClient(ctx, ytv1.YtsaurusSubjectStateDeleting) {
+		return false
+	}
+
+	var err error
+	if resource.Status.Created {
+		groupPath := ypath.Path("//sys/groups").Child(resource.GetGroupName())
+		err = g.ytClient.RemoveNode(ctx, groupPath, &yt.RemoveNodeOptions{Force: true})
+	} else {
+		err = g.revokeMembers(ctx)
+	}
+	if err != nil {
+		logger.Error(err, "failed to remove ytsaurus group")
+		g.group.SetState(ctx, ytv1.YtsaurusSubjectStateFailed, err.Error())
+		return false
+	}
+
+	g.group.SetState(ctx, ytv1.YtsaurusSubjectStateDeleting, "Group is removed")
+	return true
+}
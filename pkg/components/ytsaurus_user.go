@@ -0,0 +1,313 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/resources"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+// YtsaurusUser keeps a user of the cluster in line with its spec:
+// the user is created if missing, added to the groups, its password and token are set.
+// Only the user, memberships and tokens created by the operator are removed.
+type YtsaurusUser struct {
+	user     *apiproxy.YtsaurusUser
+	cfgen    *ytconfig.Generator
+	ytsaurus *ytv1.Ytsaurus
+
+	clientSecret   *resources.StringSecret
+	passwordSecret *resources.StringSecret
+	tokenSecret    *resources.StringSecret
+
+	ytClient       yt.Client
+	passwordSetter userPasswordSetter
+}
+
+func NewYtsaurusUser(
+	cfgen *ytconfig.Generator,
+	user *apiproxy.YtsaurusUser,
+	ytsaurus *ytv1.Ytsaurus) *YtsaurusUser {
+	resource := user.GetResource()
+	l := labeller.Labeller{
+		ObjectMeta:     &resource.ObjectMeta,
+		APIProxy:       user.APIProxy(),
+		ComponentLabel: fmt.Sprintf("ytsaurus-user-%s", resource.Name),
+		ComponentName:  fmt.Sprintf("YtsaurusUser-%s", resource.Name),
+	}
+	clientLabeller := labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		ComponentLabel: consts.YTComponentLabelClient,
+	}
+
+	u := &YtsaurusUser{
+		user:     user,
+		cfgen:    cfgen,
+		ytsaurus: ytsaurus,
+		clientSecret: resources.NewStringSecret(
+			clientLabeller.GetSecretName(),
+			&l,
+			user.APIProxy()),
+	}
+	if resource.Spec.PasswordSecret != nil {
+		u.passwordSecret = resources.NewStringSecret(resource.Spec.PasswordSecret.Name, &l, user.APIProxy())
+	}
+	if resource.Spec.TokenSecretName != "" {
+		u.tokenSecret = resources.NewStringSecret(resource.Spec.TokenSecretName, &l, user.APIProxy())
+	}
+	return u
+}
+
+func (u *YtsaurusUser) Fetch(ctx context.Context) error {
+	objects := []resources.Fetchable{u.clientSecret}
+	if u.passwordSecret != nil {
+		objects = append(objects, u.passwordSecret)
+	}
+	if u.tokenSecret != nil {
+		objects = append(objects, u.tokenSecret)
+	}
+	return resources.Fetch(ctx, objects...)
+}
+
+// initClients creates clients to the cluster, returns false if the cluster is not accessible yet.
+func (u *YtsaurusUser) initClients(ctx context.Context, state ytv1.YtsaurusSubjectState) bool {
+	if u.ytsaurus.Status.State != ytv1.ClusterStateRunning {
+		u.user.SetState(ctx, state, "Waiting for ytsaurus to be running")
+		return false
+	}
+
+	if !resources.Exists(u.clientSecret) {
+		u.user.SetState(ctx, state, "Waiting for ytsaurus client secret")
+		return false
+	}
+
+	token, _ := u.clientSecret.GetValue(consts.TokenSecretKey)
+	if u.ytClient == nil {
+		ytClient, err := newYtClient(u.cfgen, token)
+		if err != nil {
+			u.user.SetState(ctx, ytv1.YtsaurusSubjectStateFailed, err.Error())
+			return false
+		}
+		u.ytClient = ytClient
+	}
+	if u.passwordSetter == nil {
+		u.passwordSetter = newHTTPUserPasswordSetter(u.cfgen, token)
+	}
+	return true
+}
+
+// syncUser creates the user if it is missing and issues the token, the previously issued token is revoked on rotation.
+func (u *YtsaurusUser) syncUser(ctx context.Context, token string) error {
+	resource := u.user.GetResource()
+	name := resource.GetUserName()
+
+	tokenHash := ""
+	if token != "" {
+		tokenHash = sha256String(token)
+	}
+
+	exists, err := u.ytClient.NodeExists(ctx, ypath.Path("//sys/users").Child(name), nil)
+	if err != nil {
+		return err
+	}
+	if exists && tokenHash == resource.Status.TokenHash {
+		return nil
+	}
+
+	if err = CreateUser(ctx, u.ytClient, name, token, false); err != nil {
+		return err
+	}
+	if !exists {
+		resource.Status.Created = true
+	}
+
+	if resource.Status.TokenHash != "" && resource.Status.TokenHash != tokenHash {
+		if err = u.revokeToken(ctx); err != nil {
+			return err
+		}
+	}
+	resource.Status.TokenHash = tokenHash
+	return nil
+}
+
+// revokeToken removes the token issued by the operator.
+func (u *YtsaurusUser) revokeToken(ctx context.Context) error {
+	resource := u.user.GetResource()
+	tokenPath := ypath.Path("//sys/cypress_tokens").Child(resource.Status.TokenHash)
+	if err := u.ytClient.RemoveNode(ctx, tokenPath, &yt.RemoveNodeOptions{Force: true}); err != nil {
+		return fmt.Errorf("failed to remove token: %w", err)
+	}
+	resource.Status.TokenHash = ""
+	return nil
+}
+
+func (u *YtsaurusUser) getMemberOf(ctx context.Context) ([]string, error) {
+	var memberOf []string
+	path := ypath.Path("//sys/users").Child(u.user.GetResource().GetUserName()).Attr("member_of")
+	if err := u.ytClient.GetNode(ctx, path, &memberOf, nil); err != nil {
+		return nil, err
+	}
+	return memberOf, nil
+}
+
+func (u *YtsaurusUser) syncMembership(ctx context.Context) error {
+	resource := u.user.GetResource()
+	name := resource.GetUserName()
+
+	memberOf, err := u.getMemberOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Memberships which already exist are managed elsewhere, e.g. by YtsaurusGroup, so they are not recorded.
+	var added []string
+	for _, group := range resource.Spec.MemberOf {
+		if slices.Contains(memberOf, group) {
+			if slices.Contains(resource.Status.MemberOf, group) {
+				added = append(added, group)
+			}
+			continue
+		}
+		if err := u.ytClient.AddMember(ctx, group, name, nil); err != nil {
+			return fmt.Errorf("failed to add user to group %s: %w", group, err)
+		}
+		added = append(added, group)
+	}
+
+	// Only memberships added by the operator are revoked, the others are managed elsewhere.
+	for _, group := range resource.Status.MemberOf {
+		if slices.Contains(resource.Spec.MemberOf, group) || !slices.Contains(memberOf, group) {
+			continue
+		}
+		if err := u.ytClient.RemoveMember(ctx, group, name, nil); err != nil {
+			return fmt.Errorf("failed to remove user from group %s: %w", group, err)
+		}
+	}
+
+	resource.Status.MemberOf = added
+	return nil
+}
+
+// revokeMembership removes a user which was not created by the operator from groups it was added to by the operator.
+func (u *YtsaurusUser) revokeMembership(ctx context.Context) error {
+	resource := u.user.GetResource()
+
+	memberOf, err := u.getMemberOf(ctx)
+	if err != nil {
+		return err
+	}
+	for _, group := range resource.Status.MemberOf {
+		if !slices.Contains(memberOf, group) {
+			continue
+		}
+		if err := u.ytClient.RemoveMember(ctx, group, resource.GetUserName(), nil); err != nil {
+			return fmt.Errorf("failed to remove user from group %s: %w", group, err)
+		}
+	}
+	resource.Status.MemberOf = nil
+	return nil
+}
+
+func (u *YtsaurusUser) syncPassword(ctx context.Context) error {
+	resource := u.user.GetResource()
+	version := u.passwordSecret.OldObject().GetResourceVersion()
+	if resource.Status.PasswordSecretVersion == version {
+		return nil
+	}
+
+	password, ok := u.passwordSecret.GetValue(resource.Spec.PasswordSecret.Key)
+	if !ok {
+		return fmt.Errorf("password secret %s has no key %s", u.passwordSecret.Name(), resource.Spec.PasswordSecret.Key)
+	}
+	if err := u.passwordSetter.SetUserPassword(ctx, resource.GetUserName(), sha256String(password)); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	resource.Status.PasswordSecretVersion = version
+	return nil
+}
+
+func (u *YtsaurusUser) Sync(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if !u.initClients(ctx, ytv1.YtsaurusSubjectStatePending) {
+		return nil
+	}
+
+	if u.passwordSecret != nil && !resources.Exists(u.passwordSecret) {
+		u.user.SetState(ctx, ytv1.YtsaurusSubjectStatePending, "Waiting for password secret")
+		return nil
+	}
+
+	if u.tokenSecret != nil && u.tokenSecret.NeedSync(consts.TokenSecretKey, "") {
+		s := u.tokenSecret.Build()
+		s.StringData = map[string]string{
+			consts.TokenSecretKey: ytconfig.RandString(30),
+		}
+		u.user.SetState(ctx, ytv1.YtsaurusSubjectStatePending, "Waiting for token secret")
+		return u.tokenSecret.Sync(ctx)
+	}
+
+	token := ""
+	if u.tokenSecret != nil {
+		token, _ = u.tokenSecret.GetValue(consts.TokenSecretKey)
+	}
+
+	// Errors of YTsaurus API are reported in the status, the user is reconciled again later.
+	err := u.syncUser(ctx, token)
+	if err == nil {
+		err = u.syncMembership(ctx)
+	}
+	if err == nil && u.passwordSecret != nil {
+		err = u.syncPassword(ctx)
+	}
+	if err != nil {
+		logger.Error(err, "failed to sync ytsaurus user")
+		u.user.SetState(ctx, ytv1.YtsaurusSubjectStateFailed, err.Error())
+		return nil
+	}
+
+	u.user.SetState(ctx, ytv1.YtsaurusSubjectStateSynced, "User is in sync with the spec")
+	return nil
+}
+
+// Remove removes the token issued by the operator and the user if it was created by the operator,
+// otherwise memberships added by the operator are revoked. It returns true once it is done.
+func (u *YtsaurusUser) Remove(ctx context.Context) bool {
+	logger := log.FromContext(ctx)
+	resource := u.user.GetResource()
+
+	if !u.initClients(ctx, ytv1.YtsaurusSubjectStateDeleting) {
+		return false
+	}
+
+	err := func() error {
+		if resource.Status.TokenHash != "" {
+			if err := u.revokeToken(ctx); err != nil {
+				return err
+			}
+		}
+		if !resource.Status.Created {
+			return u.revokeMembership(ctx)
+		}
+		userPath := ypath.Path("//sys/users").Child(resource.GetUserName())
+		return u.ytClient.RemoveNode(ctx, userPath, &yt.RemoveNodeOptions{Force: true})
+	}()
+	if err != nil {
+		logger.Error(err, "failed to remove ytsaurus user")
+		u.user.SetState(ctx, ytv1.YtsaurusSubjectStateFailed, err.Error())
+		return false
+	}
+
+	u.user.SetState(ctx, ytv1.YtsaurusSubjectStateDeleting, "User is removed")
+	return true
+}
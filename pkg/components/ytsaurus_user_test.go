@@ -0,0 +1,257 @@
+package components
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.ytsaurus.tech/yt/go/ypath"
+	"go.ytsaurus.tech/yt/go/yt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	mock_yt "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/mock"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/ytconfig"
+)
+
+type fakeUserPasswordSetter struct {
+	passwords map[string]string
+}
+
+func (s *fakeUserPasswordSetter) SetUserPassword(ctx context.Context, userName, passwordSHA256 string) error {
+	s.passwords[userName] = passwordSHA256
+	return nil
+}
+
+var _ = Describe("Ytsaurus user and group test", func() {
+	var ytsaurusSpec *ytv1.Ytsaurus
+	var clientSecret *corev1.Secret
+	var mockYtClient *mock_yt.MockClient
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		mockYtClient = mock_yt.NewMockClient(mockCtrl)
+
+		scheme = runtime.NewScheme()
+		Expect(ytv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		ytsaurusSpec = &ytv1.Ytsaurus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ytsaurus",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusSpec{
+				CommonSpec: ytv1.CommonSpec{
+					CoreImage:     "ytsaurus/ytsaurus:latest",
+					UseShortNames: true,
+				},
+			},
+			Status: ytv1.YtsaurusStatus{
+				State: ytv1.ClusterStateRunning,
+			},
+		}
+
+		clientSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "yt-client-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				consts.TokenSecretKey: []byte("token"),
+			},
+		}
+	})
+
+	It("YtsaurusUser Sync and Remove", func() {
+		ctx := context.Background()
+		userSpec := &ytv1.YtsaurusUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "alice",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusUserSpec{
+				Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				MemberOf: []string{"analysts"},
+				PasswordSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "alice-password"},
+					Key:                  "password",
+				},
+				TokenSecretName: "alice-token",
+			},
+			Status: ytv1.YtsaurusUserStatus{
+				MemberOf: []string{"admins"},
+			},
+		}
+		passwordSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "alice-password",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"password": []byte("secret"),
+			},
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, userSpec, clientSecret, passwordSecret).
+			WithStatusSubresource(userSpec).
+			Build()
+
+		passwordSetter := &fakeUserPasswordSetter{passwords: map[string]string{}}
+		user := &ytv1.YtsaurusUser{}
+		newUser := func() *YtsaurusUser {
+			ytsaurus := &ytv1.Ytsaurus{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ytsaurusSpec), ytsaurus)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(userSpec), user)).Should(Succeed())
+			proxy := apiproxy.NewYtsaurusUser(user, k8sClient, record.NewFakeRecorder(100), scheme)
+			component := NewYtsaurusUser(ytconfig.NewGenerator(ytsaurus, "cluster_domain"), proxy, ytsaurus)
+			component.ytClient = mockYtClient
+			component.passwordSetter = passwordSetter
+			Expect(component.Fetch(ctx)).Should(Succeed())
+			return component
+		}
+		syncUser := func() {
+			Expect(newUser().Sync(ctx)).Should(Succeed())
+			Expect(k8sClient.Status().Update(ctx, user)).Should(Succeed())
+		}
+
+		syncUser()
+		Expect(user.Status.State).Should(Equal(ytv1.YtsaurusSubjectStatePending))
+		tokenSecret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "alice-token"}, tokenSecret)).Should(Succeed())
+		token := tokenSecret.StringData[consts.TokenSecretKey]
+		Expect(token).ShouldNot(BeEmpty())
+		// Fake client does not convert string data.
+		tokenSecret.Data = map[string][]byte{consts.TokenSecretKey: []byte(token)}
+		Expect(k8sClient.Update(ctx, tokenSecret)).Should(Succeed())
+
+		userPath := ypath.Path("//sys/users/alice")
+		tokenPath := ypath.Path("//sys/cypress_tokens/" + sha256String(token))
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(userPath), gomock.Nil()).Return(false, nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeUser), gomock.Any()).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			CreateNode(gomock.Any(), gomock.Eq(tokenPath), gomock.Eq(yt.NodeMap), gomock.Any()).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(tokenPath.Attr("user")), gomock.Eq("alice"), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(userPath.Attr("member_of")), gomock.Any(), gomock.Nil()).
+			SetArg(2, []string{"users", "admins"}).
+			Return(nil)
+		mockYtClient.EXPECT().AddMember(gomock.Any(), "analysts", "alice", gomock.Nil()).Return(nil)
+		mockYtClient.EXPECT().RemoveMember(gomock.Any(), "admins", "alice", gomock.Nil()).Return(nil)
+
+		syncUser()
+		Expect(user.Status.State).Should(Equal(ytv1.YtsaurusSubjectStateSynced))
+		Expect(user.Status.MemberOf).Should(Equal([]string{"analysts"}))
+		Expect(passwordSetter.passwords).Should(Equal(map[string]string{"alice": sha256String("secret")}))
+		Expect(user.Status.PasswordSecretVersion).ShouldNot(BeEmpty())
+		Expect(user.Status.Created).Should(BeTrue())
+		Expect(user.Status.TokenHash).Should(Equal(sha256String(token)))
+
+		// The rotated token is issued and the previous one is revoked.
+		tokenSecret.Data = map[string][]byte{consts.TokenSecretKey: []byte("rotated")}
+		Expect(k8sClient.Update(ctx, tokenSecret)).Should(Succeed())
+		rotatedTokenPath := ypath.Path("//sys/cypress_tokens/" + sha256String("rotated"))
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(userPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			CreateObject(gomock.Any(), gomock.Eq(yt.NodeUser), gomock.Any()).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			CreateNode(gomock.Any(), gomock.Eq(rotatedTokenPath), gomock.Eq(yt.NodeMap), gomock.Any()).
+			Return(yt.NodeID{}, nil)
+		mockYtClient.EXPECT().
+			SetNode(gomock.Any(), gomock.Eq(rotatedTokenPath.Attr("user")), gomock.Eq("alice"), gomock.Nil()).
+			Return(nil)
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(tokenPath), gomock.Eq(&yt.RemoveNodeOptions{Force: true})).
+			Return(nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(userPath.Attr("member_of")), gomock.Any(), gomock.Nil()).
+			SetArg(2, []string{"users", "analysts"}).
+			Return(nil)
+
+		syncUser()
+		Expect(user.Status.State).Should(Equal(ytv1.YtsaurusSubjectStateSynced))
+		Expect(user.Status.Created).Should(BeTrue())
+		Expect(user.Status.TokenHash).Should(Equal(sha256String("rotated")))
+
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(rotatedTokenPath), gomock.Eq(&yt.RemoveNodeOptions{Force: true})).
+			Return(nil)
+		mockYtClient.EXPECT().
+			RemoveNode(gomock.Any(), gomock.Eq(userPath), gomock.Eq(&yt.RemoveNodeOptions{Force: true})).
+			Return(nil)
+		Expect(newUser().Remove(ctx)).Should(BeTrue())
+	})
+
+	It("YtsaurusGroup Sync and Remove of an existing group", func() {
+		ctx := context.Background()
+		groupSpec := &ytv1.YtsaurusGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "analysts",
+				Namespace: "default",
+			},
+			Spec: ytv1.YtsaurusGroupSpec{
+				Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				Members:  []string{"alice", "bob"},
+			},
+			Status: ytv1.YtsaurusGroupStatus{
+				Members: []string{"dave"},
+			},
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ytsaurusSpec, groupSpec, clientSecret).
+			WithStatusSubresource(groupSpec).
+			Build()
+
+		group := &ytv1.YtsaurusGroup{}
+		newGroup := func() *YtsaurusGroup {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(groupSpec), group)).Should(Succeed())
+			proxy := apiproxy.NewYtsaurusGroup(group, k8sClient, record.NewFakeRecorder(100), scheme)
+			component := NewYtsaurusGroup(ytconfig.NewGenerator(ytsaurusSpec, "cluster_domain"), proxy, ytsaurusSpec)
+			component.ytClient = mockYtClient
+			Expect(component.Fetch(ctx)).Should(Succeed())
+			return component
+		}
+
+		// The group is adopted, members which were not added by the operator are kept.
+		groupPath := ypath.Path("//sys/groups/analysts")
+		mockYtClient.EXPECT().NodeExists(gomock.Any(), gomock.Eq(groupPath), gomock.Nil()).Return(true, nil)
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(groupPath.Attr("members")), gomock.Any(), gomock.Nil()).
+			SetArg(2, []string{"bob", "carol", "dave"}).
+			Return(nil)
+		mockYtClient.EXPECT().AddMember(gomock.Any(), "analysts", "alice", gomock.Nil()).Return(nil)
+		mockYtClient.EXPECT().RemoveMember(gomock.Any(), "analysts", "dave", gomock.Nil()).Return(nil)
+
+		Expect(newGroup().Sync(ctx)).Should(Succeed())
+		Expect(group.Status.State).Should(Equal(ytv1.YtsaurusSubjectStateSynced))
+		Expect(group.Status.Created).Should(BeFalse())
+		Expect(group.Status.Members).Should(Equal([]string{"alice"}))
+		Expect(k8sClient.Status().Update(ctx, group)).Should(Succeed())
+
+		// The adopted group is kept, only members added by the operator are removed.
+		mockYtClient.EXPECT().
+			GetNode(gomock.Any(), gomock.Eq(groupPath.Attr("members")), gomock.Any(), gomock.Nil()).
+			SetArg(2, []string{"alice", "bob", "carol"}).
+			Return(nil)
+		mockYtClient.EXPECT().RemoveMember(gomock.Any(), "analysts", "alice", gomock.Nil()).Return(nil)
+
+		Expect(newGroup().Remove(ctx)).Should(BeTrue())
+		Expect(group.Status.Members).Should(BeEmpty())
+	})
+})
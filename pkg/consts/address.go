@@ -6,6 +6,7 @@ const (
 	YTMonitoringContainerPortName = "metrics"
 	YTMonitoringServicePortName   = "ytsaurus-metrics"
 	YTMonitoringPort              = 10000
+	YTMonitoringMetricsPath       = "/solomon/all"
 )
 
 const (
@@ -15,6 +15,7 @@ const (
 	UICustomConfigMountPoint   = "/opt/app/dist/server/configs/custom"
 	UISecretsMountPoint        = "/opt/app/secrets"
 	UIVaultMountPoint          = "/vault"
+	SnapshotsExportMountPoint  = "/snapshots_export"
 )
 
 const (
@@ -35,6 +36,8 @@ const (
 	UISecretFileName        = "yt-interface-secret.json"
 	CABundleFileName        = "ca.crt"
 	TokenSecretKey          = "YT_TOKEN"
+
+	PreviousSpecConfigMapKey = "spec.json"
 )
 
 const (
@@ -50,15 +53,16 @@ const (
 )
 
 const (
-	ConfigTemplateVolumeName = "config-template"
-	ConfigVolumeName         = "config"
-	HTTPSSecretVolumeName    = "https-secret"
-	RPCSecretVolumeName      = "rpc-secret"
-	BusSecretVolumeName      = "bus-secret"
-	CABundleVolumeName       = "ca-bundle"
-	InitScriptVolumeName     = "init-script"
-	UIVaultVolumeName        = "vault"
-	UISecretsVolumeName      = "secrets"
+	ConfigTemplateVolumeName  = "config-template"
+	ConfigVolumeName          = "config"
+	HTTPSSecretVolumeName     = "https-secret"
+	RPCSecretVolumeName       = "rpc-secret"
+	BusSecretVolumeName       = "bus-secret"
+	CABundleVolumeName        = "ca-bundle"
+	InitScriptVolumeName      = "init-script"
+	UIVaultVolumeName         = "vault"
+	UISecretsVolumeName       = "secrets"
+	SnapshotsExportVolumeName = "snapshots-export"
 )
 
 const (
@@ -9,6 +9,8 @@ const ConditionTabletCellsRemoved = "TabletCellsRemoved"
 const ConditionSnapshotsBuildingStarted = "SnapshotsBuildingStarted"
 const ConditionSnapshotsMonitoringInfoSaved = "SnapshotsMonitoringInfoSaved"
 const ConditionSnaphotsSaved = "SnaphotsSaved"
+const ConditionMasterSnapshotsExportPrepared = "MasterSnapshotsExportPrepared"
+const ConditionMasterSnapshotsExported = "MasterSnapshotsExported"
 const ConditionTabletCellsRecovered = "TabletCellsRecovered"
 const ConditionOpArchiveUpdated = "OpArchiveUpdated"
 const ConditionOpArchivePreparedForUpdating = "OpArchivePreparedForUpdating"
@@ -17,4 +19,16 @@ const ConditionQTStateUpdated = "QTStateUpdated"
 const ConditionQTStatePreparedForUpdating = "QTStatePreparedForUpdating"
 const ConditionMasterExitReadOnlyPrepared = "MasterExitReadOnlyPrepared"
 const ConditionMasterExitedReadOnly = "MasterExitedReadOnly"
+const ConditionMasterQuorumVerified = "MasterQuorumVerified"
 const ConditionSafeModeDisabled = "SafeModeDisabled"
+const ConditionRollingBack = "RollingBack"
+const ConditionUpdateFailed = "UpdateFailed"
+const ConditionCertificatesReady = "CertificatesReady"
+
+// Reasons of UpdateFailed condition, the cluster keeps running the previous spec
+// while the condition observes the current generation with one of the rollback reasons.
+const UpdateFailedReasonRolledBack = "RolledBack"
+const UpdateFailedReasonRollbackFailed = "RollbackFailed"
+
+// Conditions of MasterRestore.
+const ConditionYtsaurusManagementPaused = "YtsaurusManagementPaused"
@@ -1,5 +1,7 @@
 package consts
 
+import "time"
+
 const DefaultAdminLogin = "admin"
 const DefaultAdminPassword = "password"
 
@@ -7,6 +9,9 @@ const AdminLoginSecret = "login"
 const AdminPasswordSecret = "password"
 const AdminTokenSecret = "token"
 
+const S3AccessKeyIDSecret = "accessKeyId"
+const S3SecretAccessKeySecret = "secretAccessKey"
+
 const DefaultCABundlePath = "/etc/ssl/certs/ca-certificates.crt"
 
 const UIUserName = "robot-ui"
@@ -21,5 +26,57 @@ const StartUID = 19500
 const DefaultHTTPProxyRole = "default"
 const DefaultName = "default"
 const DefaultMedium = "default"
+const DefaultQueryTrackerStage = "production"
 
 const MaxSlotLocationReserve = 10 << 30 // 10GiB
+
+// YtsaurusSubjectFinalizer keeps YtsaurusUser and YtsaurusGroup resources until they are removed from YTsaurus.
+const YtsaurusSubjectFinalizer = "cluster.ytsaurus.tech/ytsaurus-subject"
+
+// ChytCliqueFinalizer keeps ChytClique resources until cliques are removed from YTsaurus.
+const ChytCliqueFinalizer = "cluster.ytsaurus.tech/chyt-clique"
+
+// ChytCliqueYtsaurusLabel remembers the cluster of ChytClique to remove the clique when the referenced Chyt is deleted first.
+const ChytCliqueYtsaurusLabel = "cluster.ytsaurus.tech/ytsaurus"
+
+// ReleaseCleanupFinalizer keeps Chyt and Spyt resources until their releases are removed from Cypress.
+const ReleaseCleanupFinalizer = "cluster.ytsaurus.tech/release-cleanup"
+
+// Secrets with certificates issued by the operator when managedCertificates are enabled.
+const (
+	CertificateAuthoritySecretName       = "yt-ca-tls"
+	NativeTransportCertificateSecretName = "yt-native-transport-tls"
+	HTTPSCertificateSecretName           = "yt-https-tls"
+)
+
+const DefaultCertificateDuration = 90 * 24 * time.Hour
+const DefaultCertificateRenewBefore = 30 * 24 * time.Hour
+const CertificateAuthorityDuration = 10 * 365 * 24 * time.Hour
+
+// ManagedCertificatesCheckPeriod is how often running clusters are checked for renewed certificates.
+const ManagedCertificatesCheckPeriod = 10 * time.Minute
+
+// RemoteClustersCheckPeriod is how often running clusters check connectivity of remote clusters.
+const RemoteClustersCheckPeriod = 5 * time.Minute
+
+// RemoteClusterCheckTimeout limits connection to a remote cluster in its connectivity check.
+const RemoteClusterCheckTimeout = 30 * time.Second
+
+// TLSSecretVersionAnnotationPrefix marks pod templates with versions of mounted TLS secrets,
+// pods are restarted when secret content changes.
+const TLSSecretVersionAnnotationPrefix = "cluster.ytsaurus.tech/tls-secret-version-"
+
+// ConfigVersionAnnotation marks pod templates of canary updates with version of configs,
+// canary pods are restarted when configs change.
+const ConfigVersionAnnotation = "cluster.ytsaurus.tech/config-version"
+
+// MaxUpdateHistoryLength is the number of recent updates kept in the status of Ytsaurus.
+const MaxUpdateHistoryLength = 10
+
+// IgnoreMaintenanceWindowsAnnotation on Ytsaurus with value "true" allows the next update outside of maintenance windows,
+// the annotation is removed once the update starts.
+const IgnoreMaintenanceWindowsAnnotation = "cluster.ytsaurus.tech/ignore-maintenance-windows"
+
+// MaintenanceWindowCheckPeriod limits the delay of checks while the update waits for a maintenance window,
+// so changes of windows and the annotation are noticed.
+const MaintenanceWindowCheckPeriod = 5 * time.Minute
@@ -6,6 +6,7 @@ const YTMetricsLabelName = "yt_metrics"
 const (
 	YTComponentLabelDiscovery       string = "yt-discovery"
 	YTComponentLabelMaster          string = "yt-master"
+	YTComponentLabelSecondaryMaster string = "yt-secondary-master"
 	YTComponentLabelScheduler       string = "yt-scheduler"
 	YTComponentLabelControllerAgent string = "yt-controller-agent"
 	YTComponentLabelDataNode        string = "yt-data-node"
@@ -18,4 +19,6 @@ const (
 	YTComponentLabelYqlAgent        string = "yt-yql-agent"
 	YTComponentLabelClient          string = "yt-client"
 	YTComponentLabelMasterCache     string = "yt-master-cache"
+	YTComponentLabelCertificates    string = "yt-certificates"
+	YTComponentLabelRemoteClusters  string = "yt-remote-clusters"
 )
@@ -12,8 +12,10 @@ const (
 	MasterType               ComponentType = "Master"
 	QueryTrackerType         ComponentType = "QueryTracker"
 	QueueAgentType           ComponentType = "QueueAgent"
+	RemoteClustersType       ComponentType = "RemoteClusters"
 	RpcProxyType             ComponentType = "RpcProxy"
 	SchedulerType            ComponentType = "Scheduler"
+	SecondaryMasterType      ComponentType = "SecondaryMaster"
 	StrawberryControllerType ComponentType = "StrawberryController"
 	TabletNodeType           ComponentType = "TabletNode"
 	TcpProxyType             ComponentType = "TcpProxy"
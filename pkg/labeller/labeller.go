@@ -27,6 +27,16 @@ func (l *Labeller) GetClusterName() string {
 	return l.ObjectMeta.Name
 }
 
+// GetComponentTypeAndGroup splits component name into component type
+// and name of the instance group (role or name of node group), "default" if there is none.
+func (l *Labeller) GetComponentTypeAndGroup() (string, string) {
+	componentType, group, found := strings.Cut(l.ComponentName, "-")
+	if !found {
+		group = consts.DefaultName
+	}
+	return componentType, group
+}
+
 func (l *Labeller) GetSecretName() string {
 	return fmt.Sprintf("%s-secret", l.ComponentLabel)
 }
@@ -44,7 +54,15 @@ func (l *Labeller) GetInitJobName(name string) string {
 }
 
 func (l *Labeller) GetPodsRemovingStartedCondition() string {
-	return fmt.Sprintf("%sPodsRemovingStarted", l.ComponentName)
+	return GetPodsRemovingStartedCondition(l.ComponentName)
+}
+
+func (l *Labeller) GetPodsUpdatingStartedCondition() string {
+	return fmt.Sprintf("%sPodsUpdatingStarted", l.ComponentName)
+}
+
+func (l *Labeller) GetNodesDrainStartedCondition() string {
+	return fmt.Sprintf("%sNodesDrainStarted", l.ComponentName)
 }
 
 func (l *Labeller) GetObjectMeta(name string) metav1.ObjectMeta {
@@ -104,6 +122,38 @@ func (l *Labeller) GetMonitoringMetaLabelMap() map[string]string {
 	return labels
 }
 
+func GetPodsRemovingStartedCondition(componentName string) string {
+	return fmt.Sprintf("%sPodsRemovingStarted", componentName)
+}
+
 func GetPodsRemovedCondition(componentName string) string {
 	return fmt.Sprintf("%sPodsRemoved", componentName)
 }
+
+func GetPodsUpdatedCondition(componentName string) string {
+	return fmt.Sprintf("%sPodsUpdated", componentName)
+}
+
+func GetNodesDrainedCondition(componentName string) string {
+	return fmt.Sprintf("%sNodesDrained", componentName)
+}
+
+func GetNodesUndrainedCondition(componentName string) string {
+	return fmt.Sprintf("%sNodesUndrained", componentName)
+}
+
+func GetNodeBannedCondition(podName string) string {
+	return fmt.Sprintf("NodeBanned-%s", podName)
+}
+
+func GetNodeDrainedCondition(podName string) string {
+	return fmt.Sprintf("NodeDrained-%s", podName)
+}
+
+func GetNodeRestartedCondition(podName string) string {
+	return fmt.Sprintf("NodeRestarted-%s", podName)
+}
+
+func GetNodeUpdatedCondition(podName string) string {
+	return fmt.Sprintf("NodeUpdated-%s", podName)
+}
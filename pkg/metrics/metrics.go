@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+)
+
+// Metrics are exposed with controller-runtime metrics on metrics-bind-address.
+// Each series is labeled with namespace and name of the Ytsaurus resource.
+
+const (
+	metricsNamespace = "ytop"
+
+	labelNamespace = "namespace"
+	labelYtsaurus  = "ytsaurus"
+	labelState     = "state"
+	labelComponent = "component"
+	labelStatus    = "status"
+	labelJob       = "job"
+	labelReason    = "reason"
+)
+
+var (
+	clusterState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "cluster_state",
+			Help:      "Current state of the cluster, 1 for the current state.",
+		},
+		[]string{labelNamespace, labelYtsaurus, labelState},
+	)
+	updateState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "update_state",
+			Help:      "Current state of the cluster update, 1 for the current state.",
+		},
+		[]string{labelNamespace, labelYtsaurus, labelState},
+	)
+	updateStateTransitionTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "update_state_transition_timestamp_seconds",
+			Help:      "Time when the cluster update entered the current state.",
+		},
+		[]string{labelNamespace, labelYtsaurus},
+	)
+	updateStateDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "update_state_duration_seconds",
+			Help:      "Time spent by the cluster update in each state.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		},
+		[]string{labelNamespace, labelYtsaurus, labelState},
+	)
+	componentSyncStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_sync_status",
+			Help:      "Sync status of the cluster component, 1 for the current status.",
+		},
+		[]string{labelNamespace, labelYtsaurus, labelComponent, labelStatus},
+	)
+	componentErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_reconcile_errors_total",
+			Help:      "Number of errors while fetching, checking or syncing the cluster component.",
+		},
+		[]string{labelNamespace, labelYtsaurus, labelComponent},
+	)
+	initJobDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "init_job_duration_seconds",
+			Help:      "Time between start and completion of component init jobs.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		},
+		[]string{labelNamespace, labelYtsaurus, labelComponent, labelJob},
+	)
+	possibilityCheckFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "update_possibility_check_failures_total",
+			Help:      "Number of failed checks of update possibility by reason.",
+		},
+		[]string{labelNamespace, labelYtsaurus, labelReason},
+	)
+)
+
+var componentSyncStatuses = []string{"Blocked", "NeedLocalUpdate", "Pending", "Ready", "Updating"}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		clusterState,
+		updateState,
+		updateStateTransitionTime,
+		updateStateDuration,
+		componentSyncStatus,
+		componentErrors,
+		initJobDuration,
+		possibilityCheckFailures,
+	)
+}
+
+func clusterLabels(namespace, name string) prometheus.Labels {
+	return prometheus.Labels{
+		labelNamespace: namespace,
+		labelYtsaurus:  name,
+	}
+}
+
+// ReportClusterState sets current cluster and update states of the resource.
+func ReportClusterState(ytsaurus *ytv1.Ytsaurus) {
+	labels := clusterLabels(ytsaurus.Namespace, ytsaurus.Name)
+	clusterState.DeletePartialMatch(labels)
+	clusterState.WithLabelValues(ytsaurus.Namespace, ytsaurus.Name, string(ytsaurus.Status.State)).Set(1)
+
+	updateStatus := &ytsaurus.Status.UpdateStatus
+	updateState.DeletePartialMatch(labels)
+	state := updateStatus.State
+	if state == "" {
+		state = ytv1.UpdateStateNone
+	}
+	updateState.WithLabelValues(ytsaurus.Namespace, ytsaurus.Name, string(state)).Set(1)
+	if updateStatus.StateTransitionTime != nil {
+		updateStateTransitionTime.With(labels).Set(float64(updateStatus.StateTransitionTime.Unix()))
+	} else {
+		updateStateTransitionTime.Delete(labels)
+	}
+}
+
+// ObserveUpdateStateDuration records time spent by the update in the state it has left.
+func ObserveUpdateStateDuration(namespace, name string, state ytv1.UpdateState, duration time.Duration) {
+	updateStateDuration.WithLabelValues(namespace, name, string(state)).Observe(duration.Seconds())
+}
+
+// ResetComponentSyncStatuses forgets statuses of components, which may have been removed from the spec.
+func ResetComponentSyncStatuses(namespace, name string) {
+	componentSyncStatus.DeletePartialMatch(clusterLabels(namespace, name))
+}
+
+func ReportComponentSyncStatus(namespace, name, component, status string) {
+	for _, s := range componentSyncStatuses {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+		componentSyncStatus.WithLabelValues(namespace, name, component, s).Set(value)
+	}
+}
+
+func ReportComponentError(namespace, name, component string) {
+	componentErrors.WithLabelValues(namespace, name, component).Inc()
+}
+
+func ObserveInitJobDuration(namespace, name, component, job string, duration time.Duration) {
+	initJobDuration.WithLabelValues(namespace, name, component, job).Observe(duration.Seconds())
+}
+
+func ReportPossibilityCheckFailure(namespace, name, reason string) {
+	possibilityCheckFailures.WithLabelValues(namespace, name, reason).Inc()
+}
+
+// DeleteCluster removes all series of the deleted resource.
+func DeleteCluster(namespace, name string) {
+	labels := clusterLabels(namespace, name)
+	clusterState.DeletePartialMatch(labels)
+	updateState.DeletePartialMatch(labels)
+	updateStateTransitionTime.DeletePartialMatch(labels)
+	updateStateDuration.DeletePartialMatch(labels)
+	componentSyncStatus.DeletePartialMatch(labels)
+	componentErrors.DeletePartialMatch(labels)
+	initJobDuration.DeletePartialMatch(labels)
+	possibilityCheckFailures.DeletePartialMatch(labels)
+}
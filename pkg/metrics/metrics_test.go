@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+)
+
+func TestReportClusterState(t *testing.T) {
+	ytsaurus := &ytv1.Ytsaurus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Status: ytv1.YtsaurusStatus{
+			State: ytv1.ClusterStateRunning,
+		},
+	}
+	ReportClusterState(ytsaurus)
+	require.Equal(t, 1.0, testutil.ToFloat64(clusterState.WithLabelValues("default", "test", string(ytv1.ClusterStateRunning))))
+	require.Equal(t, 1.0, testutil.ToFloat64(updateState.WithLabelValues("default", "test", string(ytv1.UpdateStateNone))))
+
+	transitionTime := metav1.NewTime(time.Unix(1700000000, 0))
+	ytsaurus.Status.State = ytv1.ClusterStateUpdating
+	ytsaurus.Status.UpdateStatus.State = ytv1.UpdateStateWaitingForPodsRemoval
+	ytsaurus.Status.UpdateStatus.StateTransitionTime = &transitionTime
+	ReportClusterState(ytsaurus)
+	require.Equal(t, 1, testutil.CollectAndCount(clusterState))
+	require.Equal(t, 1, testutil.CollectAndCount(updateState))
+	require.Equal(t, 1.0, testutil.ToFloat64(updateState.WithLabelValues("default", "test", string(ytv1.UpdateStateWaitingForPodsRemoval))))
+	require.Equal(t, 1700000000.0, testutil.ToFloat64(updateStateTransitionTime.WithLabelValues("default", "test")))
+
+	DeleteCluster("default", "test")
+	require.Equal(t, 0, testutil.CollectAndCount(clusterState))
+	require.Equal(t, 0, testutil.CollectAndCount(updateStateTransitionTime))
+}
+
+func TestReportComponentSyncStatus(t *testing.T) {
+	ReportComponentSyncStatus("default", "test", "Master", "Blocked")
+	require.Equal(t, 1.0, testutil.ToFloat64(componentSyncStatus.WithLabelValues("default", "test", "Master", "Blocked")))
+	require.Equal(t, 0.0, testutil.ToFloat64(componentSyncStatus.WithLabelValues("default", "test", "Master", "Ready")))
+
+	ReportComponentSyncStatus("default", "test", "Master", "Ready")
+	require.Equal(t, 0.0, testutil.ToFloat64(componentSyncStatus.WithLabelValues("default", "test", "Master", "Blocked")))
+	require.Equal(t, 1.0, testutil.ToFloat64(componentSyncStatus.WithLabelValues("default", "test", "Master", "Ready")))
+
+	ResetComponentSyncStatuses("default", "test")
+	require.Equal(t, 0, testutil.CollectAndCount(componentSyncStatus))
+}
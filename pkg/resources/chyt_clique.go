@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+)
+
+// ChytClique is a clique declared by another resource, e.g. the default clique of a Chyt release.
+type ChytClique struct {
+	name     string
+	labeller *labeller.Labeller
+	apiProxy apiproxy.APIProxy
+
+	oldObject ytv1.ChytClique
+	newObject ytv1.ChytClique
+}
+
+func NewChytClique(name string, labeller *labeller.Labeller, apiProxy apiproxy.APIProxy) *ChytClique {
+	return &ChytClique{
+		name:     name,
+		labeller: labeller,
+		apiProxy: apiProxy,
+	}
+}
+
+func (c *ChytClique) OldObject() client.Object {
+	return &c.oldObject
+}
+
+func (c *ChytClique) Name() string {
+	return c.name
+}
+
+func (c *ChytClique) NeedSync(spec ytv1.ChytCliqueSpec) bool {
+	return !Exists(c) || !equality.Semantic.DeepEqual(c.oldObject.Spec, spec)
+}
+
+func (c *ChytClique) Sync(ctx context.Context) error {
+	return c.apiProxy.SyncObject(ctx, &c.oldObject, &c.newObject)
+}
+
+func (c *ChytClique) Build() *ytv1.ChytClique {
+	c.newObject.ObjectMeta = c.labeller.GetObjectMeta(c.name)
+	// Finalizer and the cluster label are managed by the clique controller.
+	c.newObject.Finalizers = c.oldObject.Finalizers
+	if name, ok := c.oldObject.Labels[consts.ChytCliqueYtsaurusLabel]; ok {
+		metav1.SetMetaDataLabel(&c.newObject.ObjectMeta, consts.ChytCliqueYtsaurusLabel, name)
+	}
+	return &c.newObject
+}
+
+func (c *ChytClique) Fetch(ctx context.Context) error {
+	return c.apiProxy.FetchObject(ctx, c.name, &c.oldObject)
+}
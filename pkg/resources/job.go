@@ -2,8 +2,10 @@ package resources
 
 import (
 	"context"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
@@ -39,6 +41,25 @@ func (j *Job) Completed() bool {
 	return j.oldObject.Status.Succeeded > 0
 }
 
+// Duration returns time between start and completion of the job, false if it is not completed.
+func (j *Job) Duration() (time.Duration, bool) {
+	status := &j.oldObject.Status
+	if status.StartTime == nil || status.CompletionTime == nil {
+		return 0, false
+	}
+	return status.CompletionTime.Sub(status.StartTime.Time), true
+}
+
+// Failed returns true when the job has reached its backoff limit.
+func (j *Job) Failed() bool {
+	for _, condition := range j.oldObject.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func (j *Job) Sync(ctx context.Context) error {
 	return j.apiProxy.SyncObject(ctx, &j.oldObject, &j.newObject)
 }
@@ -0,0 +1,184 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+	labeller2 "github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+)
+
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// ServiceMonitor is Prometheus operator ServiceMonitor for the monitoring service of a component.
+// Prometheus operator is an optional dependency, so the object is managed as unstructured
+// and is skipped if its CRD is not installed. The object is removed when the spec is nil, i.e. monitoring is disabled.
+type ServiceMonitor struct {
+	name     string
+	labeller *labeller2.Labeller
+	apiProxy apiproxy.APIProxy
+	spec     *ytv1.MonitoringSpec
+
+	unavailable bool
+
+	oldObject unstructured.Unstructured
+	newObject unstructured.Unstructured
+}
+
+func NewServiceMonitor(spec *ytv1.MonitoringSpec, labeller *labeller2.Labeller, apiProxy apiproxy.APIProxy) *ServiceMonitor {
+	s := &ServiceMonitor{
+		name:     fmt.Sprintf("%s-monitoring", labeller.ComponentLabel),
+		labeller: labeller,
+		apiProxy: apiProxy,
+		spec:     spec,
+	}
+	s.oldObject.SetGroupVersionKind(ServiceMonitorGVK)
+	s.newObject.SetGroupVersionKind(ServiceMonitorGVK)
+	return s
+}
+
+func (s *ServiceMonitor) OldObject() client.Object {
+	return &s.oldObject
+}
+
+func (s *ServiceMonitor) Name() string {
+	return s.name
+}
+
+// IsAvailable checks that ServiceMonitor CRD is installed, valid after Fetch.
+func (s *ServiceMonitor) IsAvailable() bool {
+	return !s.unavailable
+}
+
+func (s *ServiceMonitor) Fetch(ctx context.Context) error {
+	err := s.apiProxy.FetchObject(ctx, s.name, &s.oldObject)
+	if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+		if !s.unavailable {
+			log.FromContext(ctx).V(1).Info("ServiceMonitor CRD is not installed, skipping", "name", s.name)
+		}
+		s.unavailable = true
+		return nil
+	}
+	s.unavailable = false
+	return err
+}
+
+// NeedSync checks that ServiceMonitor is missing or differs from the spec, or has to be removed.
+func (s *ServiceMonitor) NeedSync() bool {
+	if s.unavailable {
+		return false
+	}
+	if s.spec == nil {
+		return Exists(s)
+	}
+	if !Exists(s) {
+		return true
+	}
+	newObject := s.Build()
+	return !reflect.DeepEqual(s.oldObject.Object["spec"], newObject.Object["spec"]) ||
+		!reflect.DeepEqual(s.oldObject.GetLabels(), newObject.GetLabels())
+}
+
+func (s *ServiceMonitor) Sync(ctx context.Context) error {
+	if s.unavailable {
+		return nil
+	}
+	if s.spec == nil {
+		if !Exists(s) {
+			return nil
+		}
+		log.FromContext(ctx).Info("Monitoring is disabled, removing ServiceMonitor", "name", s.name)
+		return s.apiProxy.DeleteObject(ctx, &s.oldObject)
+	}
+	return s.apiProxy.SyncObject(ctx, &s.oldObject, &s.newObject)
+}
+
+func (s *ServiceMonitor) Build() *unstructured.Unstructured {
+	if s.spec == nil {
+		return &s.newObject
+	}
+
+	componentType, group := s.labeller.GetComponentTypeAndGroup()
+
+	labels := s.labeller.GetMetaLabelMap(false)
+	for key, value := range s.spec.Labels {
+		labels[key] = value
+	}
+
+	selector := s.labeller.GetSelectorLabelMap()
+	selector[consts.YTMetricsLabelName] = "true"
+	matchLabels := make(map[string]interface{}, len(selector))
+	for key, value := range selector {
+		matchLabels[key] = value
+	}
+
+	endpoint := map[string]interface{}{
+		"port": consts.YTMonitoringServicePortName,
+		"path": consts.YTMonitoringMetricsPath,
+		"relabelings": []interface{}{
+			newLabelRelabeling("yt_cluster", s.labeller.GetClusterName()),
+			newLabelRelabeling("yt_component", componentType),
+			newLabelRelabeling("yt_group", group),
+		},
+	}
+	if s.spec.ScrapeInterval != "" {
+		endpoint["interval"] = s.spec.ScrapeInterval
+	}
+	if s.spec.ScrapeTimeout != "" {
+		endpoint["scrapeTimeout"] = s.spec.ScrapeTimeout
+	}
+
+	var metricRelabelings []interface{}
+	if len(s.spec.KeepMetrics) != 0 {
+		metricRelabelings = append(metricRelabelings, newMetricNameRelabeling("keep", s.spec.KeepMetrics))
+	}
+	if len(s.spec.DropMetrics) != 0 {
+		metricRelabelings = append(metricRelabelings, newMetricNameRelabeling("drop", s.spec.DropMetrics))
+	}
+	if metricRelabelings != nil {
+		endpoint["metricRelabelings"] = metricRelabelings
+	}
+
+	s.newObject.SetName(s.name)
+	s.newObject.SetNamespace(s.labeller.ObjectMeta.Namespace)
+	s.newObject.SetLabels(labels)
+	s.newObject.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"endpoints": []interface{}{endpoint},
+	}
+
+	return &s.newObject
+}
+
+func newLabelRelabeling(label, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"action":      "replace",
+		"targetLabel": label,
+		"replacement": value,
+	}
+}
+
+func newMetricNameRelabeling(action string, regexps []string) map[string]interface{} {
+	return map[string]interface{}{
+		"action":       action,
+		"sourceLabels": []interface{}{"__name__"},
+		"regex":        strings.Join(regexps, "|"),
+	}
+}
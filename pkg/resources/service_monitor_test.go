@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/labeller"
+)
+
+func newTestServiceMonitor(t *testing.T, withCRD bool) (*ServiceMonitor, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ytv1.AddToScheme(scheme))
+	ytsaurus := &ytv1.Ytsaurus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ytsaurus)
+	if !withCRD {
+		// API server does not know the kind if CRD is not installed.
+		builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return &meta.NoKindMatchError{GroupKind: ServiceMonitorGVK.GroupKind()}
+			},
+		})
+	}
+	k8sClient := builder.Build()
+	proxy := apiproxy.NewAPIProxy(ytsaurus, k8sClient, record.NewFakeRecorder(10), scheme)
+	l := &labeller.Labeller{
+		ObjectMeta:     &ytsaurus.ObjectMeta,
+		APIProxy:       proxy,
+		ComponentLabel: "yt-http-proxy-control",
+		ComponentName:  "HttpProxy-control",
+	}
+	spec := &ytv1.MonitoringSpec{
+		ScrapeInterval: "30s",
+		Labels:         map[string]string{"release": "prometheus"},
+		DropMetrics:    []string{"yt_bus_.*", "yt_rpc_.*"},
+	}
+	return NewServiceMonitor(spec, l, proxy), k8sClient
+}
+
+func TestServiceMonitor(t *testing.T) {
+	ctx := context.Background()
+	serviceMonitor, k8sClient := newTestServiceMonitor(t, true)
+
+	require.NoError(t, serviceMonitor.Fetch(ctx))
+	require.True(t, serviceMonitor.IsAvailable())
+	require.True(t, serviceMonitor.NeedSync())
+
+	serviceMonitor.Build()
+	require.NoError(t, serviceMonitor.Sync(ctx))
+	require.NoError(t, serviceMonitor.Fetch(ctx))
+	require.False(t, serviceMonitor.NeedSync())
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(ServiceMonitorGVK)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "yt-http-proxy-control-monitoring"}, object))
+	require.Equal(t, "prometheus", object.GetLabels()["release"])
+
+	endpoints, _, err := unstructured.NestedSlice(object.Object, "spec", "endpoints")
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	endpoint := endpoints[0].(map[string]interface{})
+	require.Equal(t, "30s", endpoint["interval"])
+	require.Equal(t, "/solomon/all", endpoint["path"])
+	require.Contains(t, endpoint["relabelings"], map[string]interface{}{
+		"action":      "replace",
+		"targetLabel": "yt_group",
+		"replacement": "control",
+	})
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"action":       "drop",
+			"sourceLabels": []interface{}{"__name__"},
+			"regex":        "yt_bus_.*|yt_rpc_.*",
+		},
+	}, endpoint["metricRelabelings"])
+
+	matchLabels, _, err := unstructured.NestedStringMap(object.Object, "spec", "selector", "matchLabels")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"yt_component": "test-yt-http-proxy-control",
+		"yt_metrics":   "true",
+	}, matchLabels)
+
+	serviceMonitor.spec.ScrapeInterval = "1m"
+	require.True(t, serviceMonitor.NeedSync())
+}
+
+func TestServiceMonitorRemovedWhenMonitoringDisabled(t *testing.T) {
+	ctx := context.Background()
+	serviceMonitor, k8sClient := newTestServiceMonitor(t, true)
+
+	serviceMonitor.Build()
+	require.NoError(t, serviceMonitor.Sync(ctx))
+	require.NoError(t, serviceMonitor.Fetch(ctx))
+	require.True(t, Exists(serviceMonitor))
+
+	disabled := NewServiceMonitor(nil, serviceMonitor.labeller, serviceMonitor.apiProxy)
+	require.NoError(t, disabled.Fetch(ctx))
+	require.True(t, disabled.NeedSync())
+	disabled.Build()
+	require.NoError(t, disabled.Sync(ctx))
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(ServiceMonitorGVK)
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "yt-http-proxy-control-monitoring"}, object)
+	require.True(t, apierrors.IsNotFound(err))
+
+	disabled = NewServiceMonitor(nil, serviceMonitor.labeller, serviceMonitor.apiProxy)
+	require.NoError(t, disabled.Fetch(ctx))
+	require.False(t, disabled.NeedSync())
+}
+
+func TestServiceMonitorWithoutCRD(t *testing.T) {
+	ctx := context.Background()
+	serviceMonitor, _ := newTestServiceMonitor(t, false)
+
+	require.NoError(t, serviceMonitor.Fetch(ctx))
+	require.False(t, serviceMonitor.IsAvailable())
+	require.False(t, serviceMonitor.NeedSync())
+	serviceMonitor.Build()
+	require.NoError(t, serviceMonitor.Sync(ctx))
+}
@@ -2,6 +2,8 @@ package resources
 
 import (
 	"context"
+	"maps"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -62,8 +64,9 @@ func (s *StatefulSet) Build() *appsv1.StatefulSet {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      s.labeller.GetMetaLabelMap(false),
-					Annotations: s.commonSpec.ExtraPodAnnotations,
+					Labels: s.labeller.GetMetaLabelMap(false),
+					// Annotations are modified per component, so common ones are copied.
+					Annotations: maps.Clone(s.commonSpec.ExtraPodAnnotations),
 				},
 			},
 		}
@@ -134,6 +137,104 @@ func (s *StatefulSet) ArePodsReady(ctx context.Context, minReadyInstanceCount *i
 	return true
 }
 
+// RollPods deletes a batch of pods which were not recreated since the update start,
+// the statefulset controller recreates them from the current template.
+// Returns true when all pods are recreated and ready.
+func (s *StatefulSet) RollPods(ctx context.Context, updateStartedAt time.Time, batchSize, maxUnavailable int32) (bool, error) {
+	logger := log.FromContext(ctx)
+	podList := s.getPods(ctx)
+	if podList == nil {
+		return false, nil
+	}
+
+	var replicas int32
+	if s.oldObject.Spec.Replicas != nil {
+		replicas = *s.oldObject.Spec.Replicas
+	}
+
+	podsToDelete, done := selectPodsToRoll(
+		podList.Items,
+		replicas,
+		s.oldObject.Status.UpdateRevision,
+		updateStartedAt,
+		batchSize,
+		maxUnavailable)
+	for i := range podsToDelete {
+		pod := &podsToDelete[i]
+		logger.Info("deleting pod for rolling update", "podName", pod.Name, "component", s.labeller.ComponentName)
+		if err := s.proxy.DeleteObject(ctx, pod); err != nil {
+			return false, err
+		}
+	}
+	return done, nil
+}
+
+// IsPodReady checks that pod is running and passes readiness probe.
+func IsPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isPodOutdated(pod *corev1.Pod, updateRevision string, updateStartedAt time.Time) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	if pod.CreationTimestamp.Time.Before(updateStartedAt) {
+		return true
+	}
+	return updateRevision != "" && pod.Labels[appsv1.ControllerRevisionHashLabelKey] != updateRevision
+}
+
+// selectPodsToRoll chooses pods to delete within the current rolling update step.
+// Outdated pods which are not ready are deleted first since they do not affect availability,
+// ready ones are deleted while the number of unavailable pods stays within maxUnavailable.
+func selectPodsToRoll(
+	pods []corev1.Pod,
+	replicas int32,
+	updateRevision string,
+	updateStartedAt time.Time,
+	batchSize, maxUnavailable int32,
+) ([]corev1.Pod, bool) {
+	unavailable := replicas - int32(len(pods))
+	if unavailable < 0 {
+		unavailable = 0
+	}
+
+	var outdatedReady, outdatedNotReady []corev1.Pod
+	for _, pod := range pods {
+		ready := IsPodReady(&pod)
+		if !ready {
+			unavailable++
+		}
+		if !isPodOutdated(&pod, updateRevision, updateStartedAt) {
+			continue
+		}
+		if ready {
+			outdatedReady = append(outdatedReady, pod)
+		} else {
+			outdatedNotReady = append(outdatedNotReady, pod)
+		}
+	}
+
+	if len(outdatedReady) == 0 && len(outdatedNotReady) == 0 {
+		return nil, unavailable == 0
+	}
+
+	podsToDelete := outdatedNotReady
+	budget := min(maxUnavailable-unavailable, batchSize)
+	for i := int32(0); i < budget && int(i) < len(outdatedReady); i++ {
+		podsToDelete = append(podsToDelete, outdatedReady[i])
+	}
+	return podsToDelete, false
+}
+
 func (s *StatefulSet) NeedSync(replicas int32) bool {
 	return s.oldObject.Spec.Replicas == nil ||
 		*s.oldObject.Spec.Replicas != replicas
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestPod(name string, createdAt time.Time, revision string, ready bool) corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(createdAt),
+			Labels: map[string]string{
+				appsv1.ControllerRevisionHashLabelKey: revision,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: readyStatus},
+			},
+		},
+	}
+}
+
+func podNames(pods []corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestSelectPodsToRoll(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := startedAt.Add(-time.Hour)
+	after := startedAt.Add(time.Minute)
+
+	t.Run("first batch", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newTestPod("hp-0", before, "old", true),
+			newTestPod("hp-1", before, "old", true),
+			newTestPod("hp-2", before, "old", true),
+		}
+		toDelete, done := selectPodsToRoll(pods, 3, "new", startedAt, 2, 2)
+		require.False(t, done)
+		require.Equal(t, []string{"hp-0", "hp-1"}, podNames(toDelete))
+	})
+
+	t.Run("wait for readiness", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newTestPod("hp-0", after, "new", false),
+			newTestPod("hp-1", before, "old", true),
+			newTestPod("hp-2", before, "old", true),
+		}
+		toDelete, done := selectPodsToRoll(pods, 3, "new", startedAt, 1, 1)
+		require.False(t, done)
+		require.Empty(t, toDelete)
+	})
+
+	t.Run("missing replica is unavailable", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newTestPod("hp-1", before, "old", true),
+			newTestPod("hp-2", before, "old", true),
+		}
+		toDelete, done := selectPodsToRoll(pods, 3, "new", startedAt, 1, 1)
+		require.False(t, done)
+		require.Empty(t, toDelete)
+	})
+
+	t.Run("not ready outdated pods are deleted first", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newTestPod("hp-0", before, "old", false),
+			newTestPod("hp-1", before, "old", true),
+		}
+		toDelete, done := selectPodsToRoll(pods, 2, "new", startedAt, 1, 1)
+		require.False(t, done)
+		require.Equal(t, []string{"hp-0"}, podNames(toDelete))
+	})
+
+	t.Run("done", func(t *testing.T) {
+		pods := []corev1.Pod{
+			newTestPod("hp-0", after, "new", true),
+			newTestPod("hp-1", after, "new", true),
+		}
+		toDelete, done := selectPodsToRoll(pods, 2, "new", startedAt, 1, 1)
+		require.True(t, done)
+		require.Empty(t, toDelete)
+	})
+}
@@ -1,7 +1,17 @@
 package resources
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/apiproxy"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 )
 
 // TLSSecret represents mounted kubernetes.io/tls secret
@@ -10,16 +20,28 @@ type TLSSecret struct {
 	SecretName string
 	VolumeName string
 	MountPath  string
+
+	apiProxy  apiproxy.APIProxy
+	oldObject corev1.Secret
 }
 
-func NewTLSSecret(secretName string, volumeName string, mountPath string) *TLSSecret {
+func NewTLSSecret(secretName string, volumeName string, mountPath string, apiProxy apiproxy.APIProxy) *TLSSecret {
 	return &TLSSecret{
 		SecretName: secretName,
 		VolumeName: volumeName,
 		MountPath:  mountPath,
+		apiProxy:   apiProxy,
 	}
 }
 
+func (t *TLSSecret) OldObject() client.Object {
+	return &t.oldObject
+}
+
+func (t *TLSSecret) Fetch(ctx context.Context) error {
+	return t.apiProxy.FetchObject(ctx, t.SecretName, &t.oldObject)
+}
+
 func (t *TLSSecret) AddVolume(podSpec *corev1.PodSpec) {
 	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
 		Name: t.VolumeName,
@@ -38,3 +60,46 @@ func (t *TLSSecret) AddVolumeMount(container *corev1.Container) {
 		ReadOnly:  true,
 	})
 }
+
+// GetVersion returns hash of the secret content, empty string if secret does not exist.
+func (t *TLSSecret) GetVersion() string {
+	if !Exists(t) {
+		return ""
+	}
+	return GetTLSSecretVersion(&t.oldObject)
+}
+
+// GetTLSSecretVersion returns hash of the secret content which is stored in pod annotations.
+func GetTLSSecretVersion(secret *corev1.Secret) string {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write(secret.Data[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// GetTLSSecretVersionAnnotation returns annotation with version of the secret mounted as the volume.
+func GetTLSSecretVersionAnnotation(volumeName string) string {
+	return consts.TLSSecretVersionAnnotationPrefix + volumeName
+}
+
+// AddVersionAnnotation remembers version of the secret mounted into pods.
+func (t *TLSSecret) AddVersionAnnotation(podTemplate *corev1.PodTemplateSpec) {
+	if version := t.GetVersion(); version != "" {
+		metav1.SetMetaDataAnnotation(&podTemplate.ObjectMeta, GetTLSSecretVersionAnnotation(t.VolumeName), version)
+	}
+}
+
+// IsVersionChanged checks that the secret has changed since pods were created from the template.
+// Templates without version annotation are not considered outdated to avoid restarts after operator upgrade.
+func (t *TLSSecret) IsVersionChanged(podTemplate *corev1.PodTemplateSpec) bool {
+	version := t.GetVersion()
+	oldVersion, ok := podTemplate.Annotations[GetTLSSecretVersionAnnotation(t.VolumeName)]
+	return ok && version != "" && version != oldVersion
+}
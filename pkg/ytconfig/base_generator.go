@@ -16,6 +16,7 @@ type BaseGenerator struct {
 	discoveryInstanceCount int32
 	dataNodesInstanceCount int32
 	masterCachesSpec       *ytv1.MasterCachesSpec
+	secondaryMastersSpecs  []ytv1.MastersSpec
 }
 
 func NewRemoteBaseGenerator(
@@ -23,14 +24,20 @@ func NewRemoteBaseGenerator(
 	clusterDomain string,
 	commonSpec ytv1.CommonSpec,
 	masterConnectionSpec ytv1.MasterConnectionSpec,
+	secondaryMasterConnectionSpecs []ytv1.MasterConnectionSpec,
 	masterCachesSpec *ytv1.MasterCachesSpec,
 ) *BaseGenerator {
+	secondaryMastersSpecs := make([]ytv1.MastersSpec, 0, len(secondaryMasterConnectionSpecs))
+	for _, spec := range secondaryMasterConnectionSpecs {
+		secondaryMastersSpecs = append(secondaryMastersSpecs, ytv1.MastersSpec{MasterConnectionSpec: spec})
+	}
 	return &BaseGenerator{
-		key:                  key,
-		clusterDomain:        clusterDomain,
-		commonSpec:           commonSpec,
-		masterConnectionSpec: masterConnectionSpec,
-		masterCachesSpec:     masterCachesSpec,
+		key:                   key,
+		clusterDomain:         clusterDomain,
+		commonSpec:            commonSpec,
+		masterConnectionSpec:  masterConnectionSpec,
+		masterCachesSpec:      masterCachesSpec,
+		secondaryMastersSpecs: secondaryMastersSpecs,
 	}
 }
 
@@ -54,6 +61,7 @@ func NewLocalBaseGenerator(
 		masterInstanceCount:    ytsaurus.Spec.PrimaryMasters.InstanceCount,
 		discoveryInstanceCount: ytsaurus.Spec.Discovery.InstanceCount,
 		masterCachesSpec:       ytsaurus.Spec.MasterCaches,
+		secondaryMastersSpecs:  ytsaurus.Spec.SecondaryMasters,
 		dataNodesInstanceCount: dataNodesInstanceCount,
 	}
 }
@@ -0,0 +1,137 @@
+package ytconfig
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
+)
+
+// IsNativeTransportCertificateManaged checks that certificate for native transport is issued by the operator.
+func IsNativeTransportCertificateManaged(commonSpec *ytv1.CommonSpec) bool {
+	return commonSpec.ManagedCertificates != nil && commonSpec.ManagedCertificates.NativeTransport
+}
+
+// IsHTTPSCertificateManaged checks that certificate for HTTPS of HTTP proxies is issued by the operator.
+func IsHTTPSCertificateManaged(commonSpec *ytv1.CommonSpec) bool {
+	return commonSpec.ManagedCertificates != nil && commonSpec.ManagedCertificates.HTTPS
+}
+
+// GetNativeTransport returns native transport spec of the component, common spec is used if component has none.
+// TLS secret is set to the certificate issued by the operator unless it is set explicitly.
+func GetNativeTransport(commonSpec *ytv1.CommonSpec, transport *ytv1.RPCTransportSpec) *ytv1.RPCTransportSpec {
+	if transport == nil {
+		transport = commonSpec.NativeTransport
+	}
+	if !IsNativeTransportCertificateManaged(commonSpec) || (transport != nil && transport.TLSSecret != nil) {
+		return transport
+	}
+	var result ytv1.RPCTransportSpec
+	if transport != nil {
+		result = *transport
+	}
+	result.TLSSecret = &corev1.LocalObjectReference{Name: consts.NativeTransportCertificateSecretName}
+	return &result
+}
+
+// GetHTTPTransport returns HTTP transport spec of HTTP proxies,
+// HTTPS secret is set to the certificate issued by the operator unless it is set explicitly.
+func GetHTTPTransport(commonSpec *ytv1.CommonSpec, transport ytv1.HTTPTransportSpec) ytv1.HTTPTransportSpec {
+	if IsHTTPSCertificateManaged(commonSpec) && transport.HTTPSSecret == nil {
+		transport.HTTPSSecret = &corev1.LocalObjectReference{Name: consts.HTTPSCertificateSecretName}
+	}
+	return transport
+}
+
+func (g *BaseGenerator) getServiceDNSNames(serviceName string) []string {
+	return []string{
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, g.key.Namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, g.key.Namespace),
+		g.getPodFqdnSuffix(serviceName),
+	}
+}
+
+// getHeadlessServiceDNSNames returns names of the headless service and all its pods.
+func (g *BaseGenerator) getHeadlessServiceDNSNames(serviceName string) []string {
+	return []string{
+		g.getPodFqdnSuffix(serviceName),
+		"*." + g.getPodFqdnSuffix(serviceName),
+	}
+}
+
+// GetNativeTransportCertificateDNSNames returns subject alternative names for native transport certificate:
+// pod FQDNs of all server components of the cluster.
+func (g *Generator) GetNativeTransportCertificateDNSNames() []string {
+	spec := &g.ytsaurus.Spec
+	nodeGenerator := &NodeGenerator{BaseGenerator: g.BaseGenerator}
+
+	serviceNames := []string{
+		g.GetMastersServiceName(),
+		g.GetDiscoveryServiceName(),
+	}
+	for i := range spec.SecondaryMasters {
+		serviceNames = append(serviceNames, g.GetSecondaryMastersServiceName(spec.SecondaryMasters[i].CellTag))
+	}
+	if spec.MasterCaches != nil {
+		serviceNames = append(serviceNames, g.GetMasterCachesServiceName())
+	}
+	for _, dataNodes := range spec.DataNodes {
+		serviceNames = append(serviceNames, nodeGenerator.GetDataNodesServiceName(dataNodes.Name))
+	}
+	for _, execNodes := range spec.ExecNodes {
+		serviceNames = append(serviceNames, nodeGenerator.GetExecNodesServiceName(execNodes.Name))
+	}
+	for _, tabletNodes := range spec.TabletNodes {
+		serviceNames = append(serviceNames, nodeGenerator.GetTabletNodesServiceName(tabletNodes.Name))
+	}
+	for _, httpProxies := range spec.HTTPProxies {
+		serviceNames = append(serviceNames, g.GetHTTPProxiesHeadlessServiceName(httpProxies.Role))
+	}
+	for _, rpcProxies := range spec.RPCProxies {
+		serviceNames = append(serviceNames, g.GetRPCProxiesHeadlessServiceName(rpcProxies.Role))
+	}
+	for _, tcpProxies := range spec.TCPProxies {
+		serviceNames = append(serviceNames, g.GetTCPProxiesHeadlessServiceName(tcpProxies.Role))
+	}
+	if spec.Schedulers != nil {
+		serviceNames = append(serviceNames, g.GetSchedulerServiceName())
+	}
+	if spec.ControllerAgents != nil {
+		serviceNames = append(serviceNames, g.GetControllerAgentsServiceName())
+	}
+	if spec.QueryTrackers != nil {
+		serviceNames = append(serviceNames, g.GetQueryTrackerServiceName(consts.DefaultQueryTrackerStage))
+		for _, stage := range spec.QueryTrackers.Stages {
+			serviceNames = append(serviceNames, g.GetQueryTrackerServiceName(stage.Name))
+		}
+	}
+	if spec.QueueAgents != nil {
+		serviceNames = append(serviceNames, g.GetQueueAgentServiceName())
+	}
+	if spec.YQLAgents != nil {
+		serviceNames = append(serviceNames, g.GetYQLAgentServiceName())
+	}
+
+	var dnsNames []string
+	for _, serviceName := range serviceNames {
+		dnsNames = append(dnsNames, g.getHeadlessServiceDNSNames(serviceName)...)
+	}
+	return dnsNames
+}
+
+// GetHTTPSCertificateDNSNames returns subject alternative names for HTTPS certificate:
+// balancing services and pods of all HTTP proxy roles and extra names from the spec.
+func (g *Generator) GetHTTPSCertificateDNSNames() []string {
+	var dnsNames []string
+	for _, httpProxies := range g.ytsaurus.Spec.HTTPProxies {
+		dnsNames = append(dnsNames, g.getServiceDNSNames(g.GetHTTPProxiesServiceName(httpProxies.Role))...)
+		dnsNames = append(dnsNames, g.getHeadlessServiceDNSNames(g.GetHTTPProxiesHeadlessServiceName(httpProxies.Role))...)
+	}
+	if managedCertificates := g.commonSpec.ManagedCertificates; managedCertificates != nil {
+		dnsNames = append(dnsNames, managedCertificates.ExtraDNSNames...)
+	}
+	return dnsNames
+}
@@ -3,6 +3,7 @@ package ytconfig
 import (
 	"fmt"
 
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 	"go.ytsaurus.tech/yt/go/yson"
 )
@@ -31,7 +32,34 @@ type ChytConfig struct {
 	AddressResolver AddressResolver `yson:"address_resolver"`
 }
 
-func getStrawberryController(resolver AddressResolver) (StrawberryController, error) {
+func getStrawberryControllerRoot(spec *ytv1.StrawberryControllerSpec) string {
+	if spec != nil && spec.Root != "" {
+		return spec.Root
+	}
+	return "//sys/strawberry"
+}
+
+// getStrawberryFamilyConfig returns the config of a controller family, top-level keys of
+// the config from the spec replace keys of the generated one.
+func getStrawberryFamilyConfig(base []byte, family ytv1.StrawberryControllerFamilySpec) (yson.RawValue, error) {
+	if family.Config == "" {
+		return base, nil
+	}
+	config := map[string]any{}
+	if err := yson.Unmarshal(base, &config); err != nil {
+		return nil, err
+	}
+	overrides := map[string]any{}
+	if err := yson.Unmarshal([]byte(family.Config), &overrides); err != nil {
+		return nil, fmt.Errorf("invalid config of strawberry family %s: %w", family.Name, err)
+	}
+	for key, value := range overrides {
+		config[key] = value
+	}
+	return marshallYsonConfig(config)
+}
+
+func getStrawberryController(resolver AddressResolver, spec *ytv1.StrawberryControllerSpec) (StrawberryController, error) {
 	chytConfig := ChytConfig{
 		AddressResolver: resolver,
 	}
@@ -39,23 +67,48 @@ func getStrawberryController(resolver AddressResolver) (StrawberryController, er
 	if err != nil {
 		return StrawberryController{}, err
 	}
+
+	stage := "production"
+	families := []ytv1.StrawberryControllerFamilySpec{{Name: "chyt"}}
+	if spec != nil {
+		if spec.Stage != "" {
+			stage = spec.Stage
+		}
+		if len(spec.Families) != 0 {
+			families = spec.Families
+		}
+	}
+
+	controllers := make(map[string]yson.RawValue, len(families))
+	for _, family := range families {
+		controllers[family.Name], err = getStrawberryFamilyConfig(chytYsonConfig, family)
+		if err != nil {
+			return StrawberryController{}, err
+		}
+	}
+
 	return StrawberryController{
 		Strawberry: Strawberry{
-			Root:  "//sys/strawberry",
-			Stage: "production",
+			Root:  getStrawberryControllerRoot(spec),
+			Stage: stage,
 
 			RobotUsername: consts.StrawberryControllerUserName,
 		},
-		Controllers: map[string]yson.RawValue{
-			"chyt": chytYsonConfig,
-		},
+		Controllers:     controllers,
 		HTTPAPIEndpoint: fmt.Sprintf(":%v", consts.StrawberryHTTPAPIPort),
 	}, nil
 }
 
-func getChytInitCluster() ChytInitCluster {
+func getChytInitCluster(spec *ytv1.StrawberryControllerSpec) ChytInitCluster {
+	families := []string{"chyt", "jupyt"}
+	if spec != nil && len(spec.Families) != 0 {
+		families = nil
+		for _, family := range spec.Families {
+			families = append(families, family.Name)
+		}
+	}
 	return ChytInitCluster{
-		StrawberryRoot: "//sys/strawberry",
-		Families:       []string{"chyt", "jupyt"},
+		StrawberryRoot: getStrawberryControllerRoot(spec),
+		Families:       families,
 	}
 }
@@ -37,12 +37,14 @@ type MasterCache struct {
 type Driver struct {
 	TimestampProviders TimestampProviders `yson:"timestamp_provider,omitempty"`
 	PrimaryMaster      MasterCell         `yson:"primary_master,omitempty"`
+	SecondaryMasters   []MasterCell       `yson:"secondary_masters,omitempty"`
 	APIVersion         int                `yson:"api_version,omitempty"`
 }
 
 type ClusterConnection struct {
 	ClusterName         string              `yson:"cluster_name"`
 	PrimaryMaster       MasterCell          `yson:"primary_master"`
+	SecondaryMasters    []MasterCell        `yson:"secondary_masters,omitempty"`
 	DiscoveryConnection DiscoveryConnection `yson:"discovery_connection,omitempty"`
 	BusClient           *Bus                `yson:"bus_client,omitempty"`
 	MasterCache         MasterCache         `yson:"master_cache"`
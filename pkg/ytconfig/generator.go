@@ -5,6 +5,7 @@ import (
 	"path"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/utils/ptr"
 
@@ -48,22 +49,27 @@ func NewGenerator(
 		ytsaurus:      ytsaurus,
 	}
 }
-func (g *BaseGenerator) getMasterPodFqdnSuffix() string {
+func (g *BaseGenerator) getPodFqdnSuffix(serviceName string) string {
 	return fmt.Sprintf("%s.%s.svc.%s",
-		g.GetMastersServiceName(),
+		serviceName,
 		g.key.Namespace,
 		g.clusterDomain)
 }
 
-func (g *BaseGenerator) getMasterAddresses() []string {
-	hosts := g.masterConnectionSpec.HostAddresses
+func (g *BaseGenerator) getMasterPodFqdnSuffix() string {
+	return g.getPodFqdnSuffix(g.GetMastersServiceName())
+}
 
+func (g *BaseGenerator) getSecondaryMasterPodFqdnSuffix(spec *ytv1.MastersSpec) string {
+	return g.getPodFqdnSuffix(g.GetSecondaryMastersServiceName(spec.CellTag))
+}
+
+func getMasterCellAddresses(hosts []string, podNames []string, podFqdnSuffix string) []string {
 	if len(hosts) == 0 {
-		masterPodSuffix := g.getMasterPodFqdnSuffix()
-		for _, podName := range g.GetMasterPodNames() {
+		for _, podName := range podNames {
 			hosts = append(hosts, fmt.Sprintf("%s.%s",
 				podName,
-				masterPodSuffix,
+				podFqdnSuffix,
 			))
 		}
 	}
@@ -75,9 +81,9 @@ func (g *BaseGenerator) getMasterAddresses() []string {
 	return addresses
 }
 
-func (g *BaseGenerator) getMasterHydraPeers() []HydraPeer {
-	peers := make([]HydraPeer, 0, g.masterInstanceCount)
-	for _, address := range g.getMasterAddresses() {
+func getMasterCellHydraPeers(addresses []string) []HydraPeer {
+	peers := make([]HydraPeer, 0, len(addresses))
+	for _, address := range addresses {
 		peers = append(peers, HydraPeer{
 			Address: address,
 			Voting:  true,
@@ -86,6 +92,24 @@ func (g *BaseGenerator) getMasterHydraPeers() []HydraPeer {
 	return peers
 }
 
+func (g *BaseGenerator) getMasterAddresses() []string {
+	return getMasterCellAddresses(
+		g.masterConnectionSpec.HostAddresses,
+		g.GetMasterPodNames(),
+		g.getMasterPodFqdnSuffix())
+}
+
+func (g *BaseGenerator) getSecondaryMasterAddresses(spec *ytv1.MastersSpec) []string {
+	return getMasterCellAddresses(
+		spec.HostAddresses,
+		g.GetSecondaryMasterPodNames(spec),
+		g.getSecondaryMasterPodFqdnSuffix(spec))
+}
+
+func (g *BaseGenerator) getMasterHydraPeers() []HydraPeer {
+	return getMasterCellHydraPeers(g.getMasterAddresses())
+}
+
 func (g *BaseGenerator) getDiscoveryAddresses() []string {
 	names := make([]string, 0, g.discoveryInstanceCount)
 	for _, podName := range g.GetDiscoveryPodNames() {
@@ -112,12 +136,21 @@ func (g *Generator) GetYQLAgentAddresses() []string {
 	return names
 }
 
-func (g *Generator) GetQueryTrackerAddresses() []string {
-	names := make([]string, 0, g.ytsaurus.Spec.QueryTrackers.InstanceCount)
-	for _, podName := range g.GetQueryTrackerPodNames() {
+func (g *Generator) getQueryTrackerInstanceSpec(stage string) *ytv1.InstanceSpec {
+	for i := range g.ytsaurus.Spec.QueryTrackers.Stages {
+		if g.ytsaurus.Spec.QueryTrackers.Stages[i].Name == stage {
+			return &g.ytsaurus.Spec.QueryTrackers.Stages[i].InstanceSpec
+		}
+	}
+	return &g.ytsaurus.Spec.QueryTrackers.InstanceSpec
+}
+
+func (g *Generator) GetQueryTrackerAddresses(stage string) []string {
+	names := make([]string, 0, g.getQueryTrackerInstanceSpec(stage).InstanceCount)
+	for _, podName := range g.GetQueryTrackerPodNames(stage) {
 		names = append(names, fmt.Sprintf("%s.%s.%s.svc.%s:%d",
 			podName,
-			g.GetQueryTrackerServiceName(),
+			g.GetQueryTrackerServiceName(stage),
 			g.ytsaurus.Namespace,
 			g.clusterDomain,
 			consts.QueryTrackerRPCPort))
@@ -153,6 +186,7 @@ func (g *Generator) fillDriver(c *Driver) {
 	c.PrimaryMaster.Addresses = g.getMasterAddresses()
 	c.PrimaryMaster.CellID = generateCellID(g.ytsaurus.Spec.PrimaryMasters.CellTag)
 	g.fillPrimaryMaster(&c.PrimaryMaster)
+	g.fillSecondaryMasters(&c.SecondaryMasters)
 }
 
 func (g *BaseGenerator) fillAddressResolver(c *AddressResolver) {
@@ -182,8 +216,26 @@ func (g *BaseGenerator) fillPrimaryMaster(c *MasterCell) {
 	c.CellID = generateCellID(g.masterConnectionSpec.CellTag)
 }
 
+func (g *BaseGenerator) fillSecondaryMasters(c *[]MasterCell) {
+	if len(g.secondaryMastersSpecs) == 0 {
+		return
+	}
+	cells := make([]MasterCell, 0, len(g.secondaryMastersSpecs))
+	for idx := range g.secondaryMastersSpecs {
+		spec := &g.secondaryMastersSpecs[idx]
+		addresses := g.getSecondaryMasterAddresses(spec)
+		cells = append(cells, MasterCell{
+			AddressList: AddressList{Addresses: addresses},
+			Peers:       getMasterCellHydraPeers(addresses),
+			CellID:      generateCellID(spec.CellTag),
+		})
+	}
+	*c = cells
+}
+
 func (g *BaseGenerator) fillClusterConnection(c *ClusterConnection, s *ytv1.RPCTransportSpec) {
 	g.fillPrimaryMaster(&c.PrimaryMaster)
+	g.fillSecondaryMasters(&c.SecondaryMasters)
 	c.ClusterName = g.key.Name
 	c.DiscoveryConnection.Addresses = g.getDiscoveryAddresses()
 	g.fillClusterConnectionEncryption(c, s)
@@ -230,10 +282,9 @@ func (g *Generator) fillBusEncryption(b *Bus, s *ytv1.RPCTransportSpec) {
 }
 
 func (g *BaseGenerator) fillBusServer(c *CommonServer, s *ytv1.RPCTransportSpec) {
-	if s == nil {
-		// Use common bus transport config
-		s = g.commonSpec.NativeTransport
-	}
+	isCommon := s == nil || s == g.commonSpec.NativeTransport
+	// Use common bus transport config if component has none.
+	s = GetNativeTransport(&g.commonSpec, s)
 	if s == nil || s.TLSSecret == nil {
 		return
 	}
@@ -243,7 +294,7 @@ func (g *BaseGenerator) fillBusServer(c *CommonServer, s *ytv1.RPCTransportSpec)
 	}
 
 	// FIXME(khlebnikov): some clients does not support TLS yet
-	if s.TLSRequired && s != g.commonSpec.NativeTransport {
+	if s.TLSRequired && !isCommon {
 		c.BusServer.EncryptionMode = EncryptionModeRequired
 	} else {
 		c.BusServer.EncryptionMode = EncryptionModeOptional
@@ -258,10 +309,8 @@ func (g *BaseGenerator) fillBusServer(c *CommonServer, s *ytv1.RPCTransportSpec)
 }
 
 func (g *BaseGenerator) fillClusterConnectionEncryption(c *ClusterConnection, s *ytv1.RPCTransportSpec) {
-	if s == nil {
-		// Use common bus transport config
-		s = g.commonSpec.NativeTransport
-	}
+	// Use common bus transport config if component has none.
+	s = GetNativeTransport(&g.commonSpec, s)
 	if s == nil || s.TLSSecret == nil {
 		return
 	}
@@ -274,6 +323,11 @@ func (g *BaseGenerator) fillClusterConnectionEncryption(c *ClusterConnection, s
 		c.BusClient.CA = &PemBlob{
 			FileName: path.Join(consts.CABundleMountPoint, consts.CABundleFileName),
 		}
+	} else if s.TLSSecret.Name == consts.NativeTransportCertificateSecretName {
+		// Issuer of the managed certificate is trusted via "ca.crt" of the certificate secret.
+		c.BusClient.CA = &PemBlob{
+			FileName: path.Join(consts.BusSecretMountPoint, consts.CABundleFileName),
+		}
 	} else {
 		c.BusClient.CA = &PemBlob{
 			FileName: consts.DefaultCABundlePath,
@@ -311,28 +365,49 @@ func (g *Generator) GetClusterConnection() ([]byte, error) {
 	return marshallYsonConfig(c)
 }
 
+// GetRemoteClusterConnection returns connection of the remote cluster as it is registered in //sys/clusters.
+// The connection is built from the RemoteYtsaurus only, settings of the local cluster are not applied to it.
+func (g *Generator) GetRemoteClusterConnection(remote *ytv1.RemoteYtsaurus) ClusterConnection {
+	rg := NewRemoteBaseGenerator(
+		types.NamespacedName{Namespace: remote.Namespace, Name: remote.Name},
+		g.clusterDomain,
+		ytv1.CommonSpec{},
+		remote.Spec.MasterConnectionSpec,
+		remote.Spec.SecondaryMasters,
+		&remote.Spec.MasterCachesSpec,
+	)
+	var c ClusterConnection
+	rg.fillClusterConnection(&c, nil)
+	return c
+}
+
 func (g *Generator) GetStrawberryControllerConfig() ([]byte, error) {
 	var resolver AddressResolver
 	g.fillAddressResolver(&resolver)
-	c, err := getStrawberryController(resolver)
+	spec := g.ytsaurus.Spec.StrawberryController
+	c, err := getStrawberryController(resolver, spec)
 	if err != nil {
 		return nil, err
 	}
 	proxy := g.GetHTTPProxiesAddress(consts.DefaultHTTPProxyRole)
 	c.LocationProxies = []string{proxy}
+	aliases := []string{g.ytsaurus.Name}
+	if spec != nil {
+		aliases = append(aliases, spec.LocationAliases...)
+	}
 	c.HTTPLocationAliases = map[string][]string{
-		proxy: []string{g.ytsaurus.Name},
+		proxy: aliases,
 	}
 	return marshallYsonConfig(c)
 }
 
 func (g *Generator) GetChytInitClusterConfig() ([]byte, error) {
-	c := getChytInitCluster()
+	c := getChytInitCluster(g.ytsaurus.Spec.StrawberryController)
 	c.Proxy = g.GetHTTPProxiesAddress(consts.DefaultHTTPProxyRole)
 	return marshallYsonConfig(c)
 }
 
-func (g *Generator) getMasterConfigImpl(spec *ytv1.MastersSpec) (MasterServer, error) {
+func (g *Generator) getMasterConfigImpl(spec *ytv1.MastersSpec, podFqdnSuffix string) (MasterServer, error) {
 	c, err := getMasterServerCarcass(spec)
 	if err != nil {
 		return MasterServer{}, err
@@ -341,6 +416,7 @@ func (g *Generator) getMasterConfigImpl(spec *ytv1.MastersSpec) (MasterServer, e
 	g.fillCommonService(&c.CommonServer, &spec.InstanceSpec)
 	g.fillBusServer(&c.CommonServer, spec.NativeTransport)
 	g.fillPrimaryMaster(&c.PrimaryMaster)
+	g.fillSecondaryMasters(&c.SecondaryMasters)
 	configureMasterServerCypressManager(g.GetMaxReplicationFactor(), &c.CypressManager)
 
 	// COMPAT(l0kix2): remove that after we drop support for specifying host network without master host addresses.
@@ -353,14 +429,22 @@ func (g *Generator) getMasterConfigImpl(spec *ytv1.MastersSpec) (MasterServer, e
 		// POD_NAME is set to pod name through downward API env var and substituted during
 		// config postprocessing.
 		c.AddressResolver.LocalhostNameOverride = ptr.To(
-			fmt.Sprintf("%v.%v", "{K8S_POD_NAME}", g.getMasterPodFqdnSuffix()))
+			fmt.Sprintf("%v.%v", "{K8S_POD_NAME}", podFqdnSuffix))
 	}
 
 	return c, nil
 }
 
 func (g *Generator) GetMasterConfig(spec *ytv1.MastersSpec) ([]byte, error) {
-	c, err := g.getMasterConfigImpl(spec)
+	c, err := g.getMasterConfigImpl(spec, g.getMasterPodFqdnSuffix())
+	if err != nil {
+		return nil, err
+	}
+	return marshallYsonConfig(c)
+}
+
+func (g *Generator) GetSecondaryMasterConfig(spec *ytv1.MastersSpec) ([]byte, error) {
+	c, err := g.getMasterConfigImpl(spec, g.getSecondaryMasterPodFqdnSuffix(spec))
 	if err != nil {
 		return nil, err
 	}
@@ -589,6 +673,7 @@ func (g *Generator) getHTTPProxyConfigImpl(spec *ytv1.HTTPProxiesSpec) (HTTPProx
 }
 
 func (g *Generator) GetHTTPProxyConfig(spec ytv1.HTTPProxiesSpec) ([]byte, error) {
+	spec.Transport = GetHTTPTransport(&g.commonSpec, spec.Transport)
 	c, err := g.getHTTPProxyConfigImpl(&spec)
 	if err != nil {
 		return nil, err
@@ -597,14 +682,18 @@ func (g *Generator) GetHTTPProxyConfig(spec ytv1.HTTPProxiesSpec) ([]byte, error
 	return marshallYsonConfig(c)
 }
 
-func (g *Generator) getQueryTrackerConfigImpl(spec *ytv1.QueryTrackerSpec) (QueryTrackerServer, error) {
+func (g *Generator) getQueryTrackerConfigImpl(spec *ytv1.InstanceSpec, stage string) (QueryTrackerServer, error) {
 	c, err := getQueryTrackerServerCarcass(spec)
 	if err != nil {
 		return c, err
 	}
-	g.fillCommonService(&c.CommonServer, &spec.InstanceSpec)
+	g.fillCommonService(&c.CommonServer, spec)
 	g.fillBusServer(&c.CommonServer, spec.NativeTransport)
 
+	if stage != consts.DefaultQueryTrackerStage {
+		c.Root = g.GetQueryTrackerStageRoot(stage)
+	}
+
 	return c, nil
 }
 
@@ -613,7 +702,16 @@ func (g *Generator) GetQueryTrackerConfig(spec *ytv1.QueryTrackerSpec) ([]byte,
 		return []byte{}, nil
 	}
 
-	c, err := g.getQueryTrackerConfigImpl(spec)
+	c, err := g.getQueryTrackerConfigImpl(&spec.InstanceSpec, consts.DefaultQueryTrackerStage)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshallYsonConfig(c)
+}
+
+func (g *Generator) GetQueryTrackerStageConfig(spec *ytv1.QueryTrackerStageSpec) ([]byte, error) {
+	c, err := g.getQueryTrackerConfigImpl(&spec.InstanceSpec, spec.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -646,7 +744,7 @@ func (g *Generator) GetQueueAgentConfig(spec *ytv1.QueueAgentSpec) ([]byte, erro
 	return marshallYsonConfig(c)
 }
 
-func (g *Generator) getYQLAgentConfigImpl(spec *ytv1.YQLAgentSpec) (YQLAgentServer, error) {
+func (g *Generator) getYQLAgentConfigImpl(spec *ytv1.YQLAgentSpec, remoteClusters []ytv1.RemoteYtsaurus) (YQLAgentServer, error) {
 	c, err := getYQLAgentServerCarcass(spec)
 	if err != nil {
 		return c, err
@@ -666,23 +764,34 @@ func (g *Generator) getYQLAgentConfigImpl(spec *ytv1.YQLAgentSpec) (YQLAgentServ
 	c.YQLAgent.AdditionalClusters = map[string]string{
 		g.ytsaurus.Name: g.GetHTTPProxiesServiceAddress(consts.DefaultHTTPProxyRole),
 	}
+
+	for _, remote := range remoteClusters {
+		if remote.Spec.HTTPProxyAddress == "" {
+			continue
+		}
+		c.YQLAgent.GatewayConfig.ClusterMapping = append(c.YQLAgent.GatewayConfig.ClusterMapping, ClusterMapping{
+			Name:    remote.Name,
+			Cluster: remote.Spec.HTTPProxyAddress,
+		})
+		c.YQLAgent.AdditionalClusters[remote.Name] = remote.Spec.HTTPProxyAddress
+	}
 	c.YQLAgent.DefaultCluster = g.ytsaurus.Name
 
 	return c, nil
 }
 
-func (g *Generator) GetYQLAgentConfig(spec *ytv1.YQLAgentSpec) ([]byte, error) {
+func (g *Generator) GetYQLAgentConfig(spec *ytv1.YQLAgentSpec, remoteClusters []ytv1.RemoteYtsaurus) ([]byte, error) {
 	if spec == nil {
 		return []byte{}, nil
 	}
-	c, err := g.getYQLAgentConfigImpl(spec)
+	c, err := g.getYQLAgentConfigImpl(spec, remoteClusters)
 	if err != nil {
 		return nil, err
 	}
 	return marshallYsonConfig(c)
 }
 
-func (g *Generator) GetUIClustersConfig() ([]byte, error) {
+func (g *Generator) GetUIClustersConfig(remoteClusters []ytv1.RemoteYtsaurus) ([]byte, error) {
 	if g.ytsaurus.Spec.UI == nil {
 		return []byte{}, nil
 	}
@@ -704,8 +813,25 @@ func (g *Generator) GetUIClustersConfig() ([]byte, error) {
 		c.Description = *g.ytsaurus.Spec.UI.Description
 	}
 
+	clusters := []UICluster{c}
+	// Remote clusters without HTTP proxies are not reachable from UI.
+	for _, remote := range remoteClusters {
+		if remote.Spec.HTTPProxyAddress == "" {
+			continue
+		}
+		rc := getUIClusterCarcass()
+		rc.ID = remote.Name
+		rc.Name = remote.Name
+		rc.Proxy = remote.Spec.HTTPProxyAddress
+		rc.Secure = remote.Spec.HTTPProxySecure
+		rc.Group = c.Group
+		rc.Description = ""
+		rc.PrimaryMaster.CellTag = remote.Spec.CellTag
+		clusters = append(clusters, rc)
+	}
+
 	return marshallYsonConfig(UIClusters{
-		Clusters: []UICluster{c},
+		Clusters: clusters,
 	})
 }
 
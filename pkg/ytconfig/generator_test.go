@@ -123,6 +123,13 @@ func TestGetChytInitClusterConfig(t *testing.T) {
 	canonize.Assert(t, cfg)
 }
 
+func TestGetChytInitClusterWithFamiliesConfig(t *testing.T) {
+	g := NewGenerator(withStrawberryFamilies(getYtsaurusWithEverything()), testClusterDomain)
+	cfg, err := g.GetChytInitClusterConfig()
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
 func TestGetControllerAgentsConfig(t *testing.T) {
 	ytsaurus := getYtsaurusWithEverything()
 	g := NewGenerator(ytsaurus, testClusterDomain)
@@ -162,6 +169,7 @@ func TestGetDataNodeWithoutYtsaurusConfig(t *testing.T) {
 		testClusterDomain,
 		getCommonSpec(),
 		getMasterConnectionSpecWithFixedMasterHosts(),
+		nil,
 		getMasterCachesSpecWithFixedHosts(),
 	)
 	cfg, err := g.GetDataNodeConfig(getDataNodeSpec(testLocationChunkStore))
@@ -249,6 +257,7 @@ func TestGetExecNodeWithoutYtsaurusConfig(t *testing.T) {
 		testClusterDomain,
 		getCommonSpec(),
 		getMasterConnectionSpecWithFixedMasterHosts(),
+		nil,
 		getMasterCachesSpecWithFixedHosts(),
 	)
 	cfg, err := g.GetExecNodeConfig(getExecNodeSpec(nil))
@@ -279,6 +288,50 @@ func TestGetMasterWithFixedHostsConfig(t *testing.T) {
 	canonize.Assert(t, cfg)
 }
 
+func TestGetMasterWithManagedCertificatesConfig(t *testing.T) {
+	ytsaurus := withManagedCertificates(getYtsaurus())
+	g := NewGenerator(ytsaurus, testClusterDomain)
+	cfg, err := g.GetMasterConfig(&ytsaurus.Spec.PrimaryMasters)
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetHTTPProxyWithManagedCertificatesConfig(t *testing.T) {
+	g := NewGenerator(withManagedCertificates(getYtsaurusWithEverything()), testClusterDomain)
+	cfg, err := g.GetHTTPProxyConfig(getHTTPProxySpec())
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetCertificateDNSNames(t *testing.T) {
+	ytsaurus := withManagedCertificates(getYtsaurusWithEverything())
+	ytsaurus.Spec.HTTPProxies = []ytv1.HTTPProxiesSpec{getHTTPProxySpec()}
+	g := NewGenerator(ytsaurus, testClusterDomain)
+	nativeDNSNames := g.GetNativeTransportCertificateDNSNames()
+	require.Contains(t, nativeDNSNames, "*.masters-test.fake.svc.fake.zone")
+	require.Contains(t, nativeDNSNames, "*.controller-agents.fake.svc.fake.zone")
+	require.Contains(t, nativeDNSNames, "*.master-caches-test.fake.svc.fake.zone")
+	httpsDNSNames := g.GetHTTPSCertificateDNSNames()
+	require.Contains(t, httpsDNSNames, "http-proxies-control-lb-test.fake.svc.fake.zone")
+	require.Contains(t, httpsDNSNames, "*.http-proxies-control-test.fake.svc.fake.zone")
+	require.Contains(t, httpsDNSNames, "yt.example.com")
+}
+
+func TestGetSecondaryMasterConfig(t *testing.T) {
+	ytsaurus := withSecondaryMasters(getYtsaurus())
+	g := NewGenerator(ytsaurus, testClusterDomain)
+	cfg, err := g.GetSecondaryMasterConfig(&ytsaurus.Spec.SecondaryMasters[0])
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetClusterConnectionWithSecondaryMasters(t *testing.T) {
+	g := NewGenerator(withSecondaryMasters(getYtsaurus()), testClusterDomain)
+	cfg, err := g.GetClusterConnection()
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
 func TestGetNativeClientConfig(t *testing.T) {
 	g := NewGenerator(getYtsaurusWithEverything(), testClusterDomain)
 	cfg, err := g.GetNativeClientConfig()
@@ -294,6 +347,14 @@ func TestGetQueryTrackerConfig(t *testing.T) {
 	canonize.Assert(t, cfg)
 }
 
+func TestGetQueryTrackerStageConfig(t *testing.T) {
+	ytsaurus := withQueryTrackerStages(getYtsaurusWithEverything())
+	g := NewGenerator(ytsaurus, testClusterDomain)
+	cfg, err := g.GetQueryTrackerStageConfig(&ytsaurus.Spec.QueryTrackers.Stages[0])
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
 func TestGetQueueAgentConfig(t *testing.T) {
 	ytsaurus := getYtsaurusWithEverything()
 	g := NewGenerator(ytsaurus, testClusterDomain)
@@ -339,6 +400,13 @@ func TestGetStrawberryControllerConfig(t *testing.T) {
 	canonize.Assert(t, cfg)
 }
 
+func TestGetStrawberryControllerWithFamiliesConfig(t *testing.T) {
+	g := NewGenerator(withStrawberryFamilies(getYtsaurusWithEverything()), testClusterDomain)
+	cfg, err := g.GetStrawberryControllerConfig()
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
 func TestGetTabletNodeConfig(t *testing.T) {
 	g := NewLocalNodeGenerator(getYtsaurusWithEverything(), testClusterDomain)
 	cfg, err := g.GetTabletNodeConfig(getTabletNodeSpec())
@@ -352,6 +420,7 @@ func TestGetTabletNodeWithoutYtsaurusConfig(t *testing.T) {
 		testClusterDomain,
 		getCommonSpec(),
 		getMasterConnectionSpecWithFixedMasterHosts(),
+		nil,
 		getMasterCachesSpecWithFixedHosts(),
 	)
 	cfg, err := g.GetTabletNodeConfig(getTabletNodeSpec())
@@ -368,14 +437,21 @@ func TestGetTCPProxyConfig(t *testing.T) {
 
 func TestGetUIClustersConfig(t *testing.T) {
 	g := NewGenerator(getYtsaurusWithEverything(), testClusterDomain)
-	cfg, err := g.GetUIClustersConfig()
+	cfg, err := g.GetUIClustersConfig(nil)
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetUIClustersConfigWithRemoteClusters(t *testing.T) {
+	g := NewGenerator(withUI(getYtsaurus()), testClusterDomain)
+	cfg, err := g.GetUIClustersConfig([]ytv1.RemoteYtsaurus{getRemoteYtsaurus()})
 	require.NoError(t, err)
 	canonize.Assert(t, cfg)
 }
 
 func TestGetUIClustersConfigWithSettings(t *testing.T) {
 	g := NewGenerator(withUICustom(getYtsaurus()), testClusterDomain)
-	cfg, err := g.GetUIClustersConfig()
+	cfg, err := g.GetUIClustersConfig(nil)
 	require.NoError(t, err)
 	canonize.Assert(t, cfg)
 }
@@ -397,11 +473,52 @@ func TestGetUICustomConfigWithSettings(t *testing.T) {
 func TestGetYQLAgentConfig(t *testing.T) {
 	ytsaurus := getYtsaurusWithEverything()
 	g := NewGenerator(ytsaurus, testClusterDomain)
-	cfg, err := g.GetYQLAgentConfig(ytsaurus.Spec.YQLAgents)
+	cfg, err := g.GetYQLAgentConfig(ytsaurus.Spec.YQLAgents, nil)
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetYQLAgentConfigWithRemoteClusters(t *testing.T) {
+	ytsaurus := getYtsaurusWithEverything()
+	g := NewGenerator(ytsaurus, testClusterDomain)
+	cfg, err := g.GetYQLAgentConfig(ytsaurus.Spec.YQLAgents, []ytv1.RemoteYtsaurus{getRemoteYtsaurus()})
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetRemoteClusterConnection(t *testing.T) {
+	g := NewGenerator(getYtsaurus(), testClusterDomain)
+	remote := getRemoteYtsaurus()
+	cfg, err := marshallYsonConfig(g.GetRemoteClusterConnection(&remote))
+	require.NoError(t, err)
+	canonize.Assert(t, cfg)
+}
+
+func TestGetRemoteClusterConnectionWithSecondaryMasters(t *testing.T) {
+	g := NewGenerator(getYtsaurus(), testClusterDomain)
+	remote := getRemoteYtsaurus()
+	remote.Spec.SecondaryMasters = []ytv1.MasterConnectionSpec{
+		{
+			CellTag:       3,
+			HostAddresses: []string{"ms-secondary-0.remote.example.com", "ms-secondary-1.remote.example.com"},
+		},
+	}
+	cfg, err := marshallYsonConfig(g.GetRemoteClusterConnection(&remote))
 	require.NoError(t, err)
 	canonize.Assert(t, cfg)
 }
 
+func TestGetRemoteClusterConnectionIgnoresLocalSettings(t *testing.T) {
+	remote := getRemoteYtsaurus()
+	expected := NewGenerator(getYtsaurus(), testClusterDomain).GetRemoteClusterConnection(&remote)
+
+	ytsaurus := withManagedCertificates(getYtsaurus())
+	ytsaurus.Spec.UseShortNames = !ytsaurus.Spec.UseShortNames
+	ytsaurus.Spec.CABundle = &corev1.LocalObjectReference{Name: "ca-bundle"}
+	g := NewGenerator(ytsaurus, testClusterDomain)
+	require.Equal(t, expected, g.GetRemoteClusterConnection(&remote))
+}
+
 func TestResolverOptionsKeepSocketAndForceTCP(t *testing.T) {
 	ytsaurus := getYtsaurusWithEverything()
 	ytsaurus.Spec.CommonSpec.ForceTCP = ptr.To(true)
@@ -575,6 +692,31 @@ func withDiscovery(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
 	return ytsaurus
 }
 
+func withQueryTrackerStages(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
+	stage := ytv1.QueryTrackerStageSpec{Name: "testing", InstanceSpec: testBasicInstanceSpec}
+	stage.Image = ptr.To("ytsaurus/ytsaurus:testing")
+	stage.MonitoringPort = ptr.To(int32(consts.QueryTrackerMonitoringPort))
+	ytsaurus.Spec.QueryTrackers.Stages = []ytv1.QueryTrackerStageSpec{stage}
+	return ytsaurus
+}
+
+func getRemoteYtsaurus() ytv1.RemoteYtsaurus {
+	return ytv1.RemoteYtsaurus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "fake",
+		},
+		Spec: ytv1.RemoteYtsaurusSpec{
+			MasterConnectionSpec: ytv1.MasterConnectionSpec{
+				CellTag:       2,
+				HostAddresses: []string{"ms-0.remote.example.com"},
+			},
+			HTTPProxyAddress: "http-proxies.remote.example.com",
+			HTTPProxySecure:  true,
+		},
+	}
+}
+
 func withQueryTracker(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
 	ytsaurus.Spec.QueryTrackers = &ytv1.QueryTrackerSpec{InstanceSpec: testBasicInstanceSpec}
 	ytsaurus.Spec.QueryTrackers.InstanceSpec.MonitoringPort = ptr.To(int32(consts.QueryTrackerMonitoringPort))
@@ -596,6 +738,20 @@ func withStrawberry(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
 	return ytsaurus
 }
 
+func withStrawberryFamilies(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
+	ytsaurus.Spec.StrawberryController.Root = "//sys/strawberry_test"
+	ytsaurus.Spec.StrawberryController.Stage = "testing"
+	ytsaurus.Spec.StrawberryController.LocationAliases = []string{"test-alias"}
+	ytsaurus.Spec.StrawberryController.Families = []ytv1.StrawberryControllerFamilySpec{
+		{Name: "chyt"},
+		{
+			Name:   "jupyt",
+			Config: "{default_network_project=jupyt; address_resolver={enable_ipv6=%true}}",
+		},
+	}
+	return ytsaurus
+}
+
 func withScheduler(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
 	ytsaurus.Spec.Schedulers = &ytv1.SchedulersSpec{InstanceSpec: testBasicInstanceSpec}
 	ytsaurus.Spec.Schedulers.InstanceSpec.MonitoringPort = ptr.To(int32(consts.SchedulerMonitoringPort))
@@ -607,6 +763,23 @@ func withFixedMasterHosts(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
 	return ytsaurus
 }
 
+func withManagedCertificates(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
+	ytsaurus.Spec.ManagedCertificates = &ytv1.ManagedCertificatesSpec{
+		NativeTransport: true,
+		HTTPS:           true,
+		ExtraDNSNames:   []string{"yt.example.com"},
+	}
+	return ytsaurus
+}
+
+func withSecondaryMasters(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
+	secondaryMasters := ytsaurus.Spec.PrimaryMasters.DeepCopy()
+	secondaryMasters.CellTag = 2
+	secondaryMasters.InstanceCount = 3
+	ytsaurus.Spec.SecondaryMasters = []ytv1.MastersSpec{*secondaryMasters}
+	return ytsaurus
+}
+
 func withTCPProxies(ytsaurus *ytv1.Ytsaurus) *ytv1.Ytsaurus {
 	ytsaurus.Spec.TCPProxies = []ytv1.TCPProxiesSpec{
 		{
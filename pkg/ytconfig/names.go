@@ -3,6 +3,7 @@ package ytconfig
 import (
 	"fmt"
 
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
 	"github.com/ytsaurus/ytsaurus-k8s-operator/pkg/consts"
 )
 
@@ -18,6 +19,10 @@ func (g *BaseGenerator) GetMastersStatefulSetName() string {
 	return g.getName("ms")
 }
 
+func (g *BaseGenerator) GetSecondaryMastersStatefulSetName(cellTag int16) string {
+	return g.getName(fmt.Sprintf("sms-%d", cellTag))
+}
+
 func (g *BaseGenerator) GetDiscoveryStatefulSetName() string {
 	return g.getName("ds")
 }
@@ -30,6 +35,10 @@ func (g *BaseGenerator) GetMastersServiceName() string {
 	return g.getName("masters")
 }
 
+func (g *BaseGenerator) GetSecondaryMastersServiceName(cellTag int16) string {
+	return g.getName(fmt.Sprintf("secondary-masters-%d", cellTag))
+}
+
 func (g *BaseGenerator) GetDiscoveryServiceName() string {
 	return g.getName("discovery")
 }
@@ -47,6 +56,15 @@ func (g *BaseGenerator) GetMasterPodNames() []string {
 	return podNames
 }
 
+func (g *BaseGenerator) GetSecondaryMasterPodNames(spec *ytv1.MastersSpec) []string {
+	podNames := make([]string, 0, spec.InstanceCount)
+	for i := 0; i < int(spec.InstanceCount); i++ {
+		podNames = append(podNames, fmt.Sprintf("%s-%d", g.GetSecondaryMastersStatefulSetName(spec.CellTag), i))
+	}
+
+	return podNames
+}
+
 func (g *BaseGenerator) GetDiscoveryPodNames() []string {
 	podNames := make([]string, 0, g.discoveryInstanceCount)
 	for i := 0; i < int(g.discoveryInstanceCount); i++ {
@@ -56,10 +74,11 @@ func (g *BaseGenerator) GetDiscoveryPodNames() []string {
 	return podNames
 }
 
-func (g *Generator) GetQueryTrackerPodNames() []string {
-	podNames := make([]string, 0, g.ytsaurus.Spec.QueryTrackers.InstanceSpec.InstanceCount)
-	for i := 0; i < int(g.ytsaurus.Spec.QueryTrackers.InstanceSpec.InstanceCount); i++ {
-		podNames = append(podNames, fmt.Sprintf("%s-%d", g.GetQueryTrackerStatefulSetName(), i))
+func (g *Generator) GetQueryTrackerPodNames(stage string) []string {
+	instanceCount := g.getQueryTrackerInstanceSpec(stage).InstanceCount
+	podNames := make([]string, 0, instanceCount)
+	for i := 0; i < int(instanceCount); i++ {
+		podNames = append(podNames, fmt.Sprintf("%s-%d", g.GetQueryTrackerStatefulSetName(stage), i))
 	}
 
 	return podNames
@@ -128,6 +147,11 @@ func (g *Generator) GetSchedulerServiceName() string {
 	return g.getName("schedulers")
 }
 
+// GetControllerAgentsServiceName returns name of controller agents headless service, it does not depend on short names.
+func (g *Generator) GetControllerAgentsServiceName() string {
+	return "controller-agents"
+}
+
 func (g *Generator) GetRPCProxiesStatefulSetName(role string) string {
 	return g.getName(g.FormatComponentStringWithDefault("rp", role))
 }
@@ -152,12 +176,27 @@ func (g *Generator) GetTCPProxiesHeadlessServiceName(role string) string {
 	return g.getName(g.FormatComponentStringWithDefault("tcp-proxies", role))
 }
 
-func (g *Generator) GetQueryTrackerStatefulSetName() string {
-	return g.getName("qt")
+func (g *Generator) formatQueryTrackerStage(base string, stage string) string {
+	if stage != consts.DefaultQueryTrackerStage {
+		return fmt.Sprintf("%s-%s", base, stage)
+	}
+	return base
+}
+
+func (g *Generator) GetQueryTrackerStatefulSetName(stage string) string {
+	return g.getName(g.formatQueryTrackerStage("qt", stage))
 }
 
-func (g *Generator) GetQueryTrackerServiceName() string {
-	return g.getName("query-trackers")
+func (g *Generator) GetQueryTrackerServiceName(stage string) string {
+	return g.getName(g.formatQueryTrackerStage("query-trackers", stage))
+}
+
+// GetQueryTrackerStageRoot returns Cypress path where query tracker of the stage keeps its state.
+func (g *Generator) GetQueryTrackerStageRoot(stage string) string {
+	if stage != consts.DefaultQueryTrackerStage {
+		return fmt.Sprintf("//sys/query_tracker_%s", stage)
+	}
+	return "//sys/query_tracker"
 }
 
 func (g *Generator) GetQueueAgentStatefulSetName() string {
@@ -192,6 +231,28 @@ func (g *NodeGenerator) GetTabletNodesServiceName(name string) string {
 	return g.getName(g.FormatComponentStringWithDefault("tablet-nodes", name))
 }
 
+func (g *NodeGenerator) GetDataNodePodAddress(name string, podName string) string {
+	return g.getNodePodAddress(podName, g.GetDataNodesServiceName(name), consts.DataNodeRPCPort)
+}
+
+func (g *NodeGenerator) GetExecNodePodAddress(name string, podName string) string {
+	return g.getNodePodAddress(podName, g.GetExecNodesServiceName(name), consts.ExecNodeRPCPort)
+}
+
+func (g *NodeGenerator) GetTabletNodePodAddress(name string, podName string) string {
+	return g.getNodePodAddress(podName, g.GetTabletNodesServiceName(name), consts.TabletNodeRPCPort)
+}
+
+// getNodePodAddress returns address of the node as it is registered in //sys/cluster_nodes.
+func (g *BaseGenerator) getNodePodAddress(podName string, serviceName string, port int) string {
+	return fmt.Sprintf("%s.%s.%s.svc.%s:%d",
+		podName,
+		serviceName,
+		g.key.Namespace,
+		g.clusterDomain,
+		port)
+}
+
 func (g *BaseGenerator) FormatComponentStringWithDefault(base string, name string) string {
 	if name != consts.DefaultName {
 		return fmt.Sprintf("%s-%s", base, name)
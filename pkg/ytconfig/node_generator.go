@@ -15,6 +15,7 @@ func NewRemoteNodeGenerator(
 	clusterDomain string,
 	commonSpec ytv1.CommonSpec,
 	masterConnectionSpec ytv1.MasterConnectionSpec,
+	secondaryMasterConnectionSpecs []ytv1.MasterConnectionSpec,
 	masterCachesSpec *ytv1.MasterCachesSpec,
 ) *NodeGenerator {
 	baseGenerator := NewRemoteBaseGenerator(
@@ -22,6 +23,7 @@ func NewRemoteNodeGenerator(
 		clusterDomain,
 		commonSpec,
 		masterConnectionSpec,
+		secondaryMasterConnectionSpecs,
 		masterCachesSpec,
 	)
 	return &NodeGenerator{
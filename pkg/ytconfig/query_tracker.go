@@ -7,21 +7,22 @@ import (
 
 type QueryTrackerServer struct {
 	CommonServer
+	Root                       string `yson:"root,omitempty"`
 	User                       string `yson:"user"`
 	CreateStateTablesOnStartup bool   `yson:"create_state_tables_on_startup"`
 }
 
-func getQueryTrackerLogging(spec *ytv1.QueryTrackerSpec) Logging {
+func getQueryTrackerLogging(spec *ytv1.InstanceSpec) Logging {
 	return createLogging(
-		&spec.InstanceSpec,
+		spec,
 		"query-tracker",
 		[]ytv1.TextLoggerSpec{defaultInfoLoggerSpec(), defaultStderrLoggerSpec()})
 }
 
-func getQueryTrackerServerCarcass(spec *ytv1.QueryTrackerSpec) (QueryTrackerServer, error) {
+func getQueryTrackerServerCarcass(spec *ytv1.InstanceSpec) (QueryTrackerServer, error) {
 	var c QueryTrackerServer
 	c.RPCPort = consts.QueryTrackerRPCPort
-	c.MonitoringPort = *spec.InstanceSpec.MonitoringPort
+	c.MonitoringPort = *spec.MonitoringPort
 
 	c.User = "query_tracker"
 	c.CreateStateTablesOnStartup = true
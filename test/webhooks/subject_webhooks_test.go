@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Test for YtsaurusUser and YtsaurusGroup webhooks", func() {
+	const namespace string = "default"
+
+	Context("When setting up the test environment", func() {
+		It("Should not accept a YtsaurusUser of a built-in user", func() {
+			user := &ytv1.YtsaurusUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "operator-robot", Namespace: namespace},
+				Spec: ytv1.YtsaurusUserSpec{
+					Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+					Name:     "robot-ytsaurus-k8s-operator",
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, user)).Should(MatchError(ContainSubstring("is built-in")))
+		})
+
+		It("Should not accept a YtsaurusGroup of a built-in group", func() {
+			group := &ytv1.YtsaurusGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "superusers", Namespace: namespace},
+				Spec: ytv1.YtsaurusGroupSpec{
+					Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, group)).Should(MatchError(ContainSubstring("is built-in")))
+		})
+
+		It("Should not accept a rename of YtsaurusGroup", func() {
+			group := &ytv1.YtsaurusGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "analysts", Namespace: namespace},
+				Spec: ytv1.YtsaurusGroupSpec{
+					Ytsaurus: &corev1.LocalObjectReference{Name: "ytsaurus"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, group)).Should(Succeed())
+
+			group.Spec.Name = "data-analysts"
+			Expect(k8sClient.Update(ctx, group)).Should(MatchError(ContainSubstring("Could not be changed")))
+		})
+	})
+})
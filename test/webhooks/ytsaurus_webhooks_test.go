@@ -1,6 +1,8 @@
 package webhooks
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	ytv1 "github.com/ytsaurus/ytsaurus-k8s-operator/api/v1"
@@ -139,6 +141,61 @@ var _ = Describe("Test for Ytsaurus webhooks", func() {
 			Expect(k8sClient.Update(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.primaryMasters.cellTag")))
 		})
 
+		It("Should not accept cell roles of primary masters", func() {
+			ytsaurus := &ytv1.Ytsaurus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      testutil.YtsaurusName,
+				Namespace: namespace,
+			}, ytsaurus)).Should(Succeed())
+
+			ytsaurus.Spec.PrimaryMasters.CellRoles = []ytv1.MasterCellRole{ytv1.MasterCellRoleChunkHost}
+			Expect(k8sClient.Update(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.primaryMasters.cellRoles: Forbidden")))
+		})
+
+		It("Should not accept canary update without image of the instance group", func() {
+			ytsaurus := &ytv1.Ytsaurus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      testutil.YtsaurusName,
+				Namespace: namespace,
+			}, ytsaurus)).Should(Succeed())
+
+			ytsaurus.Spec.HTTPProxies[0].InstanceCount = 2
+			ytsaurus.Spec.HTTPProxies[0].Canary = &ytv1.CanaryUpdateSpec{InstanceCount: 1}
+			Expect(k8sClient.Update(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.httpProxies[0].image: Required")))
+		})
+
+		It("Should not accept masters resize if master update is not allowed", func() {
+			ytsaurus := &ytv1.Ytsaurus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      testutil.YtsaurusName,
+				Namespace: namespace,
+			}, ytsaurus)).Should(Succeed())
+
+			ytsaurus.Spec.PrimaryMasters.InstanceCount = 3
+			ytsaurus.Spec.EphemeralCluster = true
+			Expect(k8sClient.Update(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.primaryMasters.instanceCount: Forbidden")))
+
+			ytsaurus.Spec.UpdateSelector = ytv1.UpdateSelectorMasterOnly
+			Expect(k8sClient.Update(ctx, ytsaurus)).Should(Succeed())
+		})
+
+		It("Should not accept data nodes scale down during the update", func() {
+			ytsaurus := &ytv1.Ytsaurus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      testutil.YtsaurusName,
+				Namespace: namespace,
+			}, ytsaurus)).Should(Succeed())
+
+			ytsaurus.Status.State = ytv1.ClusterStateUpdating
+			Expect(k8sClient.Status().Update(ctx, ytsaurus)).Should(Succeed())
+
+			ytsaurus.Spec.DataNodes[0].InstanceCount -= 1
+			Expect(k8sClient.Update(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.dataNodes[0].instanceCount: Forbidden")))
+
+			ytsaurus.Spec.DataNodes = nil
+			Expect(k8sClient.Update(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.dataNodes: Forbidden")))
+		})
+
 		It("Should not accept data nodes without chunk locations", func() {
 			ytsaurus := testutil.CreateBaseYtsaurusResource(namespace)
 			ytsaurus.Spec.DataNodes = []ytv1.DataNodesSpec{
@@ -318,6 +375,48 @@ var _ = Describe("Test for Ytsaurus webhooks", func() {
 			Expect(k8sClient.Create(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.primaryMasters.hostAddresses: Invalid value")))
 		})
 
+		It("Should not accept secondary masters with the cell tag of the primary masters", func() {
+			ytsaurus := testutil.CreateBaseYtsaurusResource(namespace)
+			secondaryMasters := ytsaurus.Spec.PrimaryMasters.DeepCopy()
+			ytsaurus.Spec.SecondaryMasters = []ytv1.MastersSpec{*secondaryMasters}
+
+			Expect(k8sClient.Create(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.secondaryMasters[0].cellTag: Duplicate value")))
+		})
+
+		It("Should not accept invalid maintenance windows", func() {
+			ytsaurus := testutil.CreateBaseYtsaurusResource(namespace)
+			ytsaurus.Spec.MaintenanceWindows = []ytv1.MaintenanceWindowSpec{
+				{Schedule: "0 22 * * 1-5", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+			}
+
+			Expect(k8sClient.Create(ctx, ytsaurus)).Should(MatchError(ContainSubstring("invalid time zone")))
+		})
+
+		It("Should not accept duplicate strawberry families", func() {
+			ytsaurus := testutil.WithScheduler(testutil.CreateBaseYtsaurusResource(namespace))
+			ytsaurus.Spec.StrawberryController = &ytv1.StrawberryControllerSpec{
+				Families: []ytv1.StrawberryControllerFamilySpec{{Name: "chyt"}, {Name: "chyt"}},
+			}
+
+			Expect(k8sClient.Create(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.strawberry.families[1].name: Duplicate value")))
+		})
+
+		It("Should not accept query tracker stage named production", func() {
+			ytsaurus := testutil.WithQueryTracker(testutil.WithScheduler(testutil.WithTabletNodes(testutil.CreateBaseYtsaurusResource(namespace))))
+			ytsaurus.Spec.QueryTrackers.Stages = []ytv1.QueryTrackerStageSpec{
+				{Name: "production", InstanceSpec: ytv1.InstanceSpec{InstanceCount: 1}},
+			}
+
+			Expect(k8sClient.Create(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.queryTrackers.stages[0].name: Duplicate value")))
+		})
+
+		It("Should not accept remote cluster with the name of the cluster", func() {
+			ytsaurus := testutil.CreateBaseYtsaurusResource(namespace)
+			ytsaurus.Spec.RemoteClusters = []corev1.LocalObjectReference{{Name: ytsaurus.Name}}
+
+			Expect(k8sClient.Create(ctx, ytsaurus)).Should(MatchError(ContainSubstring("spec.remoteClusters[0].name: Duplicate value")))
+		})
+
 		It("should deny the creation of another YTsaurus CRD in the same namespace", func() {
 			ytsaurus1 := testutil.CreateBaseYtsaurusResource(namespace)
 			Expect(k8sClient.Create(ctx, ytsaurus1)).Should(MatchError(ContainSubstring("already exists")))